@@ -3,40 +3,72 @@ package api
 import (
 	_ "market/api/docs"
 	"market/api/handler"
+	"market/config"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func NewServer(h *handler.Handler) *gin.Engine {
+func NewServer(h *handler.Handler, cfg config.Config) *gin.Engine {
 	r := gin.Default()
 
+	r.Use(h.Idempotency(cfg.IdempotencyTTL))
+
 	r.POST("/branch", h.CreateBranch)
 	r.GET("/branch/:id", h.GetByIDBranch)
 	r.GET("/branch", h.GetListBranch)
 	r.PUT("/branch/:id", h.UpdateBranch)
 	r.DELETE("/branch/:id", h.DeleteBranch)
+	r.GET("/branch/:id/label_template", h.GetLabelTemplate)
+	r.PUT("/branch/:id/label_template", h.SaveLabelTemplate)
 
 	r.POST("/category", h.CreateCategory)
 	r.GET("/category/:id", h.GetByIDCategory)
 	r.GET("/category", h.GetListCategory)
 	r.PUT("/category/:id", h.UpdateCategory)
 	r.DELETE("/category/:id", h.DeleteCategory)
+	r.POST("/category/:id/attribute", h.CreateCategoryAttribute)
+	r.GET("/category/:id/attribute", h.GetListCategoryAttribute)
+	r.PUT("/category_attribute/:id", h.UpdateCategoryAttribute)
+	r.DELETE("/category_attribute/:id", h.DeleteCategoryAttribute)
 
 	r.POST("/product", h.CreateProduct)
+	r.GET("/product/search", h.SearchProduct)
 	r.GET("/product/:id", h.GetByIDProduct)
 	r.GET("/product", h.GetListProduct)
 	r.PUT("/product/:id", h.UpdateProduct)
 	r.DELETE("/product/:id", h.DeleteProduct)
+	r.POST("/product/import", h.ImportProduct)
+	r.POST("/product/:id/approve", h.ApproveProduct)
+	r.POST("/product/:id/merge", h.MergeProduct)
+	r.POST("/product/:id/variant", h.CreateProductVariant)
+	r.POST("/product/:id/image", h.UploadProductImage)
+	r.GET("/product/:id/image", h.GetListProductImage)
+	r.DELETE("/product_image/:id", h.DeleteProductImage)
+	r.POST("/product/:id/price", h.CreateProductPrice)
+	r.GET("/product/:id/price", h.GetListProductPrice)
+	r.GET("/product_price/effective", h.GetEffectivePrice)
+	r.DELETE("/product_price/:id", h.DeleteProductPrice)
+
+	r.GET("/revaluation", h.GetListRevaluation)
+	r.GET("/revaluation/:id", h.GetByIDRevaluation)
+	r.GET("/revaluation/:id/pdf", h.GetPdfRevaluation)
 
 	r.POST("/coming_table", h.CreateComingTable)
 	r.GET("/coming_table/:id", h.GetByIDComingTable)
+	r.GET("/coming_table/:id/full", h.GetFullComingTable)
+	r.GET("/coming_table/:id/pdf", h.GetPdfComingTable)
 	r.GET("/coming_table", h.GetListComingTable)
 	r.PUT("/coming_table/:id", h.UpdateComingTable)
 	r.DELETE("/coming_table/:id", h.DeleteComingTable)
+	r.PUT("/coming_table/:id/status", h.UpdateStatusComingTable)
+	r.POST("/coming_table/:id/cancel", h.CancelComingTable)
+	r.POST("/coming_table/:id/reverse", h.ReverseComingTable)
+	r.POST("/coming_table/:id/import", h.ImportComingTable)
 
 	r.POST("/coming_product/:coming_table_id", h.CreateComingTableProduct)
+	r.POST("/coming_product/:coming_table_id/bulk", h.CreateBulkComingTableProduct)
 
 	r.GET("/coming_product/:id", h.GetByIDComingTableProduct)
 	r.GET("/coming_product", h.GetListComingTableProduct)
@@ -49,6 +81,45 @@ func NewServer(h *handler.Handler) *gin.Engine {
 	r.GET("/remaining", h.GetListRemaining)
 	r.PUT("/remaining/:id", h.UpdateRemaining)
 	r.DELETE("/remaining/:id", h.DeleteRemaining)
+	r.GET("/branch/:id/remaining/pdf", h.GetPdfBranchRemaining)
+
+	r.POST("/supplier", h.CreateSupplier)
+	r.GET("/supplier/:id", h.GetByIDSupplier)
+	r.GET("/supplier", h.GetListSupplier)
+	r.PUT("/supplier/:id", h.UpdateSupplier)
+	r.DELETE("/supplier/:id", h.DeleteSupplier)
+	r.GET("/supplier/:id/import_template", h.GetSupplierImportTemplate)
+	r.PUT("/supplier/:id/import_template", h.SaveSupplierImportTemplate)
+
+	r.POST("/purchase_order", h.CreatePurchaseOrder)
+	r.GET("/purchase_order/:id", h.GetByIDPurchaseOrder)
+	r.GET("/purchase_order", h.GetListPurchaseOrder)
+	r.PUT("/purchase_order/:id", h.UpdatePurchaseOrder)
+	r.DELETE("/purchase_order/:id", h.DeletePurchaseOrder)
+	r.POST("/purchase_order/:id/coming_table", h.CreateComingTableFromOrder)
+
+	r.POST("/label", h.PrintLabels)
+
+	r.POST("/promotion", h.CreatePromotion)
+	r.GET("/promotion/:id", h.GetByIDPromotion)
+	r.GET("/promotion", h.GetListPromotion)
+	r.PUT("/promotion/:id", h.UpdatePromotion)
+	r.DELETE("/promotion/:id", h.DeletePromotion)
+	r.POST("/promotion/evaluate", h.EvaluatePromotion)
+
+	reports := r.Group("/reports")
+	reports.GET("/income", h.IncomeReport)
+	reports.GET("/stock", h.StockReport)
+	reports.GET("/top_products", h.TopProductsReport)
+	reports.GET("/movements", h.MovementReport)
+	reports.GET("/promotions", h.PromotionReport)
+
+	r.GET("/period_closing", h.GetListPeriodClosing)
+	r.POST("/period_closing", h.ClosePeriod)
+	r.POST("/period_closing/reopen", h.ReopenPeriod)
+	r.GET("/period_closing/log", h.GetPeriodClosingLog)
+
+	r.GET("/files/*key", h.GetFile)
 
 	url := ginSwagger.URL("swagger/doc.json") // The url pointing to API definition
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler, url))
@@ -30,6 +30,7 @@ const docTemplate = `{
                 "summary": "LIST BRANCHS",
                 "parameters": [
                     {
+                        "maximum": 500,
                         "minimum": 1,
                         "type": "integer",
                         "default": 10,
@@ -45,6 +46,43 @@ const docTemplate = `{
                         "name": "page",
                         "in": "query"
                     },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of previous page, page is ignored with it",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-created_at",
+                        "description": "one of created_at, name, - prefix sorts descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "return total count, by default only without cursor",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at from, date means its beginning",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at to, date means its end",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter on name, address, phone_number, created_at, updated_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like",
+                        "name": "field[op]",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "search",
@@ -92,6 +130,12 @@ const docTemplate = `{
                 ],
                 "summary": "CREATE BRANCH",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
                     {
                         "description": "branch data",
                         "name": "data",
@@ -193,6 +237,12 @@ const docTemplate = `{
                 ],
                 "summary": "UPDATE BRANCH",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "format": "uuid",
@@ -288,9 +338,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/category": {
+        "/branch/{id}/label_template": {
             "get": {
-                "description": "gets all category based on limit, page and search by name",
+                "description": "gets label size and barcode type of branch, default template is returned when it is not saved",
                 "consumes": [
                     "application/json"
                 ],
@@ -298,38 +348,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "CATEGORY"
+                    "LABEL"
                 ],
-                "summary": "LIST CATEGORY",
+                "summary": "GET LABEL TEMPLATE",
                 "parameters": [
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 10,
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "search",
-                        "name": "search",
-                        "in": "query"
+                        "format": "uuid",
+                        "description": "Branch ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.CategoryGetListResponse"
+                            "$ref": "#/definitions/models.LabelTemplate"
                         }
                     },
                     "400": {
@@ -352,8 +388,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "adds category data to db based on given info in body",
+            "put": {
+                "description": "saves label size in millimeters, labels per row of A4 sheet, font size, barcode type and printer dpi of branch",
                 "consumes": [
                     "application/json"
                 ],
@@ -361,17 +397,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "CATEGORY"
+                    "LABEL"
                 ],
-                "summary": "CREATE CATEGORY",
+                "summary": "SAVE LABEL TEMPLATE",
                 "parameters": [
                     {
-                        "description": "category data",
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of branch",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "label template",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateCategory"
+                            "$ref": "#/definitions/models.LabelTemplate"
                         }
                     }
                 ],
@@ -403,24 +453,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/category/{id}": {
+        "/branch/{id}/remaining/pdf": {
             "get": {
-                "description": "gets category by ID",
+                "description": "renders printable stock report of all remaining of the branch with totals and signature fields",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/pdf"
                 ],
                 "tags": [
-                    "CATEGORY"
+                    "REMAINING"
                 ],
-                "summary": "GET BY ID",
+                "summary": "STOCK REPORT PDF",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Category ID",
+                        "description": "Branch ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -430,7 +480,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Category"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -452,9 +502,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "UPDATES CATEGORY BASED ON GIVEN DATA AND ID",
+            }
+        },
+        "/category": {
+            "get": {
+                "description": "gets all category based on limit, page and search by name",
                 "consumes": [
                     "application/json"
                 ],
@@ -464,31 +516,74 @@ const docTemplate = `{
                 "tags": [
                     "CATEGORY"
                 ],
-                "summary": "UPDATE CATEGORY",
+                "summary": "LIST CATEGORY",
                 "parameters": [
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "id of category",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "next_cursor of previous page, page is ignored with it",
+                        "name": "cursor",
+                        "in": "query"
                     },
                     {
-                        "description": "category data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateCategory"
-                        }
+                        "type": "string",
+                        "default": "-created_at",
+                        "description": "one of created_at, name, - prefix sorts descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "return total count, by default only without cursor",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at from, date means its beginning",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at to, date means its end",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter on name, parent_id, created_at, updated_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like",
+                        "name": "field[op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search",
+                        "name": "search",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.CategoryGetListResponse"
                         }
                     },
                     "400": {
@@ -511,8 +606,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "deletes category by id",
+            "post": {
+                "description": "adds category data to db based on given info in body",
                 "consumes": [
                     "application/json"
                 ],
@@ -522,15 +617,22 @@ const docTemplate = `{
                 "tags": [
                     "CATEGORY"
                 ],
-                "summary": "DELETE CATEGORY BY ID",
+                "summary": "CREATE CATEGORY",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "id of category",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "category data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateCategory"
+                        }
                     }
                 ],
                 "responses": {
@@ -561,9 +663,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/coming_product": {
+        "/category/{id}": {
             "get": {
-                "description": "gets all coming_product based on limit, page and search by name",
+                "description": "gets category by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -571,44 +673,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "COMING TABLE PRODUCT"
+                    "CATEGORY"
                 ],
-                "summary": "LIST COMING TABLE PRODUCT",
+                "summary": "GET BY ID",
                 "parameters": [
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 10,
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "category_id",
-                        "name": "category_id",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "barcode",
-                        "name": "barcode",
-                        "in": "query"
+                        "format": "uuid",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ComingTableProductGetListResponse"
+                            "$ref": "#/definitions/models.Category"
                         }
                     },
                     "400": {
@@ -630,11 +712,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/coming_product/{coming_table_id}": {
-            "post": {
-                "description": "adds coming_product data to db based on given info in body",
+            },
+            "put": {
+                "description": "UPDATES CATEGORY BASED ON GIVEN DATA AND ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -642,31 +722,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "COMING TABLE PRODUCT"
+                    "CATEGORY"
                 ],
-                "summary": "CREATE COMING TABLE PRODUCT",
+                "summary": "UPDATE CATEGORY",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Coming Table ID",
-                        "name": "coming_table_id",
-                        "in": "path",
-                        "required": true
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     },
                     {
                         "type": "string",
-                        "description": "Barcode value",
-                        "name": "barcode",
-                        "in": "query",
+                        "format": "uuid",
+                        "description": "id of category",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "coming_product count",
+                        "description": "category data",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateComingTableProductCount"
+                            "$ref": "#/definitions/models.CreateCategory"
                         }
                     }
                 ],
@@ -696,11 +776,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/coming_product/{id}": {
-            "get": {
-                "description": "gets coming_product by ID",
+            },
+            "delete": {
+                "description": "deletes category by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -708,14 +786,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "COMING TABLE PRODUCT"
+                    "CATEGORY"
                 ],
-                "summary": "GET BY ID",
+                "summary": "DELETE CATEGORY BY ID",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "ComingTableProduct ID",
+                        "description": "id of category",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -725,7 +803,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ComingTableProduct"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -747,9 +825,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "UPDATES COMING TABLE PRODUCT BASED ON GIVEN DATA AND ID",
+            }
+        },
+        "/category/{id}/attribute": {
+            "get": {
+                "description": "gets attributes defined for products of the category in their order",
                 "consumes": [
                     "application/json"
                 ],
@@ -757,33 +837,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "COMING TABLE PRODUCT"
+                    "CATEGORY ATTRIBUTE"
                 ],
-                "summary": "UPDATE COMING TABLE PRODUCT",
+                "summary": "LIST CATEGORY ATTRIBUTES",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "id of coming_product",
+                        "description": "id of category",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "coming_product data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateComingTableProduct"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.CategoryAttributeGetListResponse"
                         }
                     },
                     "400": {
@@ -806,8 +877,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "delete": {
-                "description": "deletes coming_product by id",
+            "post": {
+                "description": "defines attribute products of the category have, like size or color, empty values allow any value",
                 "consumes": [
                     "application/json"
                 ],
@@ -815,17 +886,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "COMING TABLE PRODUCT"
+                    "CATEGORY ATTRIBUTE"
                 ],
-                "summary": "DELETE COMING TABLE PRODUCT BY ID",
+                "summary": "CREATE CATEGORY ATTRIBUTE",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "id of coming_product",
+                        "description": "id of category",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "attribute data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateCategoryAttribute"
+                        }
                     }
                 ],
                 "responses": {
@@ -841,8 +927,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResp"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResp"
                         }
@@ -856,9 +942,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/coming_table": {
-            "get": {
-                "description": "gets all coming_table based on limit, page and search by name",
+        "/category_attribute/{id}": {
+            "put": {
+                "description": "changes attribute definition and renames it in products of the category, values products already have are kept",
                 "consumes": [
                     "application/json"
                 ],
@@ -866,44 +952,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "COMING TABLE"
+                    "CATEGORY ATTRIBUTE"
                 ],
-                "summary": "LIST COMING TABLES",
+                "summary": "UPDATE CATEGORY ATTRIBUTE",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 10,
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     },
                     {
                         "type": "string",
-                        "description": "coming_id",
-                        "name": "coming_id",
-                        "in": "query"
+                        "format": "uuid",
+                        "description": "id of category attribute",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "branch_id",
-                        "name": "branch_id",
-                        "in": "query"
+                        "description": "attribute data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateCategoryAttribute"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ComingTableGetListResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -912,8 +993,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResp"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResp"
                         }
@@ -926,8 +1007,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "adds coming_table data to db based on given info in body",
+            "delete": {
+                "description": "deletes attribute definition and its values from products of the category",
                 "consumes": [
                     "application/json"
                 ],
@@ -935,18 +1016,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "COMING TABLE"
+                    "CATEGORY ATTRIBUTE"
                 ],
-                "summary": "CREATE COMING TABLE",
+                "summary": "DELETE CATEGORY ATTRIBUTE",
                 "parameters": [
                     {
-                        "description": "coming_table data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateComingTable"
-                        }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of category attribute",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -962,8 +1042,8 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResp"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResp"
                         }
@@ -977,9 +1057,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/coming_table/{id}": {
+        "/coming_product": {
             "get": {
-                "description": "gets coming_table by ID",
+                "description": "gets all coming_product based on limit, page and search by name",
                 "consumes": [
                     "application/json"
                 ],
@@ -987,24 +1067,98 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "COMING TABLE"
+                    "COMING TABLE PRODUCT"
                 ],
-                "summary": "GET BY ID",
+                "summary": "LIST COMING TABLE PRODUCT",
                 "parameters": [
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "ComingTable ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "next_cursor of previous page, page is ignored with it",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-created_at",
+                        "description": "one of created_at, name, price, count, total_price, barcode, - prefix sorts descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "return total count, by default only without cursor",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at from, date means its beginning",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at to, date means its end",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter on name, barcode, price, count, total_price, category_id, product_id, coming_table_id, created_at, updated_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like",
+                        "name": "field[op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "coming_table_id",
+                        "name": "coming_table_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "category_id",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "barcode",
+                        "name": "barcode",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "download whole filtered and sorted list as file",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ComingTable"
+                            "$ref": "#/definitions/models.ComingTableProductGetListResponse"
                         }
                     },
                     "400": {
@@ -1026,9 +1180,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "UPDATES COMING TABLE BASED ON GIVEN DATA AND ID",
+            }
+        },
+        "/coming_product/{coming_table_id}": {
+            "post": {
+                "description": "adds coming_product data to db based on given info in body, unknown barcode with name and price is added as pending product",
                 "consumes": [
                     "application/json"
                 ],
@@ -1036,25 +1192,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "COMING TABLE"
+                    "COMING TABLE PRODUCT"
                 ],
-                "summary": "UPDATE COMING TABLE",
+                "summary": "CREATE COMING TABLE PRODUCT",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "id of coming_table",
-                        "name": "id",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Coming Table ID",
+                        "name": "coming_table_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "coming_table data",
+                        "type": "string",
+                        "description": "Barcode value",
+                        "name": "barcode",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "coming_product count, name, price and category_id for unknown barcode",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateComingTable"
+                            "$ref": "#/definitions/models.CreateComingTableProductCount"
                         }
                     }
                 ],
@@ -1077,6 +1245,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResp"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1084,9 +1258,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "deletes coming_table by id",
+            }
+        },
+        "/coming_product/{coming_table_id}/bulk": {
+            "post": {
+                "description": "adds buffered scans of barcodes to coming_table in one transaction, unknown barcodes are reported back",
                 "consumes": [
                     "application/json"
                 ],
@@ -1094,24 +1270,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "COMING TABLE"
+                    "COMING TABLE PRODUCT"
                 ],
-                "summary": "DELETE COMING TABLE BY ID",
+                "summary": "CREATE COMING TABLE PRODUCTS IN BULK",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "id of coming_table",
-                        "name": "id",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Coming Table ID",
+                        "name": "coming_table_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "scanned barcodes and counts",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateComingTableProductBulk"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ComingTableProductBulkResponse"
                         }
                     },
                     "400": {
@@ -1126,6 +1316,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResp"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1135,9 +1331,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/do_income/{coming_table_id}": {
-            "post": {
-                "description": "adds remaining data to db based on given coming_table_id",
+        "/coming_product/{id}": {
+            "get": {
+                "description": "gets coming_product by ID, ETag header is its version for If-Match of update",
                 "consumes": [
                     "application/json"
                 ],
@@ -1145,14 +1341,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "REMAINING"
+                    "COMING TABLE PRODUCT"
                 ],
-                "summary": "CREATE REMAINING",
+                "summary": "GET BY ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Coming Table ID",
-                        "name": "coming_table_id",
+                        "format": "uuid",
+                        "description": "ComingTableProduct ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1161,7 +1358,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ComingTableProduct"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of coming_product"
+                            }
                         }
                     },
                     "400": {
@@ -1183,11 +1386,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/product": {
-            "get": {
-                "description": "gets all product based on limit, page and search by name",
+            },
+            "put": {
+                "description": "UPDATES BARCODE AND COUNT OF COMING TABLE PRODUCT BY ID, NAME, CATEGORY AND PRICE ARE TAKEN FROM THE PRODUCT, WITH If-Match ONLY THAT VERSION IS UPDATED",
                 "consumes": [
                     "application/json"
                 ],
@@ -1195,44 +1396,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PRODUCT"
+                    "COMING TABLE PRODUCT"
                 ],
-                "summary": "LIST PRODUCT",
+                "summary": "UPDATE COMING TABLE PRODUCT",
                 "parameters": [
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 10,
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query"
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     },
                     {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of coming_product",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "barcode",
-                        "name": "barcode",
-                        "in": "query"
+                        "description": "ETag of coming_product read before",
+                        "name": "If-Match",
+                        "in": "header"
                     },
                     {
-                        "type": "string",
-                        "description": "name",
-                        "name": "name",
-                        "in": "query"
+                        "description": "coming_product data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateComingTableProduct"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ProductGetListResponse"
+                            "type": "string"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "new version of coming_product"
+                            }
                         }
                     },
                     "400": {
@@ -1247,6 +1455,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResp"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1255,8 +1475,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "adds product data to db based on given info in body",
+            "delete": {
+                "description": "deletes coming_product by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -1264,18 +1484,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PRODUCT"
+                    "COMING TABLE PRODUCT"
                 ],
-                "summary": "CREATE PRODUCT",
+                "summary": "DELETE COMING TABLE PRODUCT BY ID",
                 "parameters": [
                     {
-                        "description": "product data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateProduct"
-                        }
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of coming_product",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1297,6 +1516,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResp"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1306,9 +1531,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/product/{id}": {
+        "/coming_table": {
             "get": {
-                "description": "gets product by ID",
+                "description": "gets all coming_table based on limit, page and search by name",
                 "consumes": [
                     "application/json"
                 ],
@@ -1316,24 +1541,98 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PRODUCT"
+                    "COMING TABLE"
                 ],
-                "summary": "GET BY ID",
+                "summary": "LIST COMING TABLES",
                 "parameters": [
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "next_cursor of previous page, page is ignored with it",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-created_at",
+                        "description": "one of created_at, coming_id, date_time, - prefix sorts descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "return total count, by default only without cursor",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "date_time from, date means its beginning",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "date_time to, date means its end",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter on coming_id, branch_id, supplier_id, purchase_order_id, status, date_time, created_at, updated_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like",
+                        "name": "field[op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "coming_id",
+                        "name": "coming_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "branch_id",
+                        "name": "branch_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "supplier_id",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "download whole filtered and sorted list as file",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Product"
+                            "$ref": "#/definitions/models.ComingTableGetListResponse"
                         }
                     },
                     "400": {
@@ -1356,8 +1655,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "UPDATES PRODUCT BASED ON GIVEN DATA AND ID",
+            "post": {
+                "description": "adds coming_table data to db based on given info in body",
                 "consumes": [
                     "application/json"
                 ],
@@ -1365,25 +1664,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PRODUCT"
+                    "COMING TABLE"
                 ],
-                "summary": "UPDATE PRODUCT",
+                "summary": "CREATE COMING TABLE",
                 "parameters": [
                     {
                         "type": "string",
-                        "format": "uuid",
-                        "description": "id of product",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     },
                     {
-                        "description": "product data",
+                        "description": "coming_table data",
                         "name": "data",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateProduct"
+                            "$ref": "#/definitions/models.CreateComingTable"
                         }
                     }
                 ],
@@ -1406,6 +1703,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResp"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1413,9 +1716,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "deletes product by id",
+            }
+        },
+        "/coming_table/{id}": {
+            "get": {
+                "description": "gets coming_table by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1423,14 +1728,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "PRODUCT"
+                    "COMING TABLE"
                 ],
-                "summary": "DELETE PRODUCT BY ID",
+                "summary": "GET BY ID",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "id of product",
+                        "description": "ComingTable ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1440,7 +1745,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ComingTable"
                         }
                     },
                     "400": {
@@ -1462,11 +1767,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/remaining": {
-            "get": {
-                "description": "gets all remaining based on limit, page and search by name",
+            },
+            "put": {
+                "description": "UPDATES COMING TABLE BASED ON GIVEN DATA AND ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1474,50 +1777,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "REMAINING"
+                    "COMING TABLE"
                 ],
-                "summary": "LIST REMAINING",
+                "summary": "UPDATE COMING TABLE",
                 "parameters": [
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 10,
-                        "description": "limit",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 1,
-                        "description": "page",
-                        "name": "page",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "branch_id",
-                        "name": "branch_id",
-                        "in": "query"
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     },
                     {
                         "type": "string",
-                        "description": "category_id",
-                        "name": "category_id",
-                        "in": "query"
+                        "format": "uuid",
+                        "description": "id of coming_table",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "barcode",
-                        "name": "barcode",
-                        "in": "query"
+                        "description": "coming_table data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateComingTable"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.RemainingGetListResponse"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -1532,6 +1824,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResp"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1539,11 +1837,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/remaining/{id}": {
-            "get": {
-                "description": "gets remaining by ID",
+            },
+            "delete": {
+                "description": "deletes coming_table by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -1551,14 +1847,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "REMAINING"
+                    "COMING TABLE"
                 ],
-                "summary": "GET BY ID",
+                "summary": "DELETE COMING TABLE BY ID",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Remaining ID",
+                        "description": "id of coming_table",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1568,7 +1864,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Remaining"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -1583,6 +1879,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResp"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1590,9 +1892,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "UPDATES REMAINING BASED ON GIVEN DATA AND ID",
+            }
+        },
+        "/coming_table/{id}/cancel": {
+            "post": {
+                "description": "cancels coming_table which is not posted yet",
                 "consumes": [
                     "application/json"
                 ],
@@ -1600,26 +1904,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "REMAINING"
+                    "COMING TABLE"
                 ],
-                "summary": "UPDATE REMAINING",
+                "summary": "CANCEL COMING TABLE",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "id of remaining",
+                        "description": "id of coming_table",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "remaining data",
-                        "name": "data",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateRemainingSoft"
-                        }
                     }
                 ],
                 "responses": {
@@ -1641,6 +1942,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResp"
                         }
                     },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -1648,9 +1955,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "deletes remaining by id",
+            }
+        },
+        "/coming_table/{id}/full": {
+            "get": {
+                "description": "gets coming_table with branch, supplier, products, category subtotals and grand totals",
                 "consumes": [
                     "application/json"
                 ],
@@ -1658,14 +1967,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "REMAINING"
+                    "COMING TABLE"
                 ],
-                "summary": "DELETE REMAINING BY ID",
+                "summary": "GET FULL BY ID",
                 "parameters": [
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "id of remaining",
+                        "description": "ComingTable ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1675,7 +1984,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.ComingTableFull"
                         }
                     },
                     "400": {
@@ -1698,59 +2007,5302 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.Branch": {
-            "type": "object",
-            "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "phone_number": {
-                    "type": "string"
-                },
-                "updated_at": {
-                    "type": "string"
+        },
+        "/coming_table/{id}/import": {
+            "post": {
+                "description": "adds products of supplier delivery note in csv or xlsx to coming_table the same way as bulk scanning, columns are taken from import template of the supplier",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "COMING TABLE"
+                ],
+                "summary": "IMPORT DELIVERY NOTE",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of coming_table",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "csv or xlsx delivery note",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ComingTableProductImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
                 }
             }
         },
-        "models.BranchGetListResponse": {
-            "type": "object",
-            "properties": {
-                "branches": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.Branch"
+        "/coming_table/{id}/pdf": {
+            "get": {
+                "description": "renders printable goods receipt note of coming_table with lines, totals and signature fields",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "COMING TABLE"
+                ],
+                "summary": "GOODS RECEIPT NOTE PDF",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "ComingTable ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
                     }
-                },
-                "count": {
-                    "type": "integer"
                 }
             }
         },
-        "models.Category": {
-            "type": "object",
-            "properties": {
-                "created_at": {
+        "/coming_table/{id}/reverse": {
+            "post": {
+                "description": "reverses posted coming_table by compensating stock movements which are subtracted from remaining",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "COMING TABLE"
+                ],
+                "summary": "REVERSE COMING TABLE",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of coming_table",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/coming_table/{id}/status": {
+            "put": {
+                "description": "moves coming_table between draft, receiving, awaiting_approval and cancelled when transition is allowed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "COMING TABLE"
+                ],
+                "summary": "UPDATE COMING TABLE STATUS",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of coming_table",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "coming_table status",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateComingTableStatus"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/do_income/{coming_table_id}": {
+            "post": {
+                "description": "posts coming_table: writes income stock movements and adds its products to remaining",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "REMAINING"
+                ],
+                "summary": "CREATE REMAINING",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Coming Table ID",
+                        "name": "coming_table_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{key}": {
+            "get": {
+                "description": "downloads uploaded file like product image, urls of images point here unless FILESTORE_PUBLIC_URL is another address",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "PRODUCT IMAGE"
+                ],
+                "summary": "DOWNLOAD FILE",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "key of file",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/label": {
+            "post": {
+                "description": "renders shelf labels with name, price and barcode of given products or of all lines of coming_table as A4 pdf sheets or zpl for thermal printers, label size is taken from template of the branch",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/pdf",
+                    "text/plain"
+                ],
+                "tags": [
+                    "LABEL"
+                ],
+                "summary": "PRINT LABELS",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "products to print",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.LabelRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/period_closing": {
+            "get": {
+                "description": "gets closed months of all branches or of the given one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PERIOD CLOSING"
+                ],
+                "summary": "LIST CLOSED PERIODS",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "branch_id",
+                        "name": "branch_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PeriodClosingGetListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "closes month of branch, after that stock documents dated inside it can not be created, changed, posted or reversed until the month is reopened",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PERIOD CLOSING"
+                ],
+                "summary": "CLOSE PERIOD",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "branch, month like 2023-09 and user closing it",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PeriodClosingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/period_closing/log": {
+            "get": {
+                "description": "gets who closed and reopened months and why, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PERIOD CLOSING"
+                ],
+                "summary": "PERIOD CLOSING LOG",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "branch_id",
+                        "name": "branch_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PeriodClosingLogResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/period_closing/reopen": {
+            "post": {
+                "description": "reopens closed month of branch, user and reason are written to the period closing log",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PERIOD CLOSING"
+                ],
+                "summary": "REOPEN PERIOD",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "branch, month like 2023-09, user and reason",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PeriodClosingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/product": {
+            "get": {
+                "description": "gets all product based on limit, page and search by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "LIST PRODUCT",
+                "parameters": [
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of previous page, page is ignored with it",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-created_at",
+                        "description": "one of created_at, name, price, barcode, - prefix sorts descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "return total count, by default only without cursor",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at from, date means its beginning",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at to, date means its end",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter on name, barcode, price, status, category_id, parent_id, created_at, updated_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like",
+                        "name": "field[op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "barcode",
+                        "name": "barcode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "pending_review"
+                        ],
+                        "type": "string",
+                        "description": "status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "download whole filtered and sorted list as file",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductGetListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "adds product data to db based on given info in body, attributes must be defined for its category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "CREATE PRODUCT",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "product data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateProduct"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/import": {
+            "post": {
+                "description": "creates or updates products by barcode from csv or xlsx file with name, barcode, price and category columns, missing categories of the path are created",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "IMPORT PRODUCTS",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "file",
+                        "description": "csv or xlsx file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "validate and count without saving",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/search": {
+            "get": {
+                "description": "type-ahead search of products by words of name, barcode prefix and category name, Cyrillic and Latin spellings and small typos match alike, best matches first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "SEARCH PRODUCT",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "part of name, barcode or category name",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 50,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "category_id",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "active",
+                            "pending_review"
+                        ],
+                        "type": "string",
+                        "description": "status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}": {
+            "get": {
+                "description": "gets product by ID with its variants and images",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "GET BY ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Product"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "UPDATES PRODUCT BASED ON GIVEN DATA AND ID, variant keeps category of its parent and variants get new category of parent",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "UPDATE PRODUCT",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of product",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "product data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateProduct"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "deletes product with its images, product with variants can be deleted only after them",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "DELETE PRODUCT BY ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of product",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/approve": {
+            "post": {
+                "description": "makes product created during receiving as pending review a regular one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "APPROVE PENDING PRODUCT",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of pending product",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/image": {
+            "get": {
+                "description": "gets images of product in their order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT IMAGE"
+                ],
+                "summary": "LIST PRODUCT IMAGES",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of product",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductImageGetListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "adds jpeg, png, gif or webp image of up to 5 MB after the last image of product, type is detected from file content",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT IMAGE"
+                ],
+                "summary": "UPLOAD PRODUCT IMAGE",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of product",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "image file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/merge": {
+            "post": {
+                "description": "replaces pending product by existing target product in coming tables, remaining and stock movements and deletes it, rows dated in closed period are not changed and the merge is refused",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "MERGE PENDING PRODUCT",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of pending product",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "target product",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MergeProduct"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/price": {
+            "get": {
+                "description": "gets past and scheduled prices of product, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT PRICE"
+                ],
+                "summary": "PRODUCT PRICE HISTORY",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of product",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "only overrides of the branch",
+                        "name": "branch_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProductPriceGetListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "adds price change of product effective from given time or now, without branch_id it changes base price which is copied to product when it becomes effective, with branch_id it overrides price in that branch",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT PRICE"
+                ],
+                "summary": "SCHEDULE PRODUCT PRICE",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of product",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "price, branch and effective_from",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateProductPrice"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/product/{id}/variant": {
+            "post": {
+                "description": "adds variant like size or color with its own barcode to product, it gets category of the product, name of product with attribute values when name is empty and price of product when price is 0",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT"
+                ],
+                "summary": "CREATE PRODUCT VARIANT",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of parent product",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "variant data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateProductVariant"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/product_image/{id}": {
+            "delete": {
+                "description": "deletes image of product with its file",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT IMAGE"
+                ],
+                "summary": "DELETE PRODUCT IMAGE",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of product image",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/product_price/effective": {
+            "get": {
+                "description": "gets price of barcode in branch at given time, branch override wins over base price",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT PRICE"
+                ],
+                "summary": "EFFECTIVE PRICE",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "barcode",
+                        "name": "barcode",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "branch_id",
+                        "name": "branch_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "moment as RFC3339, 2006-01-02 15:04:05 or date meaning its end, now by default",
+                        "name": "at",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EffectivePrice"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/product_price/{id}": {
+            "delete": {
+                "description": "deletes price change which is not effective yet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PRODUCT PRICE"
+                ],
+                "summary": "CANCEL SCHEDULED PRICE",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of product price",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotion": {
+            "get": {
+                "description": "gets promotions by priority, search by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PROMOTION"
+                ],
+                "summary": "LIST PROMOTIONS",
+                "parameters": [
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of previous page, page is ignored with it",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-priority",
+                        "description": "one of priority, created_at, name, starts_at, - prefix sorts descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "return total count, by default only without cursor",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at from, date means its beginning",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at to, date means its end",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter on name, type, percent, branch_id, priority, active, starts_at, ends_at, created_at, updated_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like",
+                        "name": "field[op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "promotions of the branch and of all branches",
+                        "name": "branch_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PromotionGetListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "adds discount rule: percent off, buy_count get free_count free or bundle_count units for bundle_price, scoped by products, categories with subcategories, branch and date window",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PROMOTION"
+                ],
+                "summary": "CREATE PROMOTION",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "promotion data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreatePromotion"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotion/evaluate": {
+            "post": {
+                "description": "prices basket of barcodes in the branch and applies running promotions by priority, every unit gets one promotion at most, with commit the discounts are saved for promotion report",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PROMOTION"
+                ],
+                "summary": "EVALUATE BASKET",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "branch and lines of basket",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.BasketRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.BasketResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/promotion/{id}": {
+            "get": {
+                "description": "gets promotion by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PROMOTION"
+                ],
+                "summary": "GET BY ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Promotion ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Promotion"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "updates promotion, set active to false to stop it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PROMOTION"
+                ],
+                "summary": "UPDATE PROMOTION",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of promotion",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "promotion data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreatePromotion"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "deletes promotion which has not granted discounts yet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PROMOTION"
+                ],
+                "summary": "DELETE PROMOTION BY ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of promotion",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase_order": {
+            "get": {
+                "description": "gets all purchase_order based on limit, page and filters",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PURCHASE ORDER"
+                ],
+                "summary": "LIST PURCHASE ORDERS",
+                "parameters": [
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of previous page, page is ignored with it",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-created_at",
+                        "description": "one of created_at, order_number, expected_date, - prefix sorts descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "return total count, by default only without cursor",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at from, date means its beginning",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at to, date means its end",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter on order_number, supplier_id, branch_id, status, expected_date, created_at, updated_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like",
+                        "name": "field[op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "supplier_id",
+                        "name": "supplier_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "branch_id",
+                        "name": "branch_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "new",
+                            "partially_received",
+                            "received"
+                        ],
+                        "type": "string",
+                        "description": "status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrderGetListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "adds purchase_order with its products to db based on given info in body",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PURCHASE ORDER"
+                ],
+                "summary": "CREATE PURCHASE ORDER",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "purchase_order data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreatePurchaseOrder"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase_order/{id}": {
+            "get": {
+                "description": "gets purchase_order with ordered and received quantities by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PURCHASE ORDER"
+                ],
+                "summary": "GET BY ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "PurchaseOrder ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PurchaseOrder"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "UPDATES PURCHASE ORDER BASED ON GIVEN DATA AND ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PURCHASE ORDER"
+                ],
+                "summary": "UPDATE PURCHASE ORDER",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of purchase_order",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "purchase_order data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdatePurchaseOrder"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "deletes purchase_order by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PURCHASE ORDER"
+                ],
+                "summary": "DELETE PURCHASE ORDER BY ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of purchase_order",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase_order/{id}/coming_table": {
+            "post": {
+                "description": "opens coming_table for branch and supplier of the purchase_order to receive goods against it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "PURCHASE ORDER"
+                ],
+                "summary": "CREATE COMING TABLE FROM PURCHASE ORDER",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of purchase_order",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "coming_table data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateComingTableFromOrder"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/remaining": {
+            "get": {
+                "description": "gets all remaining based on limit, page and filters, with as_of quantities and values are rebuilt from stock snapshots and movements",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "REMAINING"
+                ],
+                "summary": "LIST REMAINING",
+                "parameters": [
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of previous page, page is ignored with it",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-created_at",
+                        "description": "one of created_at, name, price, count, total_price, barcode, - prefix sorts descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "return total count, by default only without cursor",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at from, date means its beginning",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at to, date means its end",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter on name, barcode, price, count, total_price, category_id, product_id, branch_id, created_at, updated_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like",
+                        "name": "field[op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "branch_id",
+                        "name": "branch_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "category_id",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "barcode",
+                        "name": "barcode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "reconstruct remaining at the moment, date means the end of that day",
+                        "name": "as_of",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "description": "download whole filtered and sorted list as file",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RemainingGetListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/remaining/{id}": {
+            "get": {
+                "description": "gets remaining by ID, ETag header is its version for If-Match of update",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "REMAINING"
+                ],
+                "summary": "GET BY ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Remaining ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Remaining"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "version of remaining"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "UPDATES REMAINING BASED ON GIVEN DATA AND ID, WITH If-Match ONLY THAT VERSION IS UPDATED",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "REMAINING"
+                ],
+                "summary": "UPDATE REMAINING",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of remaining",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of remaining read before",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "remaining data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateRemainingSoft"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "new version of remaining"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "deletes remaining by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "REMAINING"
+                ],
+                "summary": "DELETE REMAINING BY ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of remaining",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/income": {
+            "get": {
+                "description": "sums count and total price of posted coming tables per day, week or month by branch, supplier or category",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "REPORT"
+                ],
+                "summary": "INCOME REPORT",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "first day, current month by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "last day, today by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "branch_id",
+                        "name": "branch_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "branch",
+                            "supplier",
+                            "category"
+                        ],
+                        "type": "string",
+                        "default": "branch",
+                        "description": "group_by",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "month",
+                        "description": "period",
+                        "name": "period",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.IncomeReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/movements": {
+            "get": {
+                "description": "gets opening balance, income, outcome and closing balance of every barcode for the date range from stock movements",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "REPORT"
+                ],
+                "summary": "STOCK MOVEMENT SUMMARY",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "first day, current month by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "last day, today by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "branch_id",
+                        "name": "branch_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "barcode",
+                        "name": "barcode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MovementReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/promotions": {
+            "get": {
+                "description": "sums discounts granted by every promotion in committed baskets of the date range",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "REPORT"
+                ],
+                "summary": "PROMOTION REPORT",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "first day, current month by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "last day, today by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "branch_id",
+                        "name": "branch_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PromotionReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/stock": {
+            "get": {
+                "description": "sums current remaining count and value of every branch",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "REPORT"
+                ],
+                "summary": "STOCK VALUE REPORT",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "branch_id",
+                        "name": "branch_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StockReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/top_products": {
+            "get": {
+                "description": "gets products received the most by count in posted coming tables of the date range",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "REPORT"
+                ],
+                "summary": "TOP RECEIVED PRODUCTS",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "first day, current month by default",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date",
+                        "description": "last day, today by default",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "branch_id",
+                        "name": "branch_id",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TopProductsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/revaluation": {
+            "get": {
+                "description": "gets revaluations written when product price changes, newest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "REVALUATION"
+                ],
+                "summary": "LIST REVALUATIONS",
+                "parameters": [
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of previous page, page is ignored with it",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-created_at",
+                        "description": "one of created_at, barcode, difference, - prefix sorts descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "return total count, by default only without cursor",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at from, date means its beginning",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at to, date means its end",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter on product_id, barcode, name, old_price, new_price, difference, source, created_by, created_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like",
+                        "name": "field[op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "barcode",
+                        "name": "barcode",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "product_id",
+                        "name": "product_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RevaluationGetListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/revaluation/{id}": {
+            "get": {
+                "description": "gets revaluation with remaining of every branch before and after it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "REVALUATION"
+                ],
+                "summary": "GET BY ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Revaluation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RevaluationFull"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/revaluation/{id}/pdf": {
+            "get": {
+                "description": "renders printable repricing sheet of revaluation with remaining of every branch and signature fields",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/pdf"
+                ],
+                "tags": [
+                    "REVALUATION"
+                ],
+                "summary": "REPRICING SHEET PDF",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Revaluation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier": {
+            "get": {
+                "description": "gets all supplier based on limit, page and search by name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SUPPLIER"
+                ],
+                "summary": "LIST SUPPLIERS",
+                "parameters": [
+                    {
+                        "maximum": 500,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of previous page, page is ignored with it",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-created_at",
+                        "description": "one of created_at, name, - prefix sorts descending",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "return total count, by default only without cursor",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at from, date means its beginning",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "created_at to, date means its end",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "filter on name, address, phone_number, created_at, updated_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like",
+                        "name": "field[op]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "search",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SupplierGetListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "adds supplier data to db based on given info in body",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SUPPLIER"
+                ],
+                "summary": "CREATE SUPPLIER",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "description": "supplier data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateSupplier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier/{id}": {
+            "get": {
+                "description": "gets supplier by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SUPPLIER"
+                ],
+                "summary": "GET BY ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Supplier"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "UPDATES SUPPLIER BASED ON GIVEN DATA AND ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SUPPLIER"
+                ],
+                "summary": "UPDATE SUPPLIER",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of supplier",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "supplier data",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateSupplier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "deletes supplier by id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SUPPLIER"
+                ],
+                "summary": "DELETE SUPPLIER BY ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of supplier",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        },
+        "/supplier/{id}/import_template": {
+            "get": {
+                "description": "gets columns of supplier delivery notes, default columns are returned when template is not saved",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SUPPLIER"
+                ],
+                "summary": "GET SUPPLIER IMPORT TEMPLATE",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Supplier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SupplierImportTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "saves which columns of supplier delivery notes hold barcode, count and price, by header titles or letters like \"col:C\"",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "SUPPLIER"
+                ],
+                "summary": "SAVE SUPPLIER IMPORT TEMPLATE",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "retry of request with the same key returns its original response",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "id of supplier",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "import template",
+                        "name": "data",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SupplierImportTemplate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResp"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.AppliedPromotion": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "discount": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "promotion_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BasketLine": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "discount": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AppliedPromotion"
+                    }
+                },
+                "to_pay": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.BasketLineRequest": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BasketRequest": {
+            "type": "object",
+            "properties": {
+                "at": {
+                    "type": "string"
+                },
+                "branch_id": {
+                    "type": "string"
+                },
+                "commit": {
+                    "type": "boolean"
+                },
+                "document_id": {
+                    "type": "string"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BasketLineRequest"
+                    }
+                }
+            }
+        },
+        "models.BasketResponse": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "number"
+                },
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.BasketLine"
+                    }
+                },
+                "to_pay": {
+                    "type": "number"
+                },
+                "total": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.Branch": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BranchGetListResponse": {
+            "type": "object",
+            "properties": {
+                "branches": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Branch"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Category": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CategoryAttribute": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.CategoryAttributeGetListResponse": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CategoryAttribute"
+                    }
+                }
+            }
+        },
+        "models.CategoryGetListResponse": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Category"
+                    }
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ComingTable": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "type": "string"
+                },
+                "coming_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "date_time": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "purchase_order_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "supplier_id": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ComingTableCategoryTotal": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "string"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "total_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.ComingTableFull": {
+            "type": "object",
+            "properties": {
+                "branch": {
+                    "$ref": "#/definitions/models.Branch"
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ComingTableCategoryTotal"
+                    }
+                },
+                "coming_table": {
+                    "$ref": "#/definitions/models.ComingTable"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ComingTableFullProduct"
+                    }
+                },
+                "supplier": {
+                    "$ref": "#/definitions/models.Supplier"
+                },
+                "total_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.ComingTableFullProduct": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "string"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "coming_table_id": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "total_price": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ComingTableGetListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ComingTable"
+                    }
+                }
+            }
+        },
+        "models.ComingTableProduct": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "string"
+                },
+                "coming_table_id": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "total_price": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ComingTableProductBulkResponse": {
+            "type": "object",
+            "properties": {
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ComingTableProductScanResult"
+                    }
+                },
+                "unknown": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ComingTableProductGetListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ComingTableProduct"
+                    }
+                }
+            }
+        },
+        "models.ComingTableProductImportResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportRowError"
+                    }
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ComingTableProductScanResult"
+                    }
+                },
+                "unknown": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ComingTableProductScan": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.ComingTableProductScanResult": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "total_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.CreateBranch": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateCategory": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateCategoryAttribute": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "required": {
+                    "type": "boolean"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.CreateComingTable": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "type": "string"
+                },
+                "coming_id": {
+                    "type": "string"
+                },
+                "date_time": {
+                    "type": "string"
+                },
+                "supplier_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateComingTableFromOrder": {
+            "type": "object",
+            "properties": {
+                "coming_id": {
+                    "type": "string"
+                },
+                "date_time": {
+                    "type": "string"
+                },
+                "purchase_order_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateComingTableProduct": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "string"
+                },
+                "coming_table_id": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "total_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.CreateComingTableProductBulk": {
+            "type": "object",
+            "properties": {
+                "coming_table_id": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ComingTableProductScan"
+                    }
+                }
+            }
+        },
+        "models.CreateComingTableProductCount": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "name, price and category_id create pending product when barcode is unknown",
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.CreateProduct": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "barcode": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.CreateProductPrice": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "effective_from": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateProductVariant": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "barcode": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.CreatePromotion": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "branch_id": {
+                    "type": "string"
+                },
+                "bundle_count": {
+                    "type": "integer"
+                },
+                "bundle_price": {
+                    "type": "number"
+                },
+                "buy_count": {
+                    "type": "integer"
+                },
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "free_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "percent": {
+                    "type": "number"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreatePurchaseOrder": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "type": "string"
+                },
+                "expected_date": {
+                    "type": "string"
+                },
+                "order_number": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CreatePurchaseOrderProduct"
+                    }
+                },
+                "supplier_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreatePurchaseOrderProduct": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.CreateSupplier": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "phone_number": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EffectivePrice": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "branch_id": {
+                    "type": "string"
+                },
+                "effective_from": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ErrorResp": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ImportRowError": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.IncomeReportResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IncomeReportRow"
+                    }
+                },
+                "total_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.IncomeReportRow": {
+            "type": "object",
+            "properties": {
+                "coming_tables": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "period": {
+                    "type": "string"
+                },
+                "total_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.LabelRequest": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "type": "string"
+                },
+                "coming_table_id": {
+                    "type": "string"
+                },
+                "copies": {
+                    "type": "integer"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.LabelTemplate": {
+            "type": "object",
+            "properties": {
+                "barcode_type": {
+                    "type": "string"
+                },
+                "branch_id": {
+                    "type": "string"
+                },
+                "columns": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dpi": {
+                    "type": "integer"
+                },
+                "font_size": {
+                    "type": "number"
+                },
+                "height": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "width": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.MergeProduct": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "target_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MovementReportResponse": {
+            "type": "object",
+            "properties": {
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.MovementReportRow"
+                    }
+                }
+            }
+        },
+        "models.MovementReportRow": {
+            "type": "object",
+            "properties": {
+                "barcode": {
+                    "type": "string"
+                },
+                "closing_count": {
+                    "type": "integer"
+                },
+                "closing_price": {
+                    "type": "number"
+                },
+                "in_count": {
+                    "type": "integer"
+                },
+                "in_price": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opening_count": {
+                    "type": "integer"
+                },
+                "opening_price": {
+                    "type": "number"
+                },
+                "out_count": {
+                    "type": "integer"
+                },
+                "out_price": {
+                    "type": "number"
+                }
+            }
+        },
+        "models.PeriodClosing": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "type": "string"
+                },
+                "closed_at": {
+                    "type": "string"
+                },
+                "closed_by": {
+                    "type": "string"
+                },
+                "month": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PeriodClosingGetListResponse": {
+            "type": "object",
+            "properties": {
+                "periods": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PeriodClosing"
+                    }
+                }
+            }
+        },
+        "models.PeriodClosingLog": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "branch_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "month": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PeriodClosingLogResponse": {
+            "type": "object",
+            "properties": {
+                "logs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PeriodClosingLog"
+                    }
+                }
+            }
+        },
+        "models.PeriodClosingRequest": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "type": "string"
+                },
+                "month": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Product": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "barcode": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductImage"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Product"
+                    }
+                }
+            }
+        },
+        "models.ProductGetListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Product"
+                    }
+                }
+            }
+        },
+        "models.ProductImage": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProductImageGetListResponse": {
+            "type": "object",
+            "properties": {
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductImage"
+                    }
+                }
+            }
+        },
+        "models.ProductImportResponse": {
+            "type": "object",
+            "properties": {
+                "categories_created": {
+                    "type": "integer"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ImportRowError"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.ProductPrice": {
+            "type": "object",
+            "properties": {
+                "applied_at": {
+                    "type": "string"
+                },
+                "branch_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "effective_from": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ProductPriceGetListResponse": {
+            "type": "object",
+            "properties": {
+                "prices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductPrice"
+                    }
+                }
+            }
+        },
+        "models.ProductSearchResponse": {
+            "type": "object",
+            "properties": {
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductSearchResult"
+                    }
+                }
+            }
+        },
+        "models.ProductSearchResult": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "barcode": {
+                    "type": "string"
+                },
+                "category_id": {
+                    "type": "string"
+                },
+                "category_name": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "highlight": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ProductImage"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "variants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Product"
+                    }
+                }
+            }
+        },
+        "models.Promotion": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "branch_id": {
+                    "type": "string"
+                },
+                "bundle_count": {
+                    "type": "integer"
+                },
+                "bundle_price": {
+                    "type": "number"
+                },
+                "buy_count": {
+                    "type": "integer"
+                },
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "free_count": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "percent": {
+                    "type": "number"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "product_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PromotionGetListResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "promotions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Promotion"
+                    }
+                }
+            }
+        },
+        "models.PromotionReportResponse": {
+            "type": "object",
+            "properties": {
+                "discount": {
+                    "type": "number"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PromotionReportRow"
+                    }
+                }
+            }
+        },
+        "models.PromotionReportRow": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "discount": {
+                    "type": "number"
+                },
+                "documents": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "promotion_id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PurchaseOrder": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expected_date": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "name": {
+                "order_number": {
                     "type": "string"
                 },
-                "parent_id": {
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.PurchaseOrderProduct"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "supplier_id": {
                     "type": "string"
                 },
                 "updated_at": {
@@ -1758,70 +7310,71 @@ const docTemplate = `{
                 }
             }
         },
-        "models.CategoryGetListResponse": {
+        "models.PurchaseOrderGetListResponse": {
             "type": "object",
             "properties": {
-                "categories": {
+                "count": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "purchase_orders": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Category"
+                        "$ref": "#/definitions/models.PurchaseOrder"
                     }
-                },
-                "count": {
-                    "type": "integer"
                 }
             }
         },
-        "models.ComingTable": {
+        "models.PurchaseOrderProduct": {
             "type": "object",
             "properties": {
-                "branch_id": {
+                "barcode": {
                     "type": "string"
                 },
-                "coming_id": {
+                "category_id": {
                     "type": "string"
                 },
-                "created_at": {
-                    "type": "string"
+                "count": {
+                    "type": "integer"
                 },
-                "date_time": {
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "status": {
+                "name": {
                     "type": "string"
                 },
-                "updated_at": {
+                "price": {
+                    "type": "number"
+                },
+                "purchase_order_id": {
                     "type": "string"
-                }
-            }
-        },
-        "models.ComingTableGetListResponse": {
-            "type": "object",
-            "properties": {
-                "count": {
+                },
+                "received_count": {
                     "type": "integer"
                 },
-                "products": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.ComingTable"
-                    }
+                "total_price": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "models.ComingTableProduct": {
+        "models.Remaining": {
             "type": "object",
             "properties": {
                 "barcode": {
                     "type": "string"
                 },
-                "category_id": {
+                "branch_id": {
                     "type": "string"
                 },
-                "coming_table_id": {
+                "category_id": {
                     "type": "string"
                 },
                 "count": {
@@ -1839,136 +7392,179 @@ const docTemplate = `{
                 "price": {
                     "type": "number"
                 },
+                "product_id": {
+                    "type": "string"
+                },
                 "total_price": {
                     "type": "number"
                 },
                 "updated_at": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "models.ComingTableProductGetListResponse": {
+        "models.RemainingGetListResponse": {
             "type": "object",
             "properties": {
                 "count": {
                     "type": "integer"
                 },
-                "products": {
+                "next_cursor": {
+                    "type": "string"
+                },
+                "remainings": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.ComingTableProduct"
+                        "$ref": "#/definitions/models.Remaining"
                     }
                 }
             }
         },
-        "models.CreateBranch": {
+        "models.Revaluation": {
             "type": "object",
             "properties": {
-                "address": {
+                "barcode": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "created_by": {
+                    "type": "string"
+                },
+                "difference": {
+                    "type": "number"
+                },
+                "id": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "phone_number": {
+                "new_price": {
+                    "type": "number"
+                },
+                "old_price": {
+                    "type": "number"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "source": {
                     "type": "string"
                 }
             }
         },
-        "models.CreateCategory": {
+        "models.RevaluationFull": {
             "type": "object",
             "properties": {
-                "name": {
-                    "type": "string"
+                "count": {
+                    "type": "integer"
                 },
-                "parent_id": {
-                    "type": "string"
+                "lines": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.RevaluationLine"
+                    }
+                },
+                "revaluation": {
+                    "$ref": "#/definitions/models.Revaluation"
                 }
             }
         },
-        "models.CreateComingTable": {
+        "models.RevaluationGetListResponse": {
             "type": "object",
             "properties": {
-                "branch_id": {
-                    "type": "string"
+                "count": {
+                    "type": "integer"
                 },
-                "coming_id": {
+                "next_cursor": {
                     "type": "string"
                 },
-                "date_time": {
-                    "type": "string"
+                "revaluations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Revaluation"
+                    }
                 }
             }
         },
-        "models.CreateComingTableProduct": {
+        "models.RevaluationLine": {
             "type": "object",
             "properties": {
-                "barcode": {
-                    "type": "string"
-                },
-                "category_id": {
+                "branch_id": {
                     "type": "string"
                 },
-                "coming_table_id": {
+                "branch_name": {
                     "type": "string"
                 },
                 "count": {
                     "type": "integer"
                 },
-                "name": {
+                "difference": {
+                    "type": "number"
+                },
+                "id": {
                     "type": "string"
                 },
-                "price": {
+                "new_price": {
                     "type": "number"
                 },
-                "total_price": {
+                "new_total": {
+                    "type": "number"
+                },
+                "old_price": {
+                    "type": "number"
+                },
+                "old_total": {
                     "type": "number"
                 }
             }
         },
-        "models.CreateComingTableProductCount": {
+        "models.StockReportResponse": {
             "type": "object",
             "properties": {
                 "count": {
                     "type": "integer"
-                }
-            }
-        },
-        "models.CreateProduct": {
-            "type": "object",
-            "properties": {
-                "barcode": {
-                    "type": "string"
-                },
-                "category_id": {
-                    "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.StockReportRow"
+                    }
                 },
-                "price": {
+                "total_price": {
                     "type": "number"
                 }
             }
         },
-        "models.ErrorResp": {
+        "models.StockReportRow": {
             "type": "object",
             "properties": {
-                "code": {
+                "branch_id": {
                     "type": "string"
                 },
-                "message": {
+                "branch_name": {
                     "type": "string"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "products": {
+                    "type": "integer"
+                },
+                "total_price": {
+                    "type": "number"
                 }
             }
         },
-        "models.Product": {
+        "models.Supplier": {
             "type": "object",
             "properties": {
-                "barcode": {
-                    "type": "string"
-                },
-                "category_id": {
+                "address": {
                     "type": "string"
                 },
                 "created_at": {
@@ -1980,77 +7576,142 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "phone_number": {
+                    "type": "string"
                 },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "models.ProductGetListResponse": {
+        "models.SupplierGetListResponse": {
             "type": "object",
             "properties": {
                 "count": {
                     "type": "integer"
                 },
-                "products": {
+                "next_cursor": {
+                    "type": "string"
+                },
+                "suppliers": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Product"
+                        "$ref": "#/definitions/models.Supplier"
                     }
                 }
             }
         },
-        "models.Remaining": {
+        "models.SupplierImportTemplate": {
             "type": "object",
             "properties": {
-                "barcode": {
+                "barcode_column": {
                     "type": "string"
                 },
-                "branch_id": {
+                "count_column": {
                     "type": "string"
                 },
-                "category_id": {
+                "created_at": {
                     "type": "string"
                 },
-                "count": {
+                "header_row": {
                     "type": "integer"
                 },
-                "created_at": {
+                "price_column": {
                     "type": "string"
                 },
-                "id": {
+                "supplier_id": {
                     "type": "string"
                 },
-                "name": {
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TopProductRow": {
+            "type": "object",
+            "properties": {
+                "barcode": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "number"
+                "category_name": {
+                    "type": "string"
                 },
-                "total_price": {
-                    "type": "number"
+                "count": {
+                    "type": "integer"
                 },
-                "updated_at": {
+                "name": {
                     "type": "string"
+                },
+                "total_price": {
+                    "type": "number"
                 }
             }
         },
-        "models.RemainingGetListResponse": {
+        "models.TopProductsResponse": {
             "type": "object",
             "properties": {
-                "count": {
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TopProductRow"
+                    }
+                }
+            }
+        },
+        "models.UpdateCategoryAttribute": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "position": {
                     "type": "integer"
                 },
-                "remainings": {
+                "required": {
+                    "type": "boolean"
+                },
+                "values": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Remaining"
+                        "type": "string"
                     }
                 }
             }
         },
+        "models.UpdateComingTableStatus": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.UpdatePurchaseOrder": {
+            "type": "object",
+            "properties": {
+                "branch_id": {
+                    "type": "string"
+                },
+                "expected_date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "order_number": {
+                    "type": "string"
+                },
+                "supplier_id": {
+                    "type": "string"
+                }
+            }
+        },
         "models.UpdateRemainingSoft": {
             "type": "object",
             "properties": {
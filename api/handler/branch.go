@@ -4,7 +4,6 @@ import (
 	"market/models"
 	"market/pkg/logger"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,6 +15,7 @@ import (
 // @Tags         BRANCH
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
 // @Param        data  body      models.CreateBranch  true  "branch data"
 // @Success      200  {string}  string
 // @Failure      400  {object}  models.ErrorResp
@@ -46,34 +46,38 @@ func (h *Handler) CreateBranch(ctx *gin.Context) {
 // @Tags         BRANCH
 // @Accept       json
 // @Produce      json
-// @Param  		 limit         query     int        false  "limit"          minimum(1)     default(10)
+// @Param  		 limit         query     int        false  "limit"          minimum(1)     maximum(500)   default(10)
 // @Param  		 page          query     int        false  "page"           minimum(1)     default(1)
+// @Param   	 cursor        query     string     false  "next_cursor of previous page, page is ignored with it"
+// @Param   	 sort          query     string     false  "one of created_at, name, - prefix sorts descending"  default(-created_at)
+// @Param   	 count         query     bool       false  "return total count, by default only without cursor"
+// @Param   	 date_from     query     string     false  "created_at from, date means its beginning"
+// @Param   	 date_to       query     string     false  "created_at to, date means its end"
+// @Param   	 field[op]     query     string     false  "filter on name, address, phone_number, created_at, updated_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like"
 // @Param   	 search        query     string     false  "search"
 // @Success      200  {object}  models.BranchGetListResponse
 // @Failure      400  {object}  models.ErrorResp
 // @Failure      404  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
 func (h *Handler) GetListBranch(ctx *gin.Context) {
-	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	page, limit, opts, err := listParams(ctx)
 	if err != nil {
-		h.log.Error("error get page:", logger.Error(err))
-		ctx.JSON(http.StatusBadRequest, "invalid page param")
-		return
-	}
-	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
-	if err != nil {
-		h.log.Error("error get limit:", logger.Error(err))
-		ctx.JSON(http.StatusBadRequest, "invalid page param")
+		h.log.Error("error get list params:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
 	resp, err := h.strg.Branch().GetList(&models.BranchGetListRequest{
-		Page:   page,
-		Limit:  limit,
-		Search: ctx.Query("search"),
+		Page:        page,
+		Limit:       limit,
+		ListOptions: opts,
+		Search:      ctx.Query("search"),
 	})
 	if err != nil {
 		h.log.Error("error Branch GetListBranch:", logger.Error(err))
+		if listError(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, "internal server error")
 		return
 	}
@@ -113,6 +117,7 @@ func (h *Handler) GetByIDBranch(ctx *gin.Context) {
 // @Tags         BRANCH
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
 // @Param        id    path     string  true  "id of branch" format(uuid)
 // @Param        data  body      models.CreateBranch  true  "branch data"
 // @Success      200  {string}  string
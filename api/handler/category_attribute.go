@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"market/models"
+	"market/pkg/logger"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateCategoryAttribute godoc
+// @Router       /category/{id}/attribute [POST]
+// @Summary      CREATE CATEGORY ATTRIBUTE
+// @Description  defines attribute products of the category have, like size or color, empty values allow any value
+// @Tags         CATEGORY ATTRIBUTE
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        id    path     string  true  "id of category" format(uuid)
+// @Param        data  body      models.CreateCategoryAttribute  true  "attribute data"
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) CreateCategoryAttribute(ctx *gin.Context) {
+	var attribute models.CreateCategoryAttribute
+	err := ctx.ShouldBind(&attribute)
+	if err != nil {
+		h.log.Error("error while binding category attribute:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, "invalid body")
+		return
+	}
+	attribute.Name = strings.TrimSpace(attribute.Name)
+	if attribute.Name == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+
+	attribute.CategoryId = ctx.Param("id")
+	resp, err := h.strg.CategoryAttribute().Create(&attribute)
+	if err != nil {
+		h.log.Error("error category attribute create:", logger.Error(err))
+		if statusConflict(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"code": http.StatusCreated, "message": "success", "resp": resp})
+}
+
+// GetListCategoryAttribute godoc
+// @Router       /category/{id}/attribute [GET]
+// @Summary      LIST CATEGORY ATTRIBUTES
+// @Description  gets attributes defined for products of the category in their order
+// @Tags         CATEGORY ATTRIBUTE
+// @Accept       json
+// @Produce      json
+// @Param        id    path     string  true  "id of category" format(uuid)
+// @Success      200  {object}  models.CategoryAttributeGetListResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetListCategoryAttribute(ctx *gin.Context) {
+	resp, err := h.strg.CategoryAttribute().GetList(&models.CategoryAttributeGetListRequest{CategoryId: ctx.Param("id")})
+	if err != nil {
+		h.log.Error("error category attribute GetList:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdateCategoryAttribute godoc
+// @Router       /category_attribute/{id} [PUT]
+// @Summary      UPDATE CATEGORY ATTRIBUTE
+// @Description  changes attribute definition and renames it in products of the category, values products already have are kept
+// @Tags         CATEGORY ATTRIBUTE
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        id    path     string  true  "id of category attribute" format(uuid)
+// @Param        data  body      models.UpdateCategoryAttribute  true  "attribute data"
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) UpdateCategoryAttribute(ctx *gin.Context) {
+	var attribute models.UpdateCategoryAttribute
+	err := ctx.ShouldBind(&attribute)
+	if err != nil {
+		h.log.Error("error while binding category attribute:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	attribute.Name = strings.TrimSpace(attribute.Name)
+	if attribute.Name == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "name is required"})
+		return
+	}
+
+	attribute.Id = ctx.Param("id")
+	resp, err := h.strg.CategoryAttribute().Update(&attribute)
+	if err != nil {
+		h.log.Error("error category attribute update:", logger.Error(err))
+		if statusConflict(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success", "resp": resp})
+}
+
+// DeleteCategoryAttribute godoc
+// @Router       /category_attribute/{id} [DELETE]
+// @Summary      DELETE CATEGORY ATTRIBUTE
+// @Description  deletes attribute definition and its values from products of the category
+// @Tags         CATEGORY ATTRIBUTE
+// @Accept       json
+// @Produce      json
+// @Param        id    path     string  true  "id of category attribute" format(uuid)
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) DeleteCategoryAttribute(ctx *gin.Context) {
+	err := h.strg.CategoryAttribute().Delete(&models.CategoryAttributePrimaryKey{Id: ctx.Param("id")})
+	if err != nil {
+		h.log.Error("error deleting category attribute:", logger.Error(err))
+		if statusConflict(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success"})
+}
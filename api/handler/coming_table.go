@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"bytes"
+	"fmt"
 	"market/models"
+	"market/pkg/importer"
 	"market/pkg/logger"
+	"market/pkg/pdf"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -16,10 +19,12 @@ import (
 // @Tags         COMING TABLE
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
 // @Param        data  body      models.CreateComingTable  true  "coming_table data"
 // @Success      200  {string}  string
 // @Failure      400  {object}  models.ErrorResp
 // @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
 func (h *Handler) CreateComingTable(ctx *gin.Context) {
 	var coming_table models.CreateComingTable
@@ -33,6 +38,9 @@ func (h *Handler) CreateComingTable(ctx *gin.Context) {
 	resp, err := h.strg.ComingTable().Create(&coming_table)
 	if err != nil {
 		h.log.Error("error coming_table create:", logger.Error(err))
+		if statusConflict(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, "internal server error")
 		return
 	}
@@ -46,36 +54,49 @@ func (h *Handler) CreateComingTable(ctx *gin.Context) {
 // @Tags         COMING TABLE
 // @Accept       json
 // @Produce      json
-// @Param  		 limit         query     int        false  "limit"          minimum(1)     default(10)
+// @Param  		 limit         query     int        false  "limit"          minimum(1)     maximum(500)   default(10)
 // @Param  		 page          query     int        false  "page"           minimum(1)     default(1)
+// @Param   	 cursor        query     string     false  "next_cursor of previous page, page is ignored with it"
+// @Param   	 sort          query     string     false  "one of created_at, coming_id, date_time, - prefix sorts descending"  default(-created_at)
+// @Param   	 count         query     bool       false  "return total count, by default only without cursor"
+// @Param   	 date_from     query     string     false  "date_time from, date means its beginning"
+// @Param   	 date_to       query     string     false  "date_time to, date means its end"
+// @Param   	 field[op]     query     string     false  "filter on coming_id, branch_id, supplier_id, purchase_order_id, status, date_time, created_at, updated_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like"
 // @Param   	 coming_id        query     string     false  "coming_id"
 // @Param   	 branch_id        query     string     false  "branch_id"
+// @Param   	 supplier_id      query     string     false  "supplier_id"
+// @Param   	 format        query     string     false  "download whole filtered and sorted list as file"  Enums(csv, xlsx)
 // @Success      200  {object}  models.ComingTableGetListResponse
 // @Failure      400  {object}  models.ErrorResp
 // @Failure      404  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
 func (h *Handler) GetListComingTable(ctx *gin.Context) {
-	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	page, limit, opts, err := listParams(ctx)
 	if err != nil {
-		h.log.Error("error get page:", logger.Error(err))
-		ctx.JSON(http.StatusBadRequest, "invalid page param")
+		h.log.Error("error get list params:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
-	if err != nil {
-		h.log.Error("error get limit:", logger.Error(err))
-		ctx.JSON(http.StatusBadRequest, "invalid page param")
+
+	req := &models.ComingTableGetListRequest{
+		Page:        page,
+		Limit:       limit,
+		ListOptions: opts,
+		ComingId:    ctx.Query("coming_id"),
+		BranchId:    ctx.Query("branch_id"),
+		SupplierId:  ctx.Query("supplier_id"),
+	}
+	if format := ctx.Query("format"); format != "" {
+		h.exportComingTable(ctx, format, req)
 		return
 	}
 
-	resp, err := h.strg.ComingTable().GetList(&models.ComingTableGetListRequest{
-		Page:     page,
-		Limit:    limit,
-		ComingId: ctx.Query("coming_id"),
-		BranchId: ctx.Query("branch_id"),
-	})
+	resp, err := h.strg.ComingTable().GetList(req)
 	if err != nil {
 		h.log.Error("error ComingTable GetListComingTable:", logger.Error(err))
+		if listError(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, "internal server error")
 		return
 	}
@@ -108,6 +129,65 @@ func (h *Handler) GetByIDComingTable(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, resp)
 }
 
+// GetFullComingTable godoc
+// @Router       /coming_table/{id}/full [GET]
+// @Summary      GET FULL BY ID
+// @Description  gets coming_table with branch, supplier, products, category subtotals and grand totals
+// @Tags         COMING TABLE
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "ComingTable ID" format(uuid)
+// @Success      200  {object}  models.ComingTableFull
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetFullComingTable(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	resp, err := h.strg.ComingTable().GetFull(&models.ComingTablePrimaryKey{Id: id})
+	if err != nil {
+		h.log.Error("error get full coming_table:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetPdfComingTable godoc
+// @Router       /coming_table/{id}/pdf [GET]
+// @Summary      GOODS RECEIPT NOTE PDF
+// @Description  renders printable goods receipt note of coming_table with lines, totals and signature fields
+// @Tags         COMING TABLE
+// @Accept       json
+// @Produce      application/pdf
+// @Param        id   path      string  true  "ComingTable ID" format(uuid)
+// @Success      200  {file}    file
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetPdfComingTable(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	full, err := h.strg.ComingTable().GetFull(&models.ComingTablePrimaryKey{Id: id})
+	if err != nil {
+		h.log.Error("error get full coming_table:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	var buf bytes.Buffer
+	err = pdf.GoodsReceipt(&buf, full)
+	if err != nil {
+		h.log.Error("error while rendering goods receipt:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`inline; filename="receipt_%s.pdf"`, full.ComingTable.ComingId))
+	ctx.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
 // UpdateComingTable godoc
 // @Router       /coming_table/{id} [PUT]
 // @Summary      UPDATE COMING TABLE
@@ -115,11 +195,13 @@ func (h *Handler) GetByIDComingTable(ctx *gin.Context) {
 // @Tags         COMING TABLE
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
 // @Param        id    path     string  true  "id of coming_table" format(uuid)
 // @Param        data  body      models.CreateComingTable  true  "coming_table data"
 // @Success      200  {string}  string
 // @Failure      400  {object}  models.ErrorResp
 // @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
 func (h *Handler) UpdateComingTable(ctx *gin.Context) {
 	var coming_table models.UpdateComingTable
@@ -135,6 +217,9 @@ func (h *Handler) UpdateComingTable(ctx *gin.Context) {
 	resp, err := h.strg.ComingTable().Update(&coming_table)
 	if err != nil {
 		h.log.Error("error coming_table update:", logger.Error(err))
+		if statusConflict(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -153,6 +238,7 @@ func (h *Handler) UpdateComingTable(ctx *gin.Context) {
 // @Success      200  {string}  string
 // @Failure      400  {object}  models.ErrorResp
 // @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
 func (h *Handler) DeleteComingTable(ctx *gin.Context) {
 	id := ctx.Param("id")
@@ -160,9 +246,192 @@ func (h *Handler) DeleteComingTable(ctx *gin.Context) {
 	err := h.strg.ComingTable().Delete(&models.ComingTablePrimaryKey{Id: id})
 	if err != nil {
 		h.log.Error("error deleting coming_table:", logger.Error(err))
+		if statusConflict(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success"})
 }
+
+// UpdateStatusComingTable godoc
+// @Router       /coming_table/{id}/status [PUT]
+// @Summary      UPDATE COMING TABLE STATUS
+// @Description  moves coming_table between draft, receiving, awaiting_approval and cancelled when transition is allowed
+// @Tags         COMING TABLE
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        id    path     string  true  "id of coming_table" format(uuid)
+// @Param        data  body      models.UpdateComingTableStatus  true  "coming_table status"
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) UpdateStatusComingTable(ctx *gin.Context) {
+	var status models.UpdateComingTableStatus
+
+	err := ctx.ShouldBind(&status)
+	if err != nil {
+		h.log.Error("error while binding:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	if len(models.ComingTableSources(status.Status)) == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "unknown coming_table status " + status.Status})
+		return
+	}
+
+	status.Id = ctx.Param("id")
+	h.updateStatusComingTable(ctx, &status)
+}
+
+// CancelComingTable godoc
+// @Router       /coming_table/{id}/cancel [POST]
+// @Summary      CANCEL COMING TABLE
+// @Description  cancels coming_table which is not posted yet
+// @Tags         COMING TABLE
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        id    path     string  true  "id of coming_table" format(uuid)
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) CancelComingTable(ctx *gin.Context) {
+	h.updateStatusComingTable(ctx, &models.UpdateComingTableStatus{
+		Id:     ctx.Param("id"),
+		Status: models.ComingTableCancelled,
+	})
+}
+
+func (h *Handler) updateStatusComingTable(ctx *gin.Context, status *models.UpdateComingTableStatus) {
+	resp, err := h.strg.ComingTable().UpdateStatus(status)
+	if err != nil {
+		h.log.Error("error coming_table status update:", logger.Error(err))
+		if statusConflict(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success", "resp": resp})
+}
+
+// ReverseComingTable godoc
+// @Router       /coming_table/{id}/reverse [POST]
+// @Summary      REVERSE COMING TABLE
+// @Description  reverses posted coming_table by compensating stock movements which are subtracted from remaining
+// @Tags         COMING TABLE
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        id    path     string  true  "id of coming_table" format(uuid)
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) ReverseComingTable(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	err := h.strg.ComingTable().Reverse(&models.ComingTablePrimaryKey{Id: id})
+	if err != nil {
+		h.log.Error("error reversing coming_table:", logger.Error(err))
+		if statusConflict(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success", "resp": id})
+}
+
+// ImportComingTable godoc
+// @Router       /coming_table/{id}/import [POST]
+// @Summary      IMPORT DELIVERY NOTE
+// @Description  adds products of supplier delivery note in csv or xlsx to coming_table the same way as bulk scanning, columns are taken from import template of the supplier
+// @Tags         COMING TABLE
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        id    path      string  true  "id of coming_table" format(uuid)
+// @Param        file  formData  file    true  "csv or xlsx delivery note"
+// @Success      200  {object}  models.ComingTableProductImportResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) ImportComingTable(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	comingTable, err := h.strg.ComingTable().GetByID(&models.ComingTablePrimaryKey{Id: id})
+	if err != nil {
+		h.log.Error("error get coming_table:", logger.Error(err))
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	template := models.DefaultSupplierImportTemplate("")
+	if comingTable.SupplierId != "" {
+		template, err = h.strg.Supplier().GetImportTemplate(&models.SupplierPrimaryKey{Id: comingTable.SupplierId})
+		if err != nil {
+			h.log.Error("error get supplier import template:", logger.Error(err))
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	rows, err := h.readSheet(ctx)
+	if err != nil {
+		h.log.Error("error while reading import file:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	scans, rowErrs, err := importer.DeliveryRows(rows, template)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := &models.ComingTableProductImportResponse{
+		ComingTableProductBulkResponse: &models.ComingTableProductBulkResponse{Products: make([]*models.ComingTableProductScanResult, 0)},
+		Errors:                         rowErrs,
+	}
+	if len(scans) > 0 {
+		resp.ComingTableProductBulkResponse, err = h.strg.ComingTableProduct().CreateBulk(&models.CreateComingTableProductBulk{
+			ComingTableId: id,
+			Products:      scans,
+		})
+		if err != nil {
+			h.log.Error("error coming_table import:", logger.Error(err))
+			if statusConflict(ctx, err) {
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	if resp.Unknown < len(resp.Products) {
+		h.startReceiving(id)
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// startReceiving moves draft coming_table to receiving once first product is scanned
+func (h *Handler) startReceiving(comingTableId string) {
+	err := h.strg.ComingTable().StartReceiving(&models.ComingTablePrimaryKey{Id: comingTableId})
+	if err != nil {
+		h.log.Error("error while starting receiving of coming_table:", logger.Error(err))
+	}
+}
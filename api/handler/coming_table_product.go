@@ -4,7 +4,6 @@ import (
 	"market/models"
 	"market/pkg/logger"
 	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -12,16 +11,18 @@ import (
 // CreateComingTableProduct godoc
 // @Router       /coming_product/{coming_table_id} [POST]
 // @Summary      CREATE COMING TABLE PRODUCT
-// @Description adds coming_product data to db based on given info in body
+// @Description adds coming_product data to db based on given info in body, unknown barcode with name and price is added as pending product
 // @Tags         COMING TABLE PRODUCT
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
 // @Param        coming_table_id path string true "Coming Table ID"
 // @Param        barcode query string true "Barcode value"
-// @Param        data  body      models.CreateComingTableProductCount  true  "coming_product count"
+// @Param        data  body      models.CreateComingTableProductCount  true  "coming_product count, name, price and category_id for unknown barcode"
 // @Success      200  {string}  string
 // @Failure      400  {object}  models.ErrorResp
 // @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
 func (h *Handler) CreateComingTableProduct(ctx *gin.Context) {
 
@@ -36,11 +37,14 @@ func (h *Handler) CreateComingTableProduct(ctx *gin.Context) {
 		return
 	}
 
-	// checking coming_table info weather it is in_process or finished
+	// checking coming_table info weather products still may be added
 	comingTableId := models.ComingTablePrimaryKey{Id: comingTableID}
 	_, err = h.strg.ComingTable().GetStatus(&comingTableId)
 	if err != nil {
 		h.log.Error("error while getting coming table status", logger.Error(err))
+		if statusConflict(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -50,7 +54,27 @@ func (h *Handler) CreateComingTableProduct(ctx *gin.Context) {
 	productDetails, err := h.strg.Product().GetByBarcode(&productBarcode)
 	if err != nil {
 		h.log.Error("error while getting product details:", logger.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Not Found Product with that barcode"})
+		if coming_product.ProductName == "" || coming_product.ProductPrice <= 0 {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Not Found Product with that barcode"})
+			return
+		}
+
+		// unknown barcode with given name and price: create pending product and add it to coming table together
+		coming_product.ProductBarcode = barcodeQ
+		coming_product.TotalPrice = (coming_product.ProductPrice * float64(coming_product.Count))
+		coming_product.ComingTableId = comingTableID
+
+		resp, err := h.strg.ComingTableProduct().CreateWithProduct(&coming_product)
+		if err != nil {
+			h.log.Error("error coming_product create with pending product:", logger.Error(err))
+			if statusConflict(ctx, err) {
+				return
+			}
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.startReceiving(comingTableID)
+		ctx.JSON(http.StatusCreated, gin.H{"code": http.StatusCreated, "message": "added pending product and coming_product_table", "resp": resp, "order": h.orderProgress(comingTableID, barcodeQ)})
 		return
 	}
 
@@ -62,42 +86,64 @@ func (h *Handler) CreateComingTableProduct(ctx *gin.Context) {
 	coming_product.TotalPrice = (productDetails.Price * float64(coming_product.Count))
 	coming_product.ComingTableId = comingTableID
 
-	//  Checking exists product by shtrixcode in coming_table_product table
-	barcode := models.ComingTableProductBarcode{Barcode: barcodeQ, ComingTableId: comingTableID}
-	id, err := h.strg.ComingTableProduct().CheckExistProduct(&barcode)
+	// adding the line or counts to the line of the same barcode is one statement, so concurrent scans do not duplicate it
+	id, created, err := h.strg.ComingTableProduct().Upsert(&coming_product)
 	if err != nil {
-		h.log.Info("product or coming_table_id not found:", logger.Error(err))
-
-		// if product or coming_table_id is not exists, ADD Coming product table
-		resp, err := h.strg.ComingTableProduct().Create(&coming_product)
-		if err != nil {
-			h.log.Error("error coming_product create:", logger.Error(err))
-			ctx.JSON(http.StatusBadRequest, err)
+		h.log.Error("error coming_product upsert:", logger.Error(err))
+		if statusConflict(ctx, err) {
 			return
 		}
-		ctx.JSON(http.StatusCreated, gin.H{"code": http.StatusCreated, "message": "added coming_product_table", "resp": resp})
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	h.startReceiving(comingTableID)
 
+	if created {
+		ctx.JSON(http.StatusCreated, gin.H{"code": http.StatusCreated, "message": "added coming_product_table", "resp": id, "order": h.orderProgress(comingTableID, barcodeQ)})
 		return
 	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "updated existing coming_product_table", "resp": comingTableID, "order": h.orderProgress(comingTableID, barcodeQ)})
+}
 
-	// if exits Update Coming Product Table
-	var updatingData = models.UpdateComingTableProduct{
-		Id:             id,
-		CategoryId:     coming_product.CategoryId,
-		ProductName:    coming_product.ProductName,
-		ProductPrice:   coming_product.ProductPrice,
-		ProductBarcode: barcodeQ,
-		Count:          coming_product.Count,
-		TotalPrice:     (productDetails.Price * float64(coming_product.Count)),
-		ComingTableId:  comingTableID,
+// CreateBulkComingTableProduct godoc
+// @Router       /coming_product/{coming_table_id}/bulk [POST]
+// @Summary      CREATE COMING TABLE PRODUCTS IN BULK
+// @Description adds buffered scans of barcodes to coming_table in one transaction, unknown barcodes are reported back
+// @Tags         COMING TABLE PRODUCT
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        coming_table_id path string true "Coming Table ID"
+// @Param        data  body      models.CreateComingTableProductBulk  true  "scanned barcodes and counts"
+// @Success      200  {object}  models.ComingTableProductBulkResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) CreateBulkComingTableProduct(ctx *gin.Context) {
+	var bulk models.CreateComingTableProductBulk
+	err := ctx.ShouldBind(&bulk)
+	if err != nil {
+		h.log.Error("error while binding coming_product bulk:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, "invalid body")
+		return
 	}
-	r, err := h.strg.ComingTableProduct().UpdateIdExists(&updatingData)
+
+	bulk.ComingTableId = ctx.Param("coming_table_id")
+	resp, err := h.strg.ComingTableProduct().CreateBulk(&bulk)
 	if err != nil {
-		h.log.Info("error coming_table_product update:", logger.Error(err))
-		ctx.JSON(http.StatusInternalServerError, err.Error())
+		h.log.Error("error coming_product bulk create:", logger.Error(err))
+		if statusConflict(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	ctx.JSON(http.StatusOK, gin.H{"message": "updated existing coming_product_table", "resp": r})
+
+	if resp.Unknown < len(resp.Products) {
+		h.startReceiving(bulk.ComingTableId)
+	}
+	ctx.JSON(http.StatusOK, resp)
 }
 
 // ListComingTableProducts godoc
@@ -107,36 +153,49 @@ func (h *Handler) CreateComingTableProduct(ctx *gin.Context) {
 // @Tags         COMING TABLE PRODUCT
 // @Accept       json
 // @Produce      json
-// @Param  		 limit         query     int        false  "limit"          minimum(1)     default(10)
+// @Param  		 limit         query     int        false  "limit"          minimum(1)     maximum(500)   default(10)
 // @Param  		 page          query     int        false  "page"           minimum(1)     default(1)
+// @Param   	 cursor        query     string     false  "next_cursor of previous page, page is ignored with it"
+// @Param   	 sort          query     string     false  "one of created_at, name, price, count, total_price, barcode, - prefix sorts descending"  default(-created_at)
+// @Param   	 count         query     bool       false  "return total count, by default only without cursor"
+// @Param   	 date_from     query     string     false  "created_at from, date means its beginning"
+// @Param   	 date_to       query     string     false  "created_at to, date means its end"
+// @Param   	 field[op]     query     string     false  "filter on name, barcode, price, count, total_price, category_id, product_id, coming_table_id, created_at, updated_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like"
+// @Param   	 coming_table_id    query     string     false  "coming_table_id"
 // @Param   	 category_id        query     string     false  "category_id"
 // @Param   	 barcode            query     string     false  "barcode"
+// @Param   	 format        query     string     false  "download whole filtered and sorted list as file"  Enums(csv, xlsx)
 // @Success      200  {object}  models.ComingTableProductGetListResponse
 // @Failure      400  {object}  models.ErrorResp
 // @Failure      404  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
 func (h *Handler) GetListComingTableProduct(ctx *gin.Context) {
-	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
-	if err != nil {
-		h.log.Error("error get page:", logger.Error(err))
-		ctx.JSON(http.StatusBadRequest, "invalid page param")
-		return
-	}
-	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	page, limit, opts, err := listParams(ctx)
 	if err != nil {
-		h.log.Error("error get limit:", logger.Error(err))
-		ctx.JSON(http.StatusBadRequest, "invalid page param")
+		h.log.Error("error get list params:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
 
-	resp, err := h.strg.ComingTableProduct().GetList(&models.ComingTableProductGetListRequest{
+	req := &models.ComingTableProductGetListRequest{
 		Page:           page,
 		Limit:          limit,
+		ListOptions:    opts,
+		ComingTableId:  ctx.Query("coming_table_id"),
 		CategoryId:     ctx.Query("category_id"),
 		ProductBarcode: ctx.Query("barcode"),
-	})
+	}
+	if format := ctx.Query("format"); format != "" {
+		h.exportComingTableProduct(ctx, format, req)
+		return
+	}
+
+	resp, err := h.strg.ComingTableProduct().GetList(req)
 	if err != nil {
 		h.log.Error("error ComingTableProduct GetListComingTableProduct:", logger.Error(err))
+		if listError(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, "internal server error")
 		return
 	}
@@ -147,12 +206,13 @@ func (h *Handler) GetListComingTableProduct(ctx *gin.Context) {
 // GetComingTableProduct godoc
 // @Router       /coming_product/{id} [GET]
 // @Summary      GET BY ID
-// @Description  gets coming_product by ID
+// @Description  gets coming_product by ID, ETag header is its version for If-Match of update
 // @Tags         COMING TABLE PRODUCT
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "ComingTableProduct ID" format(uuid)
 // @Success      200  {object}  models.ComingTableProduct
+// @Header       200  {string}  ETag  "version of coming_product"
 // @Failure      400  {object}  models.ErrorResp
 // @Failure      404  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
@@ -166,21 +226,27 @@ func (h *Handler) GetByIDComingTableProduct(ctx *gin.Context) {
 		return
 	}
 
+	setETag(ctx, resp.Version)
 	ctx.JSON(http.StatusOK, resp)
 }
 
 // UpdateComingTableProduct godoc
 // @Router       /coming_product/{id} [PUT]
 // @Summary      UPDATE COMING TABLE PRODUCT
-// @Description  UPDATES COMING TABLE PRODUCT BASED ON GIVEN DATA AND ID
+// @Description  UPDATES BARCODE AND COUNT OF COMING TABLE PRODUCT BY ID, NAME, CATEGORY AND PRICE ARE TAKEN FROM THE PRODUCT, WITH If-Match ONLY THAT VERSION IS UPDATED
 // @Tags         COMING TABLE PRODUCT
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
 // @Param        id    path     string  true  "id of coming_product" format(uuid)
+// @Param        If-Match  header  string  false  "ETag of coming_product read before"
 // @Param        data  body      models.CreateComingTableProduct  true  "coming_product data"
 // @Success      200  {string}  string
+// @Header       200  {string}  ETag  "new version of coming_product"
 // @Failure      400  {object}  models.ErrorResp
 // @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
+// @Failure      412  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
 func (h *Handler) UpdateComingTableProduct(ctx *gin.Context) {
 	var coming_product models.UpdateComingTableProduct
@@ -192,14 +258,24 @@ func (h *Handler) UpdateComingTableProduct(ctx *gin.Context) {
 		return
 	}
 
+	coming_product.Version, err = ifMatch(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	coming_product.Id = ctx.Param("id")
 	resp, err := h.strg.ComingTableProduct().Update(&coming_product)
 	if err != nil {
 		h.log.Error("error coming_product update:", logger.Error(err))
+		if preconditionFailed(ctx, err) || statusConflict(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	setETag(ctx, coming_product.Version)
 	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success", "resp": resp})
 }
 
@@ -214,6 +290,7 @@ func (h *Handler) UpdateComingTableProduct(ctx *gin.Context) {
 // @Success      200  {string}  string
 // @Failure      400  {object}  models.ErrorResp
 // @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
 func (h *Handler) DeleteComingTableProduct(ctx *gin.Context) {
 	id := ctx.Param("id")
@@ -221,6 +298,9 @@ func (h *Handler) DeleteComingTableProduct(ctx *gin.Context) {
 	err := h.strg.ComingTableProduct().Delete(&models.ComingTableProductPrimaryKey{Id: id})
 	if err != nil {
 		h.log.Error("error deleting coming_product:", logger.Error(err))
+		if statusConflict(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
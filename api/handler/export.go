@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"fmt"
+	"market/models"
+	"market/pkg/logger"
+	"market/pkg/sheet"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// export writes header and rows given by run to the response as csv or xlsx attachment,
+// rows are written while they are read so the whole list is never kept in memory. The file is started
+// only with the first row or when run ends, so errors of filters found before it are still answered as JSON
+func (h *Handler) export(ctx *gin.Context, format, name string, header []interface{}, run func(write func(...interface{}) error) error) {
+	if format != sheet.FormatCSV && format != sheet.FormatXLSX {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "format must be csv or xlsx"})
+		return
+	}
+
+	var writer sheet.Writer
+	start := func() error {
+		if writer != nil {
+			return nil
+		}
+
+		ctx.Header("Content-Type", sheet.ContentType(format))
+		ctx.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s_%s.%s"`, name, time.Now().Format("2006-01-02"), format))
+
+		var err error
+		writer, err = sheet.NewWriter(ctx.Writer, format)
+		if err != nil {
+			return err
+		}
+		return writer.Write(header)
+	}
+
+	err := run(func(values ...interface{}) error {
+		err := start()
+		if err != nil {
+			return err
+		}
+		return writer.Write(values)
+	})
+	if err == nil {
+		err = start()
+	}
+	if err == nil {
+		err = writer.Close()
+	}
+	if err != nil {
+		h.log.Error("error while exporting "+name+":", logger.Error(err))
+		if writer != nil {
+			writer.Discard()
+		}
+		// once the file started to be sent the status can not be changed anymore
+		if !ctx.Writer.Written() {
+			ctx.Writer.Header().Del("Content-Disposition")
+			ctx.Writer.Header().Del("Content-Type")
+			if listError(ctx, err) {
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+	}
+}
+
+func (h *Handler) exportRemaining(ctx *gin.Context, format string, req *models.RemainingGetListRequest) {
+	header := []interface{}{"Branch", "Category", "Name", "Barcode", "Price", "Count", "Total price", "Created at", "Updated at"}
+
+	h.export(ctx, format, "remaining", header, func(write func(...interface{}) error) error {
+		return h.strg.Remaining().Export(req, func(remaining *models.Remaining, names *models.ExportNames) error {
+			return write(
+				names.BranchName,
+				names.CategoryName,
+				remaining.Name,
+				remaining.Barcode,
+				remaining.Price,
+				remaining.Count,
+				remaining.TotalPrice,
+				remaining.CreatedAt,
+				remaining.UpdatedAt,
+			)
+		})
+	})
+}
+
+func (h *Handler) exportProduct(ctx *gin.Context, format string, req *models.ProductGetListRequest) {
+	header := []interface{}{"Name", "Barcode", "Price", "Category", "Status", "Created at", "Updated at"}
+
+	h.export(ctx, format, "products", header, func(write func(...interface{}) error) error {
+		return h.strg.Product().Export(req, func(product *models.Product, names *models.ExportNames) error {
+			return write(
+				product.Name,
+				product.Barcode,
+				product.Price,
+				names.CategoryName,
+				product.Status,
+				product.CreatedAt,
+				product.UpdatedAt,
+			)
+		})
+	})
+}
+
+func (h *Handler) exportComingTable(ctx *gin.Context, format string, req *models.ComingTableGetListRequest) {
+	header := []interface{}{"Coming ID", "Branch", "Supplier", "Date", "Status", "Created at", "Updated at"}
+
+	h.export(ctx, format, "coming_tables", header, func(write func(...interface{}) error) error {
+		return h.strg.ComingTable().Export(req, func(comingTable *models.ComingTable, names *models.ExportNames) error {
+			return write(
+				comingTable.ComingId,
+				names.BranchName,
+				names.SupplierName,
+				comingTable.DateTime,
+				comingTable.Status,
+				comingTable.CreatedAt,
+				comingTable.UpdatedAt,
+			)
+		})
+	})
+}
+
+func (h *Handler) exportComingTableProduct(ctx *gin.Context, format string, req *models.ComingTableProductGetListRequest) {
+	header := []interface{}{"Coming ID", "Category", "Name", "Barcode", "Price", "Count", "Total price", "Created at"}
+
+	h.export(ctx, format, "coming_products", header, func(write func(...interface{}) error) error {
+		return h.strg.ComingTableProduct().Export(req, func(product *models.ComingTableProduct, names *models.ExportNames) error {
+			return write(
+				names.ComingId,
+				names.CategoryName,
+				product.ProductName,
+				product.ProductBarcode,
+				product.ProductPrice,
+				product.Count,
+				product.TotalPrice,
+				product.CreatedAt,
+			)
+		})
+	})
+}
@@ -1,15 +1,174 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
+	"market/models"
+	"market/pkg/filestore"
 	"market/pkg/logger"
 	"market/storage"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
 )
 
 type Handler struct {
-	strg storage.StorageI
-	log  logger.LoggerI
+	strg  storage.StorageI
+	log   logger.LoggerI
+	files filestore.Store
+}
+
+func NewHandler(strg storage.StorageI, loger logger.LoggerI, files filestore.Store) *Handler {
+	return &Handler{strg: strg, log: loger, files: files}
+}
+
+// statusConflict answers 409 with document state when err is caused by document status, closed period
+// or constraint of stock data
+func statusConflict(ctx *gin.Context, err error) bool {
+	var constraintErr *storage.ConstraintError
+	if errors.As(err, &constraintErr) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": constraintErr.Error(), "constraint": constraintErr})
+		return true
+	}
+
+	var periodErr *storage.PeriodClosedError
+	if errors.As(err, &periodErr) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": periodErr.Error(), "period": periodErr})
+		return true
+	}
+
+	var statusErr *storage.StatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+
+	ctx.JSON(http.StatusConflict, gin.H{"error": statusErr.Error(), "document": statusErr})
+	return true
+}
+
+// setETag sends version of stock row as ETag, clients send it back in If-Match to update that version only
+func setETag(ctx *gin.Context, version int) {
+	ctx.Header("ETag", fmt.Sprintf(`"%d"`, version))
+}
+
+// ifMatch returns version of If-Match header, 0 when it is missing or "*"
+func ifMatch(ctx *gin.Context) (int, error) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(header, "W/"), `"`))
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid If-Match %q", header)
+	}
+
+	return version, nil
 }
 
-func NewHandler(strg storage.StorageI, loger logger.LoggerI) *Handler {
-	return &Handler{strg: strg, log: loger}
+// preconditionFailed answers 412 with current version when err is caused by stale If-Match
+func preconditionFailed(ctx *gin.Context, err error) bool {
+	var versionErr *storage.VersionError
+	if !errors.As(err, &versionErr) {
+		return false
+	}
+
+	setETag(ctx, versionErr.Version)
+	ctx.JSON(http.StatusPreconditionFailed, gin.H{"error": versionErr.Error(), "version": versionErr})
+	return true
+}
+
+// listParams reads page, limit, cursor, sort, count, date_from, date_to and field[op]=value filter params
+// of list endpoints. Total count is returned by default for pages and skipped for cursor requests,
+// count param overrides it
+func listParams(ctx *gin.Context) (page, limit int, opts models.ListOptions, err error) {
+	page, err = strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	if err != nil {
+		return 0, 0, opts, fmt.Errorf("invalid page param")
+	}
+
+	limit, err = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil {
+		return 0, 0, opts, fmt.Errorf("invalid limit param")
+	}
+
+	opts.Cursor = ctx.Query("cursor")
+	opts.Sort = ctx.Query("sort")
+	opts.WithCount = opts.Cursor == ""
+
+	if count := ctx.Query("count"); count != "" {
+		opts.WithCount, err = strconv.ParseBool(count)
+		if err != nil {
+			return 0, 0, opts, fmt.Errorf("invalid count param")
+		}
+	}
+
+	opts.DateFrom, err = parseDateFrom(ctx.Query("date_from"))
+	if err != nil {
+		return 0, 0, opts, fmt.Errorf("invalid date_from param")
+	}
+	opts.DateTo, err = parseAsOf(ctx.Query("date_to"))
+	if err != nil {
+		return 0, 0, opts, fmt.Errorf("invalid date_to param")
+	}
+
+	opts.Filters = listFilters(ctx.Request.URL.Query())
+	return page, limit, opts, nil
+}
+
+// listFilters collects field[op]=value params, repeated param gives several filters.
+// They are sorted by field and op, so the same filters always give the same query
+func listFilters(query url.Values) []models.ListFilter {
+	filters := make([]models.ListFilter, 0)
+
+	for key, values := range query {
+		open := strings.IndexByte(key, '[')
+		if open < 1 || !strings.HasSuffix(key, "]") {
+			continue
+		}
+
+		for _, value := range values {
+			filters = append(filters, models.ListFilter{Field: key[:open], Op: key[open+1 : len(key)-1], Value: value})
+		}
+	}
+
+	sort.SliceStable(filters, func(i, j int) bool {
+		if filters[i].Field != filters[j].Field {
+			return filters[i].Field < filters[j].Field
+		}
+		return filters[i].Op < filters[j].Op
+	})
+
+	return filters
+}
+
+// parseDateFrom is parseAsOf for start of a range, date means the beginning of that day
+func parseDateFrom(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateTime, time.DateOnly} {
+		if from, err := time.Parse(layout, value); err == nil {
+			return from, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// listError answers 400 when err is caused by page, limit, sort, cursor or filters of list request
+func listError(ctx *gin.Context, err error) bool {
+	var listErr *storage.ListError
+	if !errors.As(err, &listErr) {
+		return false
+	}
+
+	ctx.JSON(http.StatusBadRequest, gin.H{"error": listErr.Error(), "param": listErr})
+	return true
 }
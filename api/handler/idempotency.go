@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"market/models"
+	"market/pkg/logger"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// responseRecorder keeps copy of response body written by handler
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency replays stored response to POST and PUT requests retried with the same Idempotency-Key during ttl,
+// the key reused for different request is rejected with 422 and retry of request still in progress with 409,
+// responses with 5xx status are not stored so the request may be retried
+func (h *Handler) Idempotency(ttl time.Duration) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		key := ctx.GetHeader("Idempotency-Key")
+		if key == "" || (ctx.Request.Method != http.MethodPost && ctx.Request.Method != http.MethodPut) {
+			ctx.Next()
+			return
+		}
+
+		body, err := io.ReadAll(ctx.Request.Body)
+		if err != nil {
+			ctx.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid body"})
+			return
+		}
+		ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		hash := sha256.New()
+		hash.Write([]byte(ctx.Request.Method + " " + ctx.Request.URL.RequestURI() + "\n" + ctx.GetHeader("Content-Type") + "\n"))
+		hash.Write(body)
+
+		req := &models.IdempotencyKey{
+			Key:         key,
+			Method:      ctx.Request.Method,
+			Path:        ctx.Request.URL.RequestURI(),
+			Fingerprint: hex.EncodeToString(hash.Sum(nil)),
+			ExpiresAt:   time.Now().Add(ttl),
+		}
+
+		stored, created, err := h.strg.Idempotency().Begin(req)
+		if err != nil {
+			h.log.Error("error while claiming idempotency key:", logger.Error(err))
+			ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+
+		if !created {
+			switch {
+			case stored.Fingerprint != req.Fingerprint:
+				ctx.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": "Idempotency-Key is already used for another request"})
+			case stored.StatusCode == 0:
+				ctx.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": "request with this Idempotency-Key is in progress"})
+			default:
+				for name, values := range stored.Headers {
+					for _, value := range values {
+						ctx.Writer.Header().Add(name, value)
+					}
+				}
+				ctx.Header("Idempotent-Replayed", "true")
+				ctx.Status(stored.StatusCode)
+				ctx.Writer.Write(stored.Response)
+				ctx.Abort()
+			}
+			return
+		}
+
+		// panic of handler releases the key before it is recovered by gin
+		defer func() {
+			if p := recover(); p != nil {
+				if err := h.strg.Idempotency().Release(req); err != nil {
+					h.log.Error("error while releasing idempotency key:", logger.Error(err))
+				}
+				panic(p)
+			}
+		}()
+
+		recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+		ctx.Next()
+
+		req.StatusCode = recorder.Status()
+		if req.StatusCode >= http.StatusInternalServerError {
+			err = h.strg.Idempotency().Release(req)
+		} else {
+			req.Headers = recorder.Header().Clone()
+			req.Response = recorder.body.Bytes()
+			err = h.strg.Idempotency().Finish(req)
+		}
+		if err != nil {
+			h.log.Error("error while saving idempotency key:", logger.Error(err))
+		}
+	}
+}
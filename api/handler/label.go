@@ -0,0 +1,161 @@
+package handler
+
+import (
+	"bytes"
+	"market/models"
+	"market/pkg/label"
+	"market/pkg/logger"
+	"market/pkg/pdf"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PrintLabels godoc
+// @Router       /label [POST]
+// @Summary      PRINT LABELS
+// @Description  renders shelf labels with name, price and barcode of given products or of all lines of coming_table as A4 pdf sheets or zpl for thermal printers, label size is taken from template of the branch
+// @Tags         LABEL
+// @Accept       json
+// @Produce      application/pdf
+// @Produce      plain
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        data  body      models.LabelRequest  true  "products to print"
+// @Success      200  {file}    file
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) PrintLabels(ctx *gin.Context) {
+	var req models.LabelRequest
+	err := ctx.ShouldBind(&req)
+	if err != nil {
+		h.log.Error("error while binding label request:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, "invalid body")
+		return
+	}
+	if req.ComingTableId == "" && len(req.ProductIds) == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "product_ids or coming_table_id is required"})
+		return
+	}
+	if req.Format == "" {
+		req.Format = models.LabelFormatPDF
+	}
+	if req.Format != models.LabelFormatPDF && req.Format != models.LabelFormatZPL {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "format must be pdf or zpl"})
+		return
+	}
+
+	if req.ComingTableId != "" && req.BranchId == "" {
+		comingTable, err := h.strg.ComingTable().GetByID(&models.ComingTablePrimaryKey{Id: req.ComingTableId})
+		if err != nil {
+			h.log.Error("error get coming_table:", logger.Error(err))
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+			return
+		}
+		req.BranchId = comingTable.BranchId
+	}
+
+	template, err := h.strg.Label().GetTemplate(&models.BranchPrimaryKey{Id: req.BranchId})
+	if err != nil {
+		h.log.Error("error get label template:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	items, err := h.strg.Label().GetItems(&req)
+	if err != nil {
+		h.log.Error("error get label items:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var buf bytes.Buffer
+	if req.Format == models.LabelFormatZPL {
+		err = label.ZPL(&buf, template, items)
+	} else {
+		err = pdf.Labels(&buf, template, items)
+	}
+	if err != nil {
+		h.log.Error("error while rendering labels:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.Format == models.LabelFormatZPL {
+		ctx.Header("Content-Disposition", `attachment; filename="labels.zpl"`)
+		ctx.Data(http.StatusOK, "text/plain; charset=utf-8", buf.Bytes())
+		return
+	}
+	ctx.Header("Content-Disposition", `inline; filename="labels.pdf"`)
+	ctx.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+// GetLabelTemplate godoc
+// @Router       /branch/{id}/label_template [GET]
+// @Summary      GET LABEL TEMPLATE
+// @Description  gets label size and barcode type of branch, default template is returned when it is not saved
+// @Tags         LABEL
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Branch ID" format(uuid)
+// @Success      200  {object}  models.LabelTemplate
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetLabelTemplate(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	resp, err := h.strg.Label().GetTemplate(&models.BranchPrimaryKey{Id: id})
+	if err != nil {
+		h.log.Error("error get label template:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// SaveLabelTemplate godoc
+// @Router       /branch/{id}/label_template [PUT]
+// @Summary      SAVE LABEL TEMPLATE
+// @Description  saves label size in millimeters, labels per row of A4 sheet, font size, barcode type and printer dpi of branch
+// @Tags         LABEL
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        id    path     string  true  "id of branch" format(uuid)
+// @Param        data  body      models.LabelTemplate  true  "label template"
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) SaveLabelTemplate(ctx *gin.Context) {
+	template := models.DefaultLabelTemplate(ctx.Param("id"))
+
+	err := ctx.ShouldBind(template)
+	if err != nil {
+		h.log.Error("error while binding:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	if template.Width <= 0 || template.Height <= 0 || template.Columns < 1 || template.FontSize <= 0 || template.Dpi <= 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "width, height, columns, font_size and dpi must be positive"})
+		return
+	}
+	switch template.BarcodeType {
+	case models.BarcodeAuto, models.BarcodeEAN13, models.BarcodeCode128:
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "barcode_type must be auto, ean13 or code128"})
+		return
+	}
+
+	template.BranchId = ctx.Param("id")
+	resp, err := h.strg.Label().SaveTemplate(template)
+	if err != nil {
+		h.log.Error("error label template save:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success", "resp": resp})
+}
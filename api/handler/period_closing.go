@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"market/models"
+	"market/pkg/logger"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ClosePeriod godoc
+// @Router       /period_closing [POST]
+// @Summary      CLOSE PERIOD
+// @Description  closes month of branch, after that stock documents dated inside it can not be created, changed, posted or reversed until the month is reopened
+// @Tags         PERIOD CLOSING
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        data  body      models.PeriodClosingRequest  true  "branch, month like 2023-09 and user closing it"
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) ClosePeriod(ctx *gin.Context) {
+	var req models.PeriodClosingRequest
+	err := ctx.ShouldBind(&req)
+	if err != nil {
+		h.log.Error("error while binding period closing:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, "invalid body")
+		return
+	}
+	if req.BranchId == "" || req.Month == "" || req.User == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "branch_id, month and user are required"})
+		return
+	}
+
+	err = h.strg.PeriodClosing().Close(&req)
+	if err != nil {
+		h.log.Error("error closing period:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success"})
+}
+
+// ReopenPeriod godoc
+// @Router       /period_closing/reopen [POST]
+// @Summary      REOPEN PERIOD
+// @Description  reopens closed month of branch, user and reason are written to the period closing log
+// @Tags         PERIOD CLOSING
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        data  body      models.PeriodClosingRequest  true  "branch, month like 2023-09, user and reason"
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) ReopenPeriod(ctx *gin.Context) {
+	var req models.PeriodClosingRequest
+	err := ctx.ShouldBind(&req)
+	if err != nil {
+		h.log.Error("error while binding period reopening:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, "invalid body")
+		return
+	}
+	if req.BranchId == "" || req.Month == "" || req.User == "" || req.Reason == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "branch_id, month, user and reason are required"})
+		return
+	}
+
+	err = h.strg.PeriodClosing().Reopen(&req)
+	if err != nil {
+		h.log.Error("error reopening period:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success"})
+}
+
+// GetListPeriodClosing godoc
+// @Router       /period_closing [GET]
+// @Summary      LIST CLOSED PERIODS
+// @Description  gets closed months of all branches or of the given one
+// @Tags         PERIOD CLOSING
+// @Accept       json
+// @Produce      json
+// @Param        branch_id  query     string  false  "branch_id" format(uuid)
+// @Success      200  {object}  models.PeriodClosingGetListResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetListPeriodClosing(ctx *gin.Context) {
+	resp, err := h.strg.PeriodClosing().GetList(&models.PeriodClosingGetListRequest{
+		BranchId: ctx.Query("branch_id"),
+	})
+	if err != nil {
+		h.log.Error("error period closing GetList:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetPeriodClosingLog godoc
+// @Router       /period_closing/log [GET]
+// @Summary      PERIOD CLOSING LOG
+// @Description  gets who closed and reopened months and why, newest first
+// @Tags         PERIOD CLOSING
+// @Accept       json
+// @Produce      json
+// @Param        branch_id  query     string  false  "branch_id" format(uuid)
+// @Success      200  {object}  models.PeriodClosingLogResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetPeriodClosingLog(ctx *gin.Context) {
+	resp, err := h.strg.PeriodClosing().GetLog(&models.PeriodClosingGetListRequest{
+		BranchId: ctx.Query("branch_id"),
+	})
+	if err != nil {
+		h.log.Error("error period closing GetLog:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"fmt"
 	"market/models"
+	"market/pkg/importer"
 	"market/pkg/logger"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -12,14 +15,15 @@ import (
 // CreateProduct godoc
 // @Router       /product [POST]
 // @Summary      CREATE PRODUCT
-// @Description adds product data to db based on given info in body
+// @Description adds product data to db based on given info in body, attributes must be defined for its category
 // @Tags         PRODUCT
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
 // @Param        data  body      models.CreateProduct  true  "product data"
 // @Success      200  {string}  string
 // @Failure      400  {object}  models.ErrorResp
-// @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
 func (h *Handler) CreateProduct(ctx *gin.Context) {
 	var product models.CreateProduct
@@ -33,6 +37,9 @@ func (h *Handler) CreateProduct(ctx *gin.Context) {
 	resp, err := h.strg.Product().Create(&product)
 	if err != nil {
 		h.log.Error("error product create:", logger.Error(err))
+		if statusConflict(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -46,36 +53,49 @@ func (h *Handler) CreateProduct(ctx *gin.Context) {
 // @Tags         PRODUCT
 // @Accept       json
 // @Produce      json
-// @Param  		 limit         query     int        false  "limit"          minimum(1)     default(10)
+// @Param  		 limit         query     int        false  "limit"          minimum(1)     maximum(500)   default(10)
 // @Param  		 page          query     int        false  "page"           minimum(1)     default(1)
+// @Param   	 cursor        query     string     false  "next_cursor of previous page, page is ignored with it"
+// @Param   	 sort          query     string     false  "one of created_at, name, price, barcode, - prefix sorts descending"  default(-created_at)
+// @Param   	 count         query     bool       false  "return total count, by default only without cursor"
+// @Param   	 date_from     query     string     false  "created_at from, date means its beginning"
+// @Param   	 date_to       query     string     false  "created_at to, date means its end"
+// @Param   	 field[op]     query     string     false  "filter on name, barcode, price, status, category_id, parent_id, created_at, updated_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like"
 // @Param   	 barcode        query     string     false  "barcode"
 // @Param   	 name        query     string     false  "name"
+// @Param   	 status      query     string     false  "status"  Enums(active, pending_review)
+// @Param   	 format        query     string     false  "download whole filtered and sorted list as file"  Enums(csv, xlsx)
 // @Success      200  {object}  models.ProductGetListResponse
 // @Failure      400  {object}  models.ErrorResp
 // @Failure      404  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
 func (h *Handler) GetListProduct(ctx *gin.Context) {
-	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	page, limit, opts, err := listParams(ctx)
 	if err != nil {
-		h.log.Error("error get page:", logger.Error(err))
-		ctx.JSON(http.StatusBadRequest, "invalid page param")
+		h.log.Error("error get list params:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
-	if err != nil {
-		h.log.Error("error get limit:", logger.Error(err))
-		ctx.JSON(http.StatusBadRequest, "invalid page param")
+
+	req := &models.ProductGetListRequest{
+		Page:        page,
+		Limit:       limit,
+		ListOptions: opts,
+		Name:        ctx.Query("name"),
+		Barcode:     ctx.Query("barcode"),
+		Status:      ctx.Query("status"),
+	}
+	if format := ctx.Query("format"); format != "" {
+		h.exportProduct(ctx, format, req)
 		return
 	}
 
-	resp, err := h.strg.Product().GetList(&models.ProductGetListRequest{
-		Page:    page,
-		Limit:   limit,
-		Name:    ctx.Query("name"),
-		Barcode: ctx.Query("barcode"),
-	})
+	resp, err := h.strg.Product().GetList(req)
 	if err != nil {
 		h.log.Error("error Product GetListProduct:", logger.Error(err))
+		if listError(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
@@ -83,10 +103,52 @@ func (h *Handler) GetListProduct(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, resp)
 }
 
+// SearchProduct godoc
+// @Router       /product/search [GET]
+// @Summary      SEARCH PRODUCT
+// @Description  type-ahead search of products by words of name, barcode prefix and category name, Cyrillic and Latin spellings and small typos match alike, best matches first
+// @Tags         PRODUCT
+// @Accept       json
+// @Produce      json
+// @Param   	 q             query     string     true   "part of name, barcode or category name"
+// @Param  		 limit         query     int        false  "limit"          minimum(1)     maximum(50)    default(10)
+// @Param   	 category_id   query     string     false  "category_id"
+// @Param   	 status        query     string     false  "status"  Enums(active, pending_review)
+// @Success      200  {object}  models.ProductSearchResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) SearchProduct(ctx *gin.Context) {
+	query := strings.TrimSpace(ctx.Query("q"))
+	if query == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "q is required"})
+		return
+	}
+
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > models.MaxProductSearchLimit {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("limit must be between 1 and %d", models.MaxProductSearchLimit)})
+		return
+	}
+
+	resp, err := h.strg.Product().Search(&models.ProductSearchRequest{
+		Query:      query,
+		Limit:      limit,
+		CategoryId: ctx.Query("category_id"),
+		Status:     ctx.Query("status"),
+	})
+	if err != nil {
+		h.log.Error("error Product Search:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
 // GetProduct godoc
 // @Router       /product/{id} [GET]
 // @Summary      GET BY ID
-// @Description  gets product by ID
+// @Description  gets product by ID with its variants and images
 // @Tags         PRODUCT
 // @Accept       json
 // @Produce      json
@@ -104,6 +166,7 @@ func (h *Handler) GetByIDProduct(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Not Found Product"})
 		return
 	}
+	h.imageURLs(resp.Images)
 
 	ctx.JSON(http.StatusOK, resp)
 }
@@ -111,15 +174,16 @@ func (h *Handler) GetByIDProduct(ctx *gin.Context) {
 // UpdateProduct godoc
 // @Router       /product/{id} [PUT]
 // @Summary      UPDATE PRODUCT
-// @Description  UPDATES PRODUCT BASED ON GIVEN DATA AND ID
+// @Description  UPDATES PRODUCT BASED ON GIVEN DATA AND ID, variant keeps category of its parent and variants get new category of parent
 // @Tags         PRODUCT
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
 // @Param        id    path     string  true  "id of product" format(uuid)
 // @Param        data  body      models.CreateProduct  true  "product data"
 // @Success      200  {string}  string
 // @Failure      400  {object}  models.ErrorResp
-// @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
 func (h *Handler) UpdateProduct(ctx *gin.Context) {
 	var product models.UpdateProduct
@@ -135,6 +199,9 @@ func (h *Handler) UpdateProduct(ctx *gin.Context) {
 	resp, err := h.strg.Product().Update(&product)
 	if err != nil {
 		h.log.Error("error product update:", logger.Error(err))
+		if statusConflict(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -145,24 +212,194 @@ func (h *Handler) UpdateProduct(ctx *gin.Context) {
 // DeleteProduct godoc
 // @Router       /product/{id} [DELETE]
 // @Summary      DELETE PRODUCT BY ID
-// @Description  deletes product by id
+// @Description  deletes product with its images, product with variants can be deleted only after them
 // @Tags         PRODUCT
 // @Accept       json
 // @Produce      json
 // @Param        id    path     string  true  "id of product" format(uuid)
 // @Success      200  {string}  string
 // @Failure      400  {object}  models.ErrorResp
-// @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
 func (h *Handler) DeleteProduct(ctx *gin.Context) {
 	id := ctx.Param("id")
 
-	err := h.strg.Product().Delete(&models.ProductPrimaryKey{Id: id})
+	images, err := h.strg.ProductImage().GetList(&models.ProductPrimaryKey{Id: id})
+	if err != nil {
+		h.log.Error("error get product images:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.strg.Product().Delete(&models.ProductPrimaryKey{Id: id})
 	if err != nil {
 		h.log.Error("error deleting product:", logger.Error(err))
+		if statusConflict(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	for _, image := range images.Images {
+		h.deleteFiles(image.Key)
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success"})
+}
+
+// CreateProductVariant godoc
+// @Router       /product/{id}/variant [POST]
+// @Summary      CREATE PRODUCT VARIANT
+// @Description  adds variant like size or color with its own barcode to product, it gets category of the product, name of product with attribute values when name is empty and price of product when price is 0
+// @Tags         PRODUCT
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        id    path     string  true  "id of parent product" format(uuid)
+// @Param        data  body      models.CreateProductVariant  true  "variant data"
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) CreateProductVariant(ctx *gin.Context) {
+	var variant models.CreateProductVariant
+	err := ctx.ShouldBind(&variant)
+	if err != nil {
+		h.log.Error("error while binding product variant:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, "invalid body")
+		return
+	}
+	if variant.Barcode == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "barcode is required"})
+		return
+	}
+	if variant.Price < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "price can not be negative"})
+		return
+	}
+
+	variant.ParentId = ctx.Param("id")
+	resp, err := h.strg.Product().CreateVariant(&variant)
+	if err != nil {
+		h.log.Error("error product variant create:", logger.Error(err))
+		if statusConflict(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"code": http.StatusCreated, "message": "success", "resp": resp})
+}
+
+// ApproveProduct godoc
+// @Router       /product/{id}/approve [POST]
+// @Summary      APPROVE PENDING PRODUCT
+// @Description  makes product created during receiving as pending review a regular one
+// @Tags         PRODUCT
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        id    path     string  true  "id of pending product" format(uuid)
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) ApproveProduct(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	err := h.strg.Product().Approve(&models.ProductPrimaryKey{Id: id})
+	if err != nil {
+		h.log.Error("error approving product:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success"})
 }
+
+// MergeProduct godoc
+// @Router       /product/{id}/merge [POST]
+// @Summary      MERGE PENDING PRODUCT
+// @Description  replaces pending product by existing target product in coming tables, remaining and stock movements and deletes it, rows dated in closed period are not changed and the merge is refused
+// @Tags         PRODUCT
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        id    path     string  true  "id of pending product" format(uuid)
+// @Param        data  body      models.MergeProduct  true  "target product"
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) MergeProduct(ctx *gin.Context) {
+	var merge models.MergeProduct
+
+	err := ctx.ShouldBind(&merge)
+	if err != nil {
+		h.log.Error("error while binding:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	merge.Id = ctx.Param("id")
+	err = h.strg.Product().Merge(&merge)
+	if err != nil {
+		h.log.Error("error merging product:", logger.Error(err))
+		if statusConflict(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success", "resp": merge.TargetId})
+}
+
+// ImportProduct godoc
+// @Router       /product/import [POST]
+// @Summary      IMPORT PRODUCTS
+// @Description  creates or updates products by barcode from csv or xlsx file with name, barcode, price and category columns, missing categories of the path are created
+// @Tags         PRODUCT
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        file     formData  file  true   "csv or xlsx file"
+// @Param        dry_run  query     bool  false  "validate and count without saving"
+// @Success      200  {object}  models.ProductImportResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) ImportProduct(ctx *gin.Context) {
+	dryRun, err := strconv.ParseBool(ctx.DefaultQuery("dry_run", "false"))
+	if err != nil {
+		h.log.Error("error get dry_run:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, "invalid dry_run param")
+		return
+	}
+
+	rows, err := h.readSheet(ctx)
+	if err != nil {
+		h.log.Error("error while reading import file:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	products, rowErrs, err := importer.ProductRows(rows)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.strg.Product().Import(&models.ProductImportRequest{Rows: products, DryRun: dryRun})
+	if err != nil {
+		h.log.Error("error product import:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	resp.Total += len(rowErrs)
+	resp.Errors = append(rowErrs, resp.Errors...)
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success", "resp": resp})
+}
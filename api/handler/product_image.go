@@ -0,0 +1,205 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"market/models"
+	"market/pkg/filestore"
+	"market/pkg/logger"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// imageExtensions are accepted image types detected from file content and extensions files are stored with
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// UploadProductImage godoc
+// @Router       /product/{id}/image [POST]
+// @Summary      UPLOAD PRODUCT IMAGE
+// @Description  adds jpeg, png, gif or webp image of up to 5 MB after the last image of product, type is detected from file content
+// @Tags         PRODUCT IMAGE
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        id    path      string  true  "id of product" format(uuid)
+// @Param        file  formData  file    true  "image file"
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) UploadProductImage(ctx *gin.Context) {
+	productId := ctx.Param("id")
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		h.log.Error("error while reading image file:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if header.Size > models.MaxProductImageSize {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("image must not be larger than %d bytes", models.MaxProductImageSize)})
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		h.log.Error("error while opening image file:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		h.log.Error("error while reading image file:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "file must be jpeg, png, gif or webp image, not " + contentType})
+		return
+	}
+
+	key := "products/" + productId + "/" + uuid.NewString() + ext
+	err = h.files.Put(ctx.Request.Context(), key, io.MultiReader(bytes.NewReader(head), file), header.Size, contentType)
+	if err != nil {
+		h.log.Error("error while storing image file:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.strg.ProductImage().Create(&models.CreateProductImage{
+		ProductId:   productId,
+		Key:         key,
+		ContentType: contentType,
+		Size:        header.Size,
+	})
+	if err != nil {
+		h.log.Error("error product image create:", logger.Error(err))
+		h.deleteFiles(key)
+		if statusConflict(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"code": http.StatusCreated, "message": "success", "resp": resp})
+}
+
+// GetListProductImage godoc
+// @Router       /product/{id}/image [GET]
+// @Summary      LIST PRODUCT IMAGES
+// @Description  gets images of product in their order
+// @Tags         PRODUCT IMAGE
+// @Accept       json
+// @Produce      json
+// @Param        id    path     string  true  "id of product" format(uuid)
+// @Success      200  {object}  models.ProductImageGetListResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetListProductImage(ctx *gin.Context) {
+	resp, err := h.strg.ProductImage().GetList(&models.ProductPrimaryKey{Id: ctx.Param("id")})
+	if err != nil {
+		h.log.Error("error product image GetList:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.imageURLs(resp.Images)
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DeleteProductImage godoc
+// @Router       /product_image/{id} [DELETE]
+// @Summary      DELETE PRODUCT IMAGE
+// @Description  deletes image of product with its file
+// @Tags         PRODUCT IMAGE
+// @Accept       json
+// @Produce      json
+// @Param        id    path     string  true  "id of product image" format(uuid)
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) DeleteProductImage(ctx *gin.Context) {
+	image, err := h.strg.ProductImage().GetByID(&models.ProductImagePrimaryKey{Id: ctx.Param("id")})
+	if err != nil {
+		h.log.Error("error get product image:", logger.Error(err))
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.strg.ProductImage().Delete(&models.ProductImagePrimaryKey{Id: image.Id})
+	if err != nil {
+		h.log.Error("error deleting product image:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.deleteFiles(image.Key)
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success"})
+}
+
+// GetFile godoc
+// @Router       /files/{key} [GET]
+// @Summary      DOWNLOAD FILE
+// @Description  downloads uploaded file like product image, urls of images point here unless FILESTORE_PUBLIC_URL is another address
+// @Tags         PRODUCT IMAGE
+// @Produce      octet-stream
+// @Param        key   path      string  true  "key of file"
+// @Success      200  {file}    file
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetFile(ctx *gin.Context) {
+	key := strings.TrimPrefix(ctx.Param("key"), "/")
+
+	body, contentType, err := h.files.Get(ctx.Request.Context(), key)
+	if errors.Is(err, filestore.ErrNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		h.log.Error("error while reading file:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	defer body.Close()
+
+	// keys are never reused, so a file does not change while it exists
+	ctx.Header("Cache-Control", "public, max-age=31536000, immutable")
+	ctx.DataFromReader(http.StatusOK, -1, contentType, body, nil)
+}
+
+// imageURLs fills download urls of images from their keys
+func (h *Handler) imageURLs(images []*models.ProductImage) {
+	for _, image := range images {
+		image.Url = h.files.URL(image.Key)
+	}
+}
+
+// deleteFiles removes files whose rows are already gone, failure only leaves an orphan file so it is logged
+func (h *Handler) deleteFiles(keys ...string) {
+	for _, key := range keys {
+		err := h.files.Delete(context.Background(), key)
+		if err != nil {
+			h.log.Error("error while deleting file "+key+":", logger.Error(err))
+		}
+	}
+}
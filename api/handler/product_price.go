@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"market/models"
+	"market/pkg/logger"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateProductPrice godoc
+// @Router       /product/{id}/price [POST]
+// @Summary      SCHEDULE PRODUCT PRICE
+// @Description  adds price change of product effective from given time or now, without branch_id it changes base price which is copied to product when it becomes effective, with branch_id it overrides price in that branch
+// @Tags         PRODUCT PRICE
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        id    path     string  true  "id of product" format(uuid)
+// @Param        data  body      models.CreateProductPrice  true  "price, branch and effective_from"
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) CreateProductPrice(ctx *gin.Context) {
+	var price models.CreateProductPrice
+	err := ctx.ShouldBind(&price)
+	if err != nil {
+		h.log.Error("error while binding product price:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, "invalid body")
+		return
+	}
+	if price.Price < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "price can not be negative"})
+		return
+	}
+
+	price.ProductId = ctx.Param("id")
+	resp, err := h.strg.ProductPrice().Create(&price)
+	if err != nil {
+		h.log.Error("error product price create:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"code": http.StatusCreated, "message": "success", "resp": resp})
+}
+
+// GetListProductPrice godoc
+// @Router       /product/{id}/price [GET]
+// @Summary      PRODUCT PRICE HISTORY
+// @Description  gets past and scheduled prices of product, newest first
+// @Tags         PRODUCT PRICE
+// @Accept       json
+// @Produce      json
+// @Param        id         path     string  true   "id of product" format(uuid)
+// @Param        branch_id  query    string  false  "only overrides of the branch" format(uuid)
+// @Success      200  {object}  models.ProductPriceGetListResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetListProductPrice(ctx *gin.Context) {
+	resp, err := h.strg.ProductPrice().GetList(&models.ProductPriceGetListRequest{
+		ProductId: ctx.Param("id"),
+		BranchId:  ctx.Query("branch_id"),
+	})
+	if err != nil {
+		h.log.Error("error product price GetList:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// DeleteProductPrice godoc
+// @Router       /product_price/{id} [DELETE]
+// @Summary      CANCEL SCHEDULED PRICE
+// @Description  deletes price change which is not effective yet
+// @Tags         PRODUCT PRICE
+// @Accept       json
+// @Produce      json
+// @Param        id    path     string  true  "id of product price" format(uuid)
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) DeleteProductPrice(ctx *gin.Context) {
+	err := h.strg.ProductPrice().Delete(&models.ProductPricePrimaryKey{Id: ctx.Param("id")})
+	if err != nil {
+		h.log.Error("error deleting product price:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success"})
+}
+
+// GetEffectivePrice godoc
+// @Router       /product_price/effective [GET]
+// @Summary      EFFECTIVE PRICE
+// @Description  gets price of barcode in branch at given time, branch override wins over base price
+// @Tags         PRODUCT PRICE
+// @Accept       json
+// @Produce      json
+// @Param        barcode    query    string  true   "barcode"
+// @Param        branch_id  query    string  false  "branch_id" format(uuid)
+// @Param        at         query    string  false  "moment as RFC3339, 2006-01-02 15:04:05 or date meaning its end, now by default"
+// @Success      200  {object}  models.EffectivePrice
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetEffectivePrice(ctx *gin.Context) {
+	barcode := ctx.Query("barcode")
+	if barcode == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "barcode is required"})
+		return
+	}
+
+	at, err := parseAsOf(ctx.Query("at"))
+	if err != nil {
+		h.log.Error("error get at:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, "invalid at param")
+		return
+	}
+
+	resp, err := h.strg.ProductPrice().Effective(&models.EffectivePriceRequest{
+		Barcode:  barcode,
+		BranchId: ctx.Query("branch_id"),
+		At:       at,
+	})
+	if err != nil {
+		h.log.Error("error get effective price:", logger.Error(err))
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,272 @@
+package handler
+
+import (
+	"market/models"
+	"market/pkg/logger"
+	"market/pkg/promotion"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreatePromotion godoc
+// @Router       /promotion [POST]
+// @Summary      CREATE PROMOTION
+// @Description  adds discount rule: percent off, buy_count get free_count free or bundle_count units for bundle_price, scoped by products, categories with subcategories, branch and date window
+// @Tags         PROMOTION
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        data  body      models.CreatePromotion  true  "promotion data"
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) CreatePromotion(ctx *gin.Context) {
+	var req = models.CreatePromotion{Active: true}
+	err := ctx.ShouldBind(&req)
+	if err != nil {
+		h.log.Error("error while binding promotion:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	err = promotion.Validate(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.strg.Promotion().Create(&req)
+	if err != nil {
+		h.log.Error("error promotion create:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"code": http.StatusCreated, "message": "success", "resp": resp})
+}
+
+// GetListPromotion godoc
+// @Router       /promotion [GET]
+// @Summary      LIST PROMOTIONS
+// @Description  gets promotions by priority, search by name
+// @Tags         PROMOTION
+// @Accept       json
+// @Produce      json
+// @Param  		 limit      query     int     false  "limit"          minimum(1)     maximum(500)   default(10)
+// @Param  		 page       query     int     false  "page"           minimum(1)     default(1)
+// @Param   	 cursor        query     string     false  "next_cursor of previous page, page is ignored with it"
+// @Param   	 sort          query     string     false  "one of priority, created_at, name, starts_at, - prefix sorts descending"  default(-priority)
+// @Param   	 count         query     bool       false  "return total count, by default only without cursor"
+// @Param   	 date_from     query     string     false  "created_at from, date means its beginning"
+// @Param   	 date_to       query     string     false  "created_at to, date means its end"
+// @Param   	 field[op]     query     string     false  "filter on name, type, percent, branch_id, priority, active, starts_at, ends_at, created_at, updated_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like"
+// @Param   	 search     query     string  false  "search"
+// @Param   	 branch_id  query     string  false  "promotions of the branch and of all branches"
+// @Success      200  {object}  models.PromotionGetListResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetListPromotion(ctx *gin.Context) {
+	page, limit, opts, err := listParams(ctx)
+	if err != nil {
+		h.log.Error("error get list params:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.strg.Promotion().GetList(&models.PromotionGetListRequest{
+		Page:        page,
+		Limit:       limit,
+		ListOptions: opts,
+		Search:      ctx.Query("search"),
+		BranchId:    ctx.Query("branch_id"),
+	})
+	if err != nil {
+		h.log.Error("error Promotion GetList:", logger.Error(err))
+		if listError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetPromotion godoc
+// @Router       /promotion/{id} [GET]
+// @Summary      GET BY ID
+// @Description  gets promotion by ID
+// @Tags         PROMOTION
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Promotion ID" format(uuid)
+// @Success      200  {object}  models.Promotion
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetByIDPromotion(ctx *gin.Context) {
+	resp, err := h.strg.Promotion().GetByID(&models.PromotionPrimaryKey{Id: ctx.Param("id")})
+	if err != nil {
+		h.log.Error("error get promotion:", logger.Error(err))
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdatePromotion godoc
+// @Router       /promotion/{id} [PUT]
+// @Summary      UPDATE PROMOTION
+// @Description  updates promotion, set active to false to stop it
+// @Tags         PROMOTION
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        id    path     string  true  "id of promotion" format(uuid)
+// @Param        data  body      models.CreatePromotion  true  "promotion data"
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) UpdatePromotion(ctx *gin.Context) {
+	var req = models.UpdatePromotion{CreatePromotion: models.CreatePromotion{Active: true}}
+	err := ctx.ShouldBind(&req)
+	if err != nil {
+		h.log.Error("error while binding promotion:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	err = promotion.Validate(&req.CreatePromotion)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	req.Id = ctx.Param("id")
+	resp, err := h.strg.Promotion().Update(&req)
+	if err != nil {
+		h.log.Error("error promotion update:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success", "resp": resp})
+}
+
+// DeletePromotion godoc
+// @Router       /promotion/{id} [DELETE]
+// @Summary      DELETE PROMOTION BY ID
+// @Description  deletes promotion which has not granted discounts yet
+// @Tags         PROMOTION
+// @Accept       json
+// @Produce      json
+// @Param        id    path     string  true  "id of promotion" format(uuid)
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) DeletePromotion(ctx *gin.Context) {
+	err := h.strg.Promotion().Delete(&models.PromotionPrimaryKey{Id: ctx.Param("id")})
+	if err != nil {
+		h.log.Error("error deleting promotion:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success"})
+}
+
+// EvaluatePromotion godoc
+// @Router       /promotion/evaluate [POST]
+// @Summary      EVALUATE BASKET
+// @Description  prices basket of barcodes in the branch and applies running promotions by priority, every unit gets one promotion at most, with commit the discounts are saved for promotion report
+// @Tags         PROMOTION
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        data  body      models.BasketRequest  true  "branch and lines of basket"
+// @Success      200  {object}  models.BasketResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) EvaluatePromotion(ctx *gin.Context) {
+	var req models.BasketRequest
+	err := ctx.ShouldBind(&req)
+	if err != nil {
+		h.log.Error("error while binding basket:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, "invalid body")
+		return
+	}
+	if len(req.Lines) == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "lines are required"})
+		return
+	}
+	for _, line := range req.Lines {
+		if line.Barcode == "" || line.Count <= 0 {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "every line needs barcode and positive count"})
+			return
+		}
+	}
+
+	lines, err := h.strg.Promotion().Basket(&req)
+	if err != nil {
+		h.log.Error("error while reading basket:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	promotions, err := h.strg.Promotion().Active(&models.ActivePromotionRequest{BranchId: req.BranchId, At: req.At})
+	if err != nil {
+		h.log.Error("error while getting active promotions:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp := promotion.Apply(lines, promotions)
+
+	if req.Commit {
+		err = h.strg.Promotion().SaveUsage(&req, resp)
+		if err != nil {
+			h.log.Error("error while saving promotion usage:", logger.Error(err))
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// PromotionReport godoc
+// @Router       /reports/promotions [GET]
+// @Summary      PROMOTION REPORT
+// @Description  sums discounts granted by every promotion in committed baskets of the date range
+// @Tags         REPORT
+// @Accept       json
+// @Produce      json
+// @Param        from       query     string  false  "first day, current month by default"  format(date)
+// @Param        to         query     string  false  "last day, today by default"  format(date)
+// @Param        branch_id  query     string  false  "branch_id"
+// @Success      200  {object}  models.PromotionReportResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) PromotionReport(ctx *gin.Context) {
+	req, err := reportRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.strg.Report().Promotions(req)
+	if err != nil {
+		h.log.Error("error Report Promotions:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
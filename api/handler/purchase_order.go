@@ -0,0 +1,232 @@
+package handler
+
+import (
+	"market/models"
+	"market/pkg/logger"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreatePurchaseOrder godoc
+// @Router       /purchase_order [POST]
+// @Summary      CREATE PURCHASE ORDER
+// @Description adds purchase_order with its products to db based on given info in body
+// @Tags         PURCHASE ORDER
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        data  body      models.CreatePurchaseOrder  true  "purchase_order data"
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) CreatePurchaseOrder(ctx *gin.Context) {
+	var purchase_order models.CreatePurchaseOrder
+	err := ctx.ShouldBind(&purchase_order)
+	if err != nil {
+		h.log.Error("error while binding purchase_order:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	resp, err := h.strg.PurchaseOrder().Create(&purchase_order)
+	if err != nil {
+		h.log.Error("error purchase_order create:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"code": http.StatusCreated, "message": "success", "resp": resp})
+}
+
+// ListPurchaseOrders godoc
+// @Router       /purchase_order [GET]
+// @Summary      LIST PURCHASE ORDERS
+// @Description  gets all purchase_order based on limit, page and filters
+// @Tags         PURCHASE ORDER
+// @Accept       json
+// @Produce      json
+// @Param  		 limit         query     int        false  "limit"          minimum(1)     maximum(500)   default(10)
+// @Param  		 page          query     int        false  "page"           minimum(1)     default(1)
+// @Param   	 cursor        query     string     false  "next_cursor of previous page, page is ignored with it"
+// @Param   	 sort          query     string     false  "one of created_at, order_number, expected_date, - prefix sorts descending"  default(-created_at)
+// @Param   	 count         query     bool       false  "return total count, by default only without cursor"
+// @Param   	 date_from     query     string     false  "created_at from, date means its beginning"
+// @Param   	 date_to       query     string     false  "created_at to, date means its end"
+// @Param   	 field[op]     query     string     false  "filter on order_number, supplier_id, branch_id, status, expected_date, created_at, updated_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like"
+// @Param   	 supplier_id   query     string     false  "supplier_id"
+// @Param   	 branch_id     query     string     false  "branch_id"
+// @Param   	 status        query     string     false  "status"  Enums(new, partially_received, received)
+// @Success      200  {object}  models.PurchaseOrderGetListResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetListPurchaseOrder(ctx *gin.Context) {
+	page, limit, opts, err := listParams(ctx)
+	if err != nil {
+		h.log.Error("error get list params:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.strg.PurchaseOrder().GetList(&models.PurchaseOrderGetListRequest{
+		Page:        page,
+		Limit:       limit,
+		ListOptions: opts,
+		SupplierId:  ctx.Query("supplier_id"),
+		BranchId:    ctx.Query("branch_id"),
+		Status:      ctx.Query("status"),
+	})
+	if err != nil {
+		h.log.Error("error PurchaseOrder GetListPurchaseOrder:", logger.Error(err))
+		if listError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetPurchaseOrder godoc
+// @Router       /purchase_order/{id} [GET]
+// @Summary      GET BY ID
+// @Description  gets purchase_order with ordered and received quantities by ID
+// @Tags         PURCHASE ORDER
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "PurchaseOrder ID" format(uuid)
+// @Success      200  {object}  models.PurchaseOrder
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetByIDPurchaseOrder(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	resp, err := h.strg.PurchaseOrder().GetByID(&models.PurchaseOrderPrimaryKey{Id: id})
+	if err != nil {
+		h.log.Error("error get purchase_order:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdatePurchaseOrder godoc
+// @Router       /purchase_order/{id} [PUT]
+// @Summary      UPDATE PURCHASE ORDER
+// @Description  UPDATES PURCHASE ORDER BASED ON GIVEN DATA AND ID
+// @Tags         PURCHASE ORDER
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        id    path     string  true  "id of purchase_order" format(uuid)
+// @Param        data  body      models.UpdatePurchaseOrder  true  "purchase_order data"
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) UpdatePurchaseOrder(ctx *gin.Context) {
+	var purchase_order models.UpdatePurchaseOrder
+
+	err := ctx.ShouldBind(&purchase_order)
+	if err != nil {
+		h.log.Error("error while binding:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	purchase_order.Id = ctx.Param("id")
+	resp, err := h.strg.PurchaseOrder().Update(&purchase_order)
+	if err != nil {
+		h.log.Error("error purchase_order update:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success", "resp": resp})
+}
+
+// DeletePurchaseOrder godoc
+// @Router       /purchase_order/{id} [DELETE]
+// @Summary      DELETE PURCHASE ORDER BY ID
+// @Description  deletes purchase_order by id
+// @Tags         PURCHASE ORDER
+// @Accept       json
+// @Produce      json
+// @Param        id    path     string  true  "id of purchase_order" format(uuid)
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) DeletePurchaseOrder(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	err := h.strg.PurchaseOrder().Delete(&models.PurchaseOrderPrimaryKey{Id: id})
+	if err != nil {
+		h.log.Error("error deleting purchase_order:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success"})
+}
+
+// CreateComingTableFromOrder godoc
+// @Router       /purchase_order/{id}/coming_table [POST]
+// @Summary      CREATE COMING TABLE FROM PURCHASE ORDER
+// @Description  opens coming_table for branch and supplier of the purchase_order to receive goods against it
+// @Tags         PURCHASE ORDER
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        id    path     string  true  "id of purchase_order" format(uuid)
+// @Param        data  body      models.CreateComingTableFromOrder  true  "coming_table data"
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) CreateComingTableFromOrder(ctx *gin.Context) {
+	var coming_table models.CreateComingTableFromOrder
+	err := ctx.ShouldBind(&coming_table)
+	if err != nil {
+		h.log.Error("error while binding coming_table:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	coming_table.PurchaseOrderId = ctx.Param("id")
+	resp, err := h.strg.PurchaseOrder().CreateComingTable(&coming_table)
+	if err != nil {
+		h.log.Error("error coming_table create from purchase_order:", logger.Error(err))
+		if statusConflict(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"code": http.StatusCreated, "message": "success", "resp": resp})
+}
+
+// orderProgress returns ordered vs received quantity of barcode when coming_table receives against a purchase order
+func (h *Handler) orderProgress(comingTableId, barcode string) *models.PurchaseOrderProgress {
+	comingTable, err := h.strg.ComingTable().GetByID(&models.ComingTablePrimaryKey{Id: comingTableId})
+	if err != nil || comingTable.PurchaseOrderId == "" {
+		return nil
+	}
+
+	progress, err := h.strg.PurchaseOrder().GetProgress(&models.PurchaseOrderProgressRequest{
+		PurchaseOrderId: comingTable.PurchaseOrderId,
+		ComingTableId:   comingTableId,
+		Barcode:         barcode,
+	})
+	if err != nil {
+		h.log.Error("error while getting purchase_order progress:", logger.Error(err))
+		return nil
+	}
+
+	return progress
+}
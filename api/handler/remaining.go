@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"bytes"
+	"fmt"
 	"market/models"
 	"market/pkg/logger"
+	"market/pkg/pdf"
 	"net/http"
-	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -12,127 +15,94 @@ import (
 // CreateRemaining godoc
 // @Router       /do_income/{coming_table_id} [POST]
 // @Summary      CREATE REMAINING
-// @Description adds remaining data to db based on given coming_table_id
+// @Description posts coming_table: writes income stock movements and adds its products to remaining
 // @Tags         REMAINING
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
 // @Param        coming_table_id path string true "Coming Table ID"
 // @Success      200  {string}  string
 // @Failure      400  {object}  models.ErrorResp
 // @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
 func (h *Handler) CreateRemaining(ctx *gin.Context) {
 
 	comingTableID := ctx.Param("coming_table_id")
 
-	var remaining models.CreateRemaining
-
-	// checking coming_table info weather it is in_process or finished // return branch_id
-	comingTableId := models.ComingTablePrimaryKey{Id: comingTableID}
-	branch_id, err := h.strg.ComingTable().GetStatus(&comingTableId)
-	if err != nil {
-		h.log.Error("error while getting coming table status", logger.Error(err))
-		ctx.JSON(http.StatusInternalServerError, err.Error())
-		return
-	}
-	remaining.BranchId = branch_id
-
-	// get coming_table_product details using coming_id
-	coming_id := models.ComingTableProductPrimaryKey{Id: comingTableID}
-	coming_table_data, err := h.strg.ComingTableProduct().GetByComingTableId(&coming_id)
+	// posting moves coming_table to posted and updates remaining in one transaction
+	err := h.strg.ComingTable().Post(&models.ComingTablePrimaryKey{Id: comingTableID})
 	if err != nil {
-		h.log.Error("error while getting coming_table_data details:", logger.Error(err))
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Not Found Coming_table_data with that coming_id"})
-		return
-	}
-	remaining.Barcode = coming_table_data.ProductBarcode
-	remaining.CategoryId = coming_table_data.CategoryId
-	remaining.Price = coming_table_data.ProductPrice
-	remaining.Count = coming_table_data.Count
-	remaining.TotalPrice = coming_table_data.TotalPrice
-	remaining.Name = coming_table_data.ProductName
-
-	// cheking remain table by branch and barcode, if yes, update otherwise create
-	req := models.CheckingRemaining{BranchId: branch_id, Barcode: remaining.Barcode}
-
-	id, err := h.strg.Remaining().CheckRemaing(&req)
-	if err != nil {
-		h.log.Info("not found then we are creating new remaining", logger.Error(err))
-		// if product or remaining is not exists, add remaining table
-		resp, err := h.strg.Remaining().Create(&remaining)
-		if err != nil {
-			h.log.Error("error remaining create:", logger.Error(err))
-			ctx.JSON(http.StatusBadRequest, err)
+		h.log.Error("error while posting coming table:", logger.Error(err))
+		if statusConflict(ctx, err) {
 			return
 		}
-		ctx.JSON(http.StatusCreated, gin.H{"code": http.StatusCreated, "message": "added new remaining", "resp": resp})
-		// change status
-		h.strg.ComingTable().UpdateStatus(&comingTableId)
-		return
-	}
-
-	// if exits Update Coming Product Table
-	var updatingData = models.UpdateRemaining{
-		Id:         id,
-		BranchId:   remaining.BranchId,
-		CategoryId: remaining.CategoryId,
-		Name:       remaining.Name,
-		Price:      remaining.Price,
-		Barcode:    remaining.Barcode,
-		Count:      remaining.Count,
-		TotalPrice: remaining.TotalPrice,
-	}
-	r, err := h.strg.Remaining().UpdateExists(&updatingData)
-	if err != nil {
-		h.log.Info("error remaing update:", logger.Error(err))
-		ctx.JSON(http.StatusInternalServerError, err.Error())
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	ctx.JSON(http.StatusOK, gin.H{"message": "updated existing remaining table", "resp": r})
 
-	// if everything ok, change status to finish
-	h.strg.ComingTable().UpdateStatus(&comingTableId)
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "income posted", "resp": comingTableID})
 }
 
 // ListRemainings godoc
 // @Router       /remaining [GET]
 // @Summary      LIST REMAINING
-// @Description  gets all remaining based on limit, page and search by name
+// @Description  gets all remaining based on limit, page and filters, with as_of quantities and values are rebuilt from stock snapshots and movements
 // @Tags         REMAINING
 // @Accept       json
 // @Produce      json
-// @Param  		 limit         query     int        false  "limit"          minimum(1)     default(10)
+// @Param  		 limit         query     int        false  "limit"          minimum(1)     maximum(500)   default(10)
 // @Param  		 page          query     int        false  "page"           minimum(1)     default(1)
+// @Param   	 cursor        query     string     false  "next_cursor of previous page, page is ignored with it"
+// @Param   	 sort          query     string     false  "one of created_at, name, price, count, total_price, barcode, - prefix sorts descending"  default(-created_at)
+// @Param   	 count         query     bool       false  "return total count, by default only without cursor"
+// @Param   	 date_from     query     string     false  "created_at from, date means its beginning"
+// @Param   	 date_to       query     string     false  "created_at to, date means its end"
+// @Param   	 field[op]     query     string     false  "filter on name, barcode, price, count, total_price, category_id, product_id, branch_id, created_at, updated_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like"
 // @Param   	 branch_id          query     string     false  "branch_id"
 // @Param   	 category_id        query     string     false  "category_id"
 // @Param   	 barcode            query     string     false  "barcode"
+// @Param   	 as_of         query     string     false  "reconstruct remaining at the moment, date means the end of that day"
+// @Param   	 format        query     string     false  "download whole filtered and sorted list as file"  Enums(csv, xlsx)
 // @Success      200  {object}  models.RemainingGetListResponse
 // @Failure      400  {object}  models.ErrorResp
 // @Failure      404  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
 func (h *Handler) GetListRemaining(ctx *gin.Context) {
-	page, err := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	page, limit, opts, err := listParams(ctx)
 	if err != nil {
-		h.log.Error("error get page:", logger.Error(err))
-		ctx.JSON(http.StatusBadRequest, "invalid page param")
+		h.log.Error("error get list params:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+
+	asOf, err := parseAsOf(ctx.Query("as_of"))
 	if err != nil {
-		h.log.Error("error get limit:", logger.Error(err))
-		ctx.JSON(http.StatusBadRequest, "invalid page param")
+		h.log.Error("error get as_of:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, "invalid as_of param")
 		return
 	}
 
-	resp, err := h.strg.Remaining().GetList(&models.RemainingGetListRequest{
-		Page:       page,
-		Limit:      limit,
-		CategoryId: ctx.Query("search"),
-		Barcode:    ctx.Query("barcode"),
-		BranchId:   ctx.Query("branch_id"),
-	})
+	req := &models.RemainingGetListRequest{
+		Page:        page,
+		Limit:       limit,
+		ListOptions: opts,
+		CategoryId:  ctx.Query("category_id"),
+		Barcode:     ctx.Query("barcode"),
+		BranchId:    ctx.Query("branch_id"),
+		AsOf:        asOf,
+	}
+	if format := ctx.Query("format"); format != "" {
+		h.exportRemaining(ctx, format, req)
+		return
+	}
+
+	resp, err := h.strg.Remaining().GetList(req)
 	if err != nil {
 		h.log.Error("error Remaining GetListRemaining:", logger.Error(err))
+		if listError(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, err)
 		return
 	}
@@ -143,12 +113,13 @@ func (h *Handler) GetListRemaining(ctx *gin.Context) {
 // GetRemaining godoc
 // @Router       /remaining/{id} [GET]
 // @Summary      GET BY ID
-// @Description  gets remaining by ID
+// @Description  gets remaining by ID, ETag header is its version for If-Match of update
 // @Tags         REMAINING
 // @Accept       json
 // @Produce      json
 // @Param        id   path      string  true  "Remaining ID" format(uuid)
 // @Success      200  {object}  models.Remaining
+// @Header       200  {string}  ETag  "version of remaining"
 // @Failure      400  {object}  models.ErrorResp
 // @Failure      404  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
@@ -162,21 +133,27 @@ func (h *Handler) GetByIDRemaining(ctx *gin.Context) {
 		return
 	}
 
+	setETag(ctx, resp.Version)
 	ctx.JSON(http.StatusOK, resp)
 }
 
 // UpdateRemaining godoc
 // @Router       /remaining/{id} [PUT]
 // @Summary      UPDATE REMAINING
-// @Description  UPDATES REMAINING BASED ON GIVEN DATA AND ID
+// @Description  UPDATES REMAINING BASED ON GIVEN DATA AND ID, WITH If-Match ONLY THAT VERSION IS UPDATED
 // @Tags         REMAINING
 // @Accept       json
 // @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
 // @Param        id    path     string  true  "id of remaining" format(uuid)
+// @Param        If-Match  header  string  false  "ETag of remaining read before"
 // @Param        data  body      models.UpdateRemainingSoft  true  "remaining data"
 // @Success      200  {string}  string
+// @Header       200  {string}  ETag  "new version of remaining"
 // @Failure      400  {object}  models.ErrorResp
 // @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
+// @Failure      412  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
 func (h *Handler) UpdateRemaining(ctx *gin.Context) {
 	var remaining models.UpdateRemaining
@@ -188,15 +165,25 @@ func (h *Handler) UpdateRemaining(ctx *gin.Context) {
 		return
 	}
 
+	remaining.Version, err = ifMatch(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
 	remaining.Id = ctx.Param("id")
 	remaining.TotalPrice = float64(remaining.Count) * remaining.Price
 	resp, err := h.strg.Remaining().Update(&remaining)
 	if err != nil {
 		h.log.Error("error remaining update:", logger.Error(err))
+		if preconditionFailed(ctx, err) || statusConflict(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
+	setETag(ctx, remaining.Version)
 	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success", "resp": resp})
 }
 
@@ -211,6 +198,7 @@ func (h *Handler) UpdateRemaining(ctx *gin.Context) {
 // @Success      200  {string}  string
 // @Failure      400  {object}  models.ErrorResp
 // @Failure      404  {object}  models.ErrorResp
+// @Failure      409  {object}  models.ErrorResp
 // @Failure      500  {object}  models.ErrorResp
 func (h *Handler) DeleteRemaining(ctx *gin.Context) {
 	id := ctx.Param("id")
@@ -218,9 +206,67 @@ func (h *Handler) DeleteRemaining(ctx *gin.Context) {
 	err := h.strg.Remaining().Delete(&models.RemainingPrimaryKey{Id: id})
 	if err != nil {
 		h.log.Error("error deleting remaining:", logger.Error(err))
+		if statusConflict(ctx, err) {
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success"})
 }
+
+// GetPdfBranchRemaining godoc
+// @Router       /branch/{id}/remaining/pdf [GET]
+// @Summary      STOCK REPORT PDF
+// @Description  renders printable stock report of all remaining of the branch with totals and signature fields
+// @Tags         REMAINING
+// @Accept       json
+// @Produce      application/pdf
+// @Param        id   path      string  true  "Branch ID" format(uuid)
+// @Success      200  {file}    file
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetPdfBranchRemaining(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	branch, err := h.strg.Branch().GetByID(&models.BranchPrimaryKey{Id: id})
+	if err != nil {
+		h.log.Error("error get branch:", logger.Error(err))
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	var buf bytes.Buffer
+	err = pdf.StockReport(&buf, branch, func(add func(*models.Remaining, *models.ExportNames) error) error {
+		return h.strg.Remaining().Export(&models.RemainingGetListRequest{BranchId: id}, add)
+	})
+	if err != nil {
+		h.log.Error("error while rendering stock report:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`inline; filename="stock_%s.pdf"`, time.Now().Format("2006-01-02")))
+	ctx.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+// parseAsOf accepts RFC3339 timestamp, "2006-01-02 15:04:05" or a date which means the end of that day
+func parseAsOf(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateTime} {
+		if asOf, err := time.Parse(layout, value); err == nil {
+			return asOf, nil
+		}
+	}
+
+	date, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return date.AddDate(0, 0, 1).Add(-time.Microsecond), nil
+}
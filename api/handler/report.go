@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"errors"
+	"market/models"
+	"market/pkg/logger"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const dateLayout = "2006-01-02"
+
+// reportRequest reads date range and filters of reports, by default the range is the current month till today
+func reportRequest(ctx *gin.Context) (*models.ReportRequest, error) {
+	var (
+		now = time.Now()
+		req = &models.ReportRequest{
+			From:     time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC),
+			To:       time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+			BranchId: ctx.Query("branch_id"),
+			Barcode:  ctx.Query("barcode"),
+			GroupBy:  ctx.DefaultQuery("group_by", models.ReportByBranch),
+			Period:   ctx.DefaultQuery("period", models.PeriodMonth),
+		}
+		err error
+	)
+
+	if from := ctx.Query("from"); from != "" {
+		req.From, err = time.Parse(dateLayout, from)
+		if err != nil {
+			return nil, errors.New("from must be a date like 2006-01-02")
+		}
+	}
+	if to := ctx.Query("to"); to != "" {
+		req.To, err = time.Parse(dateLayout, to)
+		if err != nil {
+			return nil, errors.New("to must be a date like 2006-01-02")
+		}
+	}
+	if req.To.Before(req.From) {
+		return nil, errors.New("to is before from")
+	}
+
+	switch req.GroupBy {
+	case models.ReportByBranch, models.ReportBySupplier, models.ReportByCategory:
+	default:
+		return nil, errors.New("group_by must be branch, supplier or category")
+	}
+
+	switch req.Period {
+	case models.PeriodDay, models.PeriodWeek, models.PeriodMonth:
+	default:
+		return nil, errors.New("period must be day, week or month")
+	}
+
+	req.Limit, err = strconv.Atoi(ctx.DefaultQuery("limit", "10"))
+	if err != nil || req.Limit < 1 || req.Limit > 100 {
+		return nil, errors.New("limit must be between 1 and 100")
+	}
+
+	return req, nil
+}
+
+// IncomeReport godoc
+// @Router       /reports/income [GET]
+// @Summary      INCOME REPORT
+// @Description  sums count and total price of posted coming tables per day, week or month by branch, supplier or category
+// @Tags         REPORT
+// @Accept       json
+// @Produce      json
+// @Param        from       query     string  false  "first day, current month by default"  format(date)
+// @Param        to         query     string  false  "last day, today by default"  format(date)
+// @Param        branch_id  query     string  false  "branch_id"
+// @Param        group_by   query     string  false  "group_by"  Enums(branch, supplier, category)  default(branch)
+// @Param        period     query     string  false  "period"    Enums(day, week, month)  default(month)
+// @Success      200  {object}  models.IncomeReportResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) IncomeReport(ctx *gin.Context) {
+	req, err := reportRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.strg.Report().Income(req)
+	if err != nil {
+		h.log.Error("error Report Income:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// StockReport godoc
+// @Router       /reports/stock [GET]
+// @Summary      STOCK VALUE REPORT
+// @Description  sums current remaining count and value of every branch
+// @Tags         REPORT
+// @Accept       json
+// @Produce      json
+// @Param        branch_id  query     string  false  "branch_id"
+// @Success      200  {object}  models.StockReportResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) StockReport(ctx *gin.Context) {
+	req, err := reportRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.strg.Report().Stock(req)
+	if err != nil {
+		h.log.Error("error Report Stock:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// TopProductsReport godoc
+// @Router       /reports/top_products [GET]
+// @Summary      TOP RECEIVED PRODUCTS
+// @Description  gets products received the most by count in posted coming tables of the date range
+// @Tags         REPORT
+// @Accept       json
+// @Produce      json
+// @Param        from       query     string  false  "first day, current month by default"  format(date)
+// @Param        to         query     string  false  "last day, today by default"  format(date)
+// @Param        branch_id  query     string  false  "branch_id"
+// @Param        limit      query     int     false  "limit"  minimum(1)  maximum(100)  default(10)
+// @Success      200  {object}  models.TopProductsResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) TopProductsReport(ctx *gin.Context) {
+	req, err := reportRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.strg.Report().TopProducts(req)
+	if err != nil {
+		h.log.Error("error Report TopProducts:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// MovementReport godoc
+// @Router       /reports/movements [GET]
+// @Summary      STOCK MOVEMENT SUMMARY
+// @Description  gets opening balance, income, outcome and closing balance of every barcode for the date range from stock movements
+// @Tags         REPORT
+// @Accept       json
+// @Produce      json
+// @Param        from       query     string  false  "first day, current month by default"  format(date)
+// @Param        to         query     string  false  "last day, today by default"  format(date)
+// @Param        branch_id  query     string  false  "branch_id"
+// @Param        barcode    query     string  false  "barcode"
+// @Success      200  {object}  models.MovementReportResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) MovementReport(ctx *gin.Context) {
+	req, err := reportRequest(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.strg.Report().Movements(req)
+	if err != nil {
+		h.log.Error("error Report Movements:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
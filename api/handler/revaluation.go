@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"bytes"
+	"fmt"
+	"market/models"
+	"market/pkg/logger"
+	"market/pkg/pdf"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetListRevaluation godoc
+// @Router       /revaluation [GET]
+// @Summary      LIST REVALUATIONS
+// @Description  gets revaluations written when product price changes, newest first
+// @Tags         REVALUATION
+// @Accept       json
+// @Produce      json
+// @Param  		 limit       query     int     false  "limit"          minimum(1)     maximum(500)   default(10)
+// @Param  		 page        query     int     false  "page"           minimum(1)     default(1)
+// @Param   	 cursor        query     string     false  "next_cursor of previous page, page is ignored with it"
+// @Param   	 sort          query     string     false  "one of created_at, barcode, difference, - prefix sorts descending"  default(-created_at)
+// @Param   	 count         query     bool       false  "return total count, by default only without cursor"
+// @Param   	 date_from     query     string     false  "created_at from, date means its beginning"
+// @Param   	 date_to       query     string     false  "created_at to, date means its end"
+// @Param   	 field[op]     query     string     false  "filter on product_id, barcode, name, old_price, new_price, difference, source, created_by, created_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like"
+// @Param   	 barcode     query     string  false  "barcode"
+// @Param   	 product_id  query     string  false  "product_id"
+// @Success      200  {object}  models.RevaluationGetListResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetListRevaluation(ctx *gin.Context) {
+	page, limit, opts, err := listParams(ctx)
+	if err != nil {
+		h.log.Error("error get list params:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.strg.Revaluation().GetList(&models.RevaluationGetListRequest{
+		Page:        page,
+		Limit:       limit,
+		ListOptions: opts,
+		Barcode:     ctx.Query("barcode"),
+		ProductId:   ctx.Query("product_id"),
+	})
+	if err != nil {
+		h.log.Error("error Revaluation GetList:", logger.Error(err))
+		if listError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetRevaluation godoc
+// @Router       /revaluation/{id} [GET]
+// @Summary      GET BY ID
+// @Description  gets revaluation with remaining of every branch before and after it
+// @Tags         REVALUATION
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Revaluation ID" format(uuid)
+// @Success      200  {object}  models.RevaluationFull
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetByIDRevaluation(ctx *gin.Context) {
+	resp, err := h.strg.Revaluation().GetFull(&models.RevaluationPrimaryKey{Id: ctx.Param("id")})
+	if err != nil {
+		h.log.Error("error get revaluation:", logger.Error(err))
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetPdfRevaluation godoc
+// @Router       /revaluation/{id}/pdf [GET]
+// @Summary      REPRICING SHEET PDF
+// @Description  renders printable repricing sheet of revaluation with remaining of every branch and signature fields
+// @Tags         REVALUATION
+// @Accept       json
+// @Produce      application/pdf
+// @Param        id   path      string  true  "Revaluation ID" format(uuid)
+// @Success      200  {file}    file
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetPdfRevaluation(ctx *gin.Context) {
+	full, err := h.strg.Revaluation().GetFull(&models.RevaluationPrimaryKey{Id: ctx.Param("id")})
+	if err != nil {
+		h.log.Error("error get full revaluation:", logger.Error(err))
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+
+	var buf bytes.Buffer
+	err = pdf.RepricingSheet(&buf, full)
+	if err != nil {
+		h.log.Error("error while rendering repricing sheet:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`inline; filename="repricing_%s.pdf"`, full.Revaluation.Barcode))
+	ctx.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
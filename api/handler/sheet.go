@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"market/pkg/sheet"
+
+	"github.com/gin-gonic/gin"
+)
+
+// readSheet reads rows of csv or xlsx file uploaded in "file" form field
+func (h *Handler) readSheet(ctx *gin.Context) ([][]string, error) {
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return sheet.Read(file, header.Filename)
+}
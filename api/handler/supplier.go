@@ -0,0 +1,240 @@
+package handler
+
+import (
+	"market/models"
+	"market/pkg/importer"
+	"market/pkg/logger"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateSupplier godoc
+// @Router       /supplier [POST]
+// @Summary      CREATE SUPPLIER
+// @Description adds supplier data to db based on given info in body
+// @Tags         SUPPLIER
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        data  body      models.CreateSupplier  true  "supplier data"
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) CreateSupplier(ctx *gin.Context) {
+	var supplier models.CreateSupplier
+	err := ctx.ShouldBind(&supplier)
+	if err != nil {
+		h.log.Error("error while binding supplier:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, "invalid body")
+		return
+	}
+
+	resp, err := h.strg.Supplier().Create(&supplier)
+	if err != nil {
+		h.log.Error("error supplier create:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, "internal server error")
+		return
+	}
+	ctx.JSON(http.StatusCreated, gin.H{"code": http.StatusCreated, "message": "success", "resp": resp})
+}
+
+// ListSuppliers godoc
+// @Router       /supplier [GET]
+// @Summary      LIST SUPPLIERS
+// @Description  gets all supplier based on limit, page and search by name
+// @Tags         SUPPLIER
+// @Accept       json
+// @Produce      json
+// @Param  		 limit         query     int        false  "limit"          minimum(1)     maximum(500)   default(10)
+// @Param  		 page          query     int        false  "page"           minimum(1)     default(1)
+// @Param   	 cursor        query     string     false  "next_cursor of previous page, page is ignored with it"
+// @Param   	 sort          query     string     false  "one of created_at, name, - prefix sorts descending"  default(-created_at)
+// @Param   	 count         query     bool       false  "return total count, by default only without cursor"
+// @Param   	 date_from     query     string     false  "created_at from, date means its beginning"
+// @Param   	 date_to       query     string     false  "created_at to, date means its end"
+// @Param   	 field[op]     query     string     false  "filter on name, address, phone_number, created_at, updated_at; op is eq, ne, gt, gte, lt, lte, in (comma separated), null (true or false) or like"
+// @Param   	 search        query     string     false  "search"
+// @Success      200  {object}  models.SupplierGetListResponse
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetListSupplier(ctx *gin.Context) {
+	page, limit, opts, err := listParams(ctx)
+	if err != nil {
+		h.log.Error("error get list params:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.strg.Supplier().GetList(&models.SupplierGetListRequest{
+		Page:        page,
+		Limit:       limit,
+		ListOptions: opts,
+		Search:      ctx.Query("search"),
+	})
+	if err != nil {
+		h.log.Error("error Supplier GetListSupplier:", logger.Error(err))
+		if listError(ctx, err) {
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// GetSupplier godoc
+// @Router       /supplier/{id} [GET]
+// @Summary      GET BY ID
+// @Description  gets supplier by ID
+// @Tags         SUPPLIER
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Supplier ID" format(uuid)
+// @Success      200  {object}  models.Supplier
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetByIDSupplier(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	resp, err := h.strg.Supplier().GetByID(&models.SupplierPrimaryKey{Id: id})
+	if err != nil {
+		h.log.Error("error get supplier:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// UpdateSupplier godoc
+// @Router       /supplier/{id} [PUT]
+// @Summary      UPDATE SUPPLIER
+// @Description  UPDATES SUPPLIER BASED ON GIVEN DATA AND ID
+// @Tags         SUPPLIER
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        id    path     string  true  "id of supplier" format(uuid)
+// @Param        data  body      models.CreateSupplier  true  "supplier data"
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) UpdateSupplier(ctx *gin.Context) {
+	var supplier models.UpdateSupplier
+
+	err := ctx.ShouldBind(&supplier)
+	if err != nil {
+		h.log.Error("error while binding:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+
+	supplier.Id = ctx.Param("id")
+	resp, err := h.strg.Supplier().Update(&supplier)
+	if err != nil {
+		h.log.Error("error supplier update:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success", "resp": resp})
+}
+
+// DeleteSupplier godoc
+// @Router       /supplier/{id} [DELETE]
+// @Summary      DELETE SUPPLIER BY ID
+// @Description  deletes supplier by id
+// @Tags         SUPPLIER
+// @Accept       json
+// @Produce      json
+// @Param        id    path     string  true  "id of supplier" format(uuid)
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) DeleteSupplier(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	err := h.strg.Supplier().Delete(&models.SupplierPrimaryKey{Id: id})
+	if err != nil {
+		h.log.Error("error deleting supplier:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success"})
+}
+
+// GetSupplierImportTemplate godoc
+// @Router       /supplier/{id}/import_template [GET]
+// @Summary      GET SUPPLIER IMPORT TEMPLATE
+// @Description  gets columns of supplier delivery notes, default columns are returned when template is not saved
+// @Tags         SUPPLIER
+// @Accept       json
+// @Produce      json
+// @Param        id   path      string  true  "Supplier ID" format(uuid)
+// @Success      200  {object}  models.SupplierImportTemplate
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) GetSupplierImportTemplate(ctx *gin.Context) {
+	id := ctx.Param("id")
+
+	resp, err := h.strg.Supplier().GetImportTemplate(&models.SupplierPrimaryKey{Id: id})
+	if err != nil {
+		h.log.Error("error get supplier import template:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// SaveSupplierImportTemplate godoc
+// @Router       /supplier/{id}/import_template [PUT]
+// @Summary      SAVE SUPPLIER IMPORT TEMPLATE
+// @Description  saves which columns of supplier delivery notes hold barcode, count and price, by header titles or letters like "col:C"
+// @Tags         SUPPLIER
+// @Accept       json
+// @Produce      json
+// @Param        Idempotency-Key  header  string  false  "retry of request with the same key returns its original response"
+// @Param        id    path     string  true  "id of supplier" format(uuid)
+// @Param        data  body      models.SupplierImportTemplate  true  "import template"
+// @Success      200  {string}  string
+// @Failure      400  {object}  models.ErrorResp
+// @Failure      404  {object}  models.ErrorResp
+// @Failure      500  {object}  models.ErrorResp
+func (h *Handler) SaveSupplierImportTemplate(ctx *gin.Context) {
+	var template models.SupplierImportTemplate
+
+	err := ctx.ShouldBind(&template)
+	if err != nil {
+		h.log.Error("error while binding:", logger.Error(err))
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return
+	}
+	err = importer.ValidateTemplate(&template)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if template.HeaderRow < 1 {
+		template.HeaderRow = 1
+	}
+
+	template.SupplierId = ctx.Param("id")
+	resp, err := h.strg.Supplier().SaveImportTemplate(&template)
+	if err != nil {
+		h.log.Error("error supplier import template save:", logger.Error(err))
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"code": http.StatusOK, "message": "success", "resp": resp})
+}
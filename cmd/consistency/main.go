@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"market/config"
+	"market/models"
+	"market/storage/postgres"
+	"os"
+	"sort"
+)
+
+// consistency reports remaining, coming lines and stock movements which drifted from products they belong to,
+// it exits with status 1 when any drift is found
+func main() {
+	limit := flag.Int("limit", 10, "max number of sample rows of every check")
+	flag.Parse()
+
+	if *limit < 1 {
+		fmt.Fprintln(os.Stderr, "invalid -limit: must be positive")
+		os.Exit(2)
+	}
+
+	strg, err := postgres.NewStorage(context.Background(), config.Load())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer strg.Close()
+
+	report, err := strg.Consistency().Check(&models.ConsistencyRequest{Limit: *limit})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if len(report.Counts) == 0 {
+		fmt.Println("no drift found")
+		return
+	}
+
+	checks := make([]string, 0, len(report.Counts))
+	for check := range report.Counts {
+		checks = append(checks, check)
+	}
+	sort.Strings(checks)
+
+	for _, check := range checks {
+		fmt.Printf("%s: %d rows\n", check, report.Counts[check])
+	}
+
+	for _, issue := range report.Issues {
+		fmt.Printf("%s.%s\tid=%s\tproduct_id=%s\tbarcode=%s\tstored=%q\texpected=%q\n",
+			issue.Table, issue.Check, issue.Id, issue.ProductId, issue.Barcode, issue.Stored, issue.Expected)
+	}
+
+	// deferred close is skipped by os.Exit
+	strg.Close()
+	os.Exit(1)
+}
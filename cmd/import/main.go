@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"market/config"
+	"market/models"
+	"market/pkg/importer"
+	"market/pkg/sheet"
+	"market/storage/postgres"
+	"os"
+)
+
+// import loads product catalog from csv or xlsx file the same way as POST /product/import
+func main() {
+	file := flag.String("file", "", "csv or xlsx file with name, barcode, price and category columns")
+	dryRun := flag.Bool("dry-run", false, "validate and count without saving")
+	flag.Parse()
+
+	if *file == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer f.Close()
+
+	rows, err := sheet.Read(f, *file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	products, rowErrs, err := importer.ProductRows(rows)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	strg, err := postgres.NewStorage(context.Background(), config.Load())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer strg.Close()
+
+	resp, err := strg.Product().Import(&models.ProductImportRequest{Rows: products, DryRun: *dryRun})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	resp.Total += len(rowErrs)
+	resp.Errors = append(rowErrs, resp.Errors...)
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(resp)
+
+	if len(resp.Errors) > 0 {
+		os.Exit(1)
+	}
+}
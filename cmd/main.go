@@ -7,6 +7,8 @@ import (
 
 	"market/api/handler"
 	"market/config"
+	"market/pkg/filestore"
+	"market/pkg/job"
 	"market/pkg/logger"
 	"market/storage/postgres"
 )
@@ -19,8 +21,18 @@ func main() {
 		return
 	}
 
-	h := handler.NewHandler(strg, log)
+	go job.StockSnapshots(context.Background(), strg, log, cfg.StockSnapshotInterval)
+	go job.ApplyPrices(context.Background(), strg, log, cfg.PriceApplyInterval)
+	go job.PurgeIdempotencyKeys(context.Background(), strg, log, cfg.IdempotencyPurgeInterval)
 
-	r := api.NewServer(h)
+	files, err := filestore.New(cfg)
+	if err != nil {
+		log.Error("error while creating file store:", logger.Error(err))
+		return
+	}
+
+	h := handler.NewHandler(strg, log, files)
+
+	r := api.NewServer(h, cfg)
 	r.Run(fmt.Sprintf(":%s", cfg.Port))
 }
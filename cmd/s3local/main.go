@@ -0,0 +1,131 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// s3local is a stand-in for S3 compatible storage to run the service with FILESTORE_DRIVER=s3 without one.
+// It keeps objects of path style requests in a directory and supports only PUT, GET, HEAD and DELETE of
+// objects. Signatures are not verified, only their presence is required
+func main() {
+	addr := flag.String("addr", ":9000", "address to listen")
+	dir := flag.String("dir", "./s3local", "directory objects are kept in")
+	flag.Parse()
+
+	log.Printf("s3local serving %s on %s", *dir, *addr)
+	log.Fatal(http.ListenAndServe(*addr, &server{dir: *dir}))
+}
+
+type server struct {
+	dir string
+}
+
+func (s *server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 ") {
+		s.error(w, http.StatusForbidden, "AccessDenied")
+		return
+	}
+
+	name, ok := s.path(r.URL.Path)
+	if !ok {
+		s.error(w, http.StatusBadRequest, "InvalidURI")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		err := s.put(name, r)
+		if err != nil {
+			log.Println("put", r.URL.Path, err)
+			s.error(w, http.StatusInternalServerError, "InternalError")
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		file, err := os.Open(name)
+		if errors.Is(err, fs.ErrNotExist) {
+			s.error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		if err != nil {
+			s.error(w, http.StatusInternalServerError, "InternalError")
+			return
+		}
+		defer file.Close()
+
+		contentType, _ := os.ReadFile(name + ".content-type")
+		if len(contentType) > 0 {
+			w.Header().Set("Content-Type", string(contentType))
+		}
+		stat, err := file.Stat()
+		if err == nil {
+			w.Header().Set("Content-Length", fmt.Sprint(stat.Size()))
+		}
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			io.Copy(w, file)
+		}
+	case http.MethodDelete:
+		os.Remove(name + ".content-type")
+		err := os.Remove(name)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			s.error(w, http.StatusInternalServerError, "InternalError")
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.error(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+// path maps /bucket/key to file of the object, keys ending with .content-type are reserved for metadata
+func (s *server) path(urlPath string) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(urlPath, "/"), "/")
+	if len(parts) < 2 || strings.HasSuffix(urlPath, ".content-type") {
+		return "", false
+	}
+	for _, part := range parts {
+		if part == "" || part == "." || part == ".." {
+			return "", false
+		}
+	}
+	return filepath.Join(append([]string{s.dir}, parts...)...), true
+}
+
+func (s *server) put(name string, r *http.Request) error {
+	err := os.MkdirAll(filepath.Dir(name), 0o755)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(file, r.Body)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(name+".content-type", []byte(r.Header.Get("Content-Type")), 0o644)
+}
+
+func (s *server) error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<Error><Code>%s</Code></Error>", code)
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"market/config"
+	"market/models"
+	"market/storage/postgres"
+	"os"
+	"time"
+)
+
+// snapshot persists closing balances before the first day of given months, e.g. to fill history after migration
+func main() {
+	from := flag.String("from", "", "first month to close, like 2023-01")
+	to := flag.String("to", "", "last month to close, like 2023-12, previous month by default")
+	flag.Parse()
+
+	now := time.Now()
+	last := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local).AddDate(0, -1, 0)
+	if *to != "" {
+		month, err := time.ParseInLocation("2006-01", *to, time.Local)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "invalid -to:", err)
+			os.Exit(2)
+		}
+		last = month
+	}
+	first := last
+	if *from != "" {
+		month, err := time.ParseInLocation("2006-01", *from, time.Local)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "invalid -from:", err)
+			os.Exit(2)
+		}
+		first = month
+	}
+
+	strg, err := postgres.NewStorage(context.Background(), config.Load())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	defer strg.Close()
+
+	// months are closed in order because every snapshot starts from the previous one
+	for month := first; !month.After(last); month = month.AddDate(0, 1, 0) {
+		periodEnd := month.AddDate(0, 1, 0)
+
+		rows, err := strg.Remaining().Snapshot(&models.StockSnapshotRequest{PeriodEnd: periodEnd})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, month.Format("2006-01"), err)
+			os.Exit(1)
+		}
+		fmt.Printf("%s: %d balances\n", month.Format("2006-01"), rows)
+	}
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"market/config"
+	"market/models"
+	"market/storage"
+	"market/storage/postgres"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// stress hammers remaining of one barcode from many goroutines and checks that no update is lost:
+// first with upserts adding counts, then with versioned updates retried on stale version.
+// It works on its own barcode which is deleted at the end, so it may run against a live database
+func main() {
+	branchId := flag.String("branch", "", "id of existing branch to use")
+	barcode := flag.String("barcode", fmt.Sprintf("stress-%d", time.Now().UnixNano()), "barcode to hammer, must not be in use")
+	workers := flag.Int("workers", 20, "number of concurrent goroutines")
+	iterations := flag.Int("iterations", 50, "number of writes of every goroutine")
+	flag.Parse()
+
+	if *branchId == "" || *workers < 1 || *iterations < 1 {
+		fmt.Fprintln(os.Stderr, "-branch is required, -workers and -iterations must be positive")
+		os.Exit(2)
+	}
+
+	strg, err := postgres.NewStorage(context.Background(), config.Load())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	failed := run(strg, *branchId, *barcode, *workers, *iterations)
+	strg.Close()
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func run(strg storage.StorageI, branchId, barcode string, workers, iterations int) bool {
+	var (
+		expected = workers * iterations
+		failed   bool
+		errs     int64
+	)
+
+	// upserts: every goroutine adds one piece at a time, all of them land in one row
+	parallel(workers, func() {
+		for i := 0; i < iterations; i++ {
+			_, err := strg.Remaining().Upsert(&models.CreateRemaining{
+				BranchId:   branchId,
+				Name:       "stress " + barcode,
+				Price:      1,
+				Barcode:    barcode,
+				Count:      1,
+				TotalPrice: 1,
+			})
+			if err != nil {
+				atomic.AddInt64(&errs, 1)
+				fmt.Fprintln(os.Stderr, "upsert:", err)
+			}
+		}
+	})
+
+	list, err := strg.Remaining().GetList(&models.RemainingGetListRequest{Page: 1, Limit: 10, BranchId: branchId, Barcode: barcode})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return true
+	}
+	if len(list.Remainings) != 1 {
+		fmt.Printf("upsert: FAIL %d rows of barcode %s\n", len(list.Remainings), barcode)
+		failed = true
+	}
+	if len(list.Remainings) == 0 {
+		return true
+	}
+
+	remaining := list.Remainings[0]
+	defer func() {
+		err := strg.Remaining().Delete(&models.RemainingPrimaryKey{Id: remaining.Id})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "cleanup:", err)
+		}
+	}()
+
+	if remaining.Count != expected || errs > 0 {
+		fmt.Printf("upsert: FAIL count %d, expected %d, %d errors\n", remaining.Count, expected, errs)
+		failed = true
+	} else {
+		fmt.Printf("upsert: ok count %d\n", remaining.Count)
+	}
+
+	// versioned updates: read, add one and write back that version, stale writes are retried
+	var conflicts int64
+	errs = 0
+	parallel(workers, func() {
+		for i := 0; i < iterations; {
+			current, err := strg.Remaining().GetByID(&models.RemainingPrimaryKey{Id: remaining.Id})
+			if err != nil {
+				atomic.AddInt64(&errs, 1)
+				fmt.Fprintln(os.Stderr, "get:", err)
+				return
+			}
+
+			_, err = strg.Remaining().Update(&models.UpdateRemaining{
+				Id:         current.Id,
+				BranchId:   current.BranchId,
+				CategoryId: current.CategoryId,
+				Name:       current.Name,
+				Price:      current.Price,
+				Barcode:    current.Barcode,
+				Count:      current.Count + 1,
+				TotalPrice: current.Price * float64(current.Count+1),
+				Version:    current.Version,
+			})
+
+			var versionErr *storage.VersionError
+			if errors.As(err, &versionErr) {
+				atomic.AddInt64(&conflicts, 1)
+				continue
+			}
+			if err != nil {
+				atomic.AddInt64(&errs, 1)
+				fmt.Fprintln(os.Stderr, "update:", err)
+				return
+			}
+			i++
+		}
+	})
+
+	current, err := strg.Remaining().GetByID(&models.RemainingPrimaryKey{Id: remaining.Id})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return true
+	}
+
+	if current.Count != remaining.Count+expected || errs > 0 {
+		fmt.Printf("versioned update: FAIL count %d, expected %d, %d errors\n", current.Count, remaining.Count+expected, errs)
+		failed = true
+	} else {
+		fmt.Printf("versioned update: ok count %d, %d stale versions retried\n", current.Count, conflicts)
+	}
+
+	return failed
+}
+
+// parallel runs fn in n goroutines and waits for all of them
+func parallel(n int, fn func()) {
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn()
+		}()
+	}
+	wg.Wait()
+}
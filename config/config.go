@@ -35,6 +35,29 @@ type Config struct {
 
 	DefaultOffset int
 	DefaultLimit  int
+
+	// StockSnapshotInterval is how often closing balances of the previous month are recalculated
+	StockSnapshotInterval time.Duration
+	// PriceApplyInterval is how often scheduled prices which became effective are copied to products
+	PriceApplyInterval time.Duration
+
+	// IdempotencyTTL is how long response of request with Idempotency-Key is replayed to its retries
+	IdempotencyTTL time.Duration
+	// IdempotencyPurgeInterval is how often expired idempotency keys are deleted
+	IdempotencyPurgeInterval time.Duration
+
+	// FilestoreDriver is where uploaded files are kept: local or s3
+	FilestoreDriver string
+	// FilestoreDir is directory of local driver
+	FilestoreDir string
+	// FilestorePublicURL is base address files are downloaded from, the service serves local files under /files
+	FilestorePublicURL string
+
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
 }
 
 // Load ...
@@ -59,6 +82,22 @@ func Load() Config {
 
 	config.PostgresMaxConnections = cast.ToInt32(getOrReturnDefaultValue("POSTGRES_MAX_CONNECTIONS", 30))
 
+	config.StockSnapshotInterval = cast.ToDuration(getOrReturnDefaultValue("STOCK_SNAPSHOT_INTERVAL", "24h"))
+	config.PriceApplyInterval = cast.ToDuration(getOrReturnDefaultValue("PRICE_APPLY_INTERVAL", "1m"))
+
+	config.IdempotencyTTL = cast.ToDuration(getOrReturnDefaultValue("IDEMPOTENCY_TTL", "24h"))
+	config.IdempotencyPurgeInterval = cast.ToDuration(getOrReturnDefaultValue("IDEMPOTENCY_PURGE_INTERVAL", "1h"))
+
+	config.FilestoreDriver = cast.ToString(getOrReturnDefaultValue("FILESTORE_DRIVER", "local"))
+	config.FilestoreDir = cast.ToString(getOrReturnDefaultValue("FILESTORE_DIR", "./files"))
+	config.FilestorePublicURL = cast.ToString(getOrReturnDefaultValue("FILESTORE_PUBLIC_URL", "/files"))
+
+	config.S3Endpoint = cast.ToString(getOrReturnDefaultValue("S3_ENDPOINT", "http://localhost:9000"))
+	config.S3Region = cast.ToString(getOrReturnDefaultValue("S3_REGION", "us-east-1"))
+	config.S3Bucket = cast.ToString(getOrReturnDefaultValue("S3_BUCKET", "market"))
+	config.S3AccessKey = cast.ToString(getOrReturnDefaultValue("S3_ACCESS_KEY", ""))
+	config.S3SecretKey = cast.ToString(getOrReturnDefaultValue("S3_SECRET_KEY", ""))
+
 	return config
 }
 
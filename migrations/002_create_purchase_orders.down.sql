@@ -0,0 +1,13 @@
+-- Drop foreign key constraints
+ALTER TABLE "coming_table" DROP CONSTRAINT IF EXISTS "coming_table_purchase_order_id_fkey";
+ALTER TABLE "coming_table" DROP CONSTRAINT IF EXISTS "coming_table_supplier_id_fkey";
+
+ALTER TABLE "coming_table" DROP COLUMN IF EXISTS "purchase_order_id";
+ALTER TABLE "coming_table" DROP COLUMN IF EXISTS "supplier_id";
+
+-- Drop tables
+DROP TABLE IF EXISTS "purchase_order_product";
+DROP TABLE IF EXISTS "purchase_order";
+DROP TABLE IF EXISTS "supplier";
+
+DROP TYPE IF EXISTS purchase_order_status;
@@ -0,0 +1,50 @@
+CREATE TABLE "supplier" (
+  "id" uuid PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "address" varchar,
+  "phone_number" varchar,
+  "created_at" timestamp DEFAULT (current_timestamp),
+  "updated_at" timestamp
+);
+
+CREATE TYPE purchase_order_status AS ENUM ('new', 'partially_received', 'received');
+
+CREATE TABLE "purchase_order" (
+  "id" uuid PRIMARY KEY,
+  "order_number" varchar NOT NULL,
+  "supplier_id" uuid,
+  "branch_id" uuid,
+  "expected_date" timestamp,
+  "status" purchase_order_status DEFAULT 'new',
+  "created_at" timestamp DEFAULT (current_timestamp),
+  "updated_at" timestamp
+);
+
+CREATE TABLE "purchase_order_product" (
+  "id" uuid PRIMARY KEY,
+  "purchase_order_id" uuid,
+  "category_id" uuid,
+  "name" varchar NOT NULL,
+  "barcode" varchar NOT NULL,
+  "count" numeric NOT NULL DEFAULT 0,
+  "price" numeric NOT NULL DEFAULT 0,
+  "total_price" numeric DEFAULT 0,
+  "received_count" numeric NOT NULL DEFAULT 0,
+  "created_at" timestamp DEFAULT (current_timestamp),
+  "updated_at" timestamp
+);
+
+ALTER TABLE "coming_table" ADD COLUMN "supplier_id" uuid;
+ALTER TABLE "coming_table" ADD COLUMN "purchase_order_id" uuid;
+
+ALTER TABLE "purchase_order" ADD FOREIGN KEY ("supplier_id") REFERENCES "supplier" ("id");
+
+ALTER TABLE "purchase_order" ADD FOREIGN KEY ("branch_id") REFERENCES "branch" ("id");
+
+ALTER TABLE "purchase_order_product" ADD FOREIGN KEY ("purchase_order_id") REFERENCES "purchase_order" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "purchase_order_product" ADD FOREIGN KEY ("category_id") REFERENCES "category" ("id");
+
+ALTER TABLE "coming_table" ADD FOREIGN KEY ("supplier_id") REFERENCES "supplier" ("id");
+
+ALTER TABLE "coming_table" ADD FOREIGN KEY ("purchase_order_id") REFERENCES "purchase_order" ("id");
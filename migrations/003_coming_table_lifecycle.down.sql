@@ -0,0 +1,20 @@
+-- Drop foreign key constraints
+ALTER TABLE "stock_movement" DROP CONSTRAINT IF EXISTS "stock_movement_category_id_fkey";
+ALTER TABLE "stock_movement" DROP CONSTRAINT IF EXISTS "stock_movement_branch_id_fkey";
+
+-- Drop tables
+DROP TABLE IF EXISTS "stock_movement";
+
+DROP TYPE IF EXISTS stock_movement_type;
+
+ALTER TABLE "coming_table" DROP COLUMN IF EXISTS "reversed_at";
+ALTER TABLE "coming_table" DROP COLUMN IF EXISTS "posted_at";
+
+CREATE TYPE coming_status AS ENUM ('in_process', 'finished');
+
+ALTER TABLE "coming_table" ALTER COLUMN "status" DROP DEFAULT;
+ALTER TABLE "coming_table" ALTER COLUMN "status" TYPE coming_status
+  USING (CASE WHEN "status" IN ('posted', 'reversed') THEN 'finished' ELSE 'in_process' END)::coming_status;
+ALTER TABLE "coming_table" ALTER COLUMN "status" SET DEFAULT 'in_process';
+
+DROP TYPE IF EXISTS coming_table_status;
@@ -0,0 +1,64 @@
+CREATE TYPE coming_table_status AS ENUM ('draft', 'receiving', 'awaiting_approval', 'posted', 'cancelled', 'reversed');
+
+ALTER TABLE "coming_table" ALTER COLUMN "status" DROP DEFAULT;
+ALTER TABLE "coming_table" ALTER COLUMN "status" TYPE coming_table_status
+  USING (CASE "status" WHEN 'finished' THEN 'posted' ELSE 'receiving' END)::coming_table_status;
+ALTER TABLE "coming_table" ALTER COLUMN "status" SET DEFAULT 'draft';
+
+DROP TYPE IF EXISTS coming_status;
+
+ALTER TABLE "coming_table" ADD COLUMN "posted_at" timestamp;
+ALTER TABLE "coming_table" ADD COLUMN "reversed_at" timestamp;
+
+CREATE TYPE stock_movement_type AS ENUM ('income', 'income_reversal');
+
+CREATE TABLE "stock_movement" (
+  "id" uuid PRIMARY KEY,
+  "branch_id" uuid,
+  "category_id" uuid,
+  "name" varchar NOT NULL,
+  "price" numeric NOT NULL,
+  "barcode" varchar NOT NULL,
+  "count" numeric NOT NULL DEFAULT 0,
+  "total_price" numeric DEFAULT 0,
+  "type" stock_movement_type NOT NULL,
+  "document_id" uuid,
+  "date_time" timestamp,
+  "created_at" timestamp DEFAULT (current_timestamp)
+);
+
+ALTER TABLE "stock_movement" ADD FOREIGN KEY ("branch_id") REFERENCES "branch" ("id");
+
+ALTER TABLE "stock_movement" ADD FOREIGN KEY ("category_id") REFERENCES "category" ("id");
+
+-- documents finished before movements existed get their income, so they can be reversed like posted ones
+INSERT INTO "stock_movement"(
+  "id",
+  "branch_id",
+  "category_id",
+  "name",
+  "price",
+  "barcode",
+  "count",
+  "total_price",
+  "type",
+  "document_id",
+  "date_time",
+  "created_at")
+SELECT
+  gen_random_uuid(),
+  ct."branch_id",
+  (array_agg(ctp."category_id"))[1],
+  MAX(ctp."name"),
+  MAX(ctp."price"),
+  ctp."barcode",
+  SUM(ctp."count"),
+  SUM(ctp."total_price"),
+  'income',
+  ct."id",
+  COALESCE(ct."date_time", ct."updated_at", ct."created_at", NOW()),
+  NOW()
+FROM "coming_table_product" AS ctp
+JOIN "coming_table" AS ct ON ct."id" = ctp."coming_table_id"
+WHERE ct."status" = 'posted'
+GROUP BY ct."id", ct."branch_id", ct."date_time", ct."updated_at", ct."created_at", ctp."barcode";
@@ -0,0 +1,3 @@
+ALTER TABLE "product" DROP COLUMN IF EXISTS "status";
+
+DROP TYPE IF EXISTS product_status;
@@ -0,0 +1,3 @@
+CREATE TYPE product_status AS ENUM ('active', 'pending_review');
+
+ALTER TABLE "product" ADD COLUMN "status" product_status NOT NULL DEFAULT 'active';
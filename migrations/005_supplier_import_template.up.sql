@@ -0,0 +1,11 @@
+CREATE TABLE "supplier_import_template" (
+  "supplier_id" uuid PRIMARY KEY,
+  "barcode_column" varchar NOT NULL DEFAULT 'barcode',
+  "count_column" varchar NOT NULL DEFAULT 'count',
+  "price_column" varchar,
+  "header_row" int NOT NULL DEFAULT 1,
+  "created_at" timestamp DEFAULT (current_timestamp),
+  "updated_at" timestamp
+);
+
+ALTER TABLE "supplier_import_template" ADD FOREIGN KEY ("supplier_id") REFERENCES "supplier" ("id") ON DELETE CASCADE;
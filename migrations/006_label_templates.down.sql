@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "label_template";
+
+DROP TYPE IF EXISTS label_barcode_type;
@@ -0,0 +1,15 @@
+CREATE TYPE label_barcode_type AS ENUM ('auto', 'ean13', 'code128');
+
+CREATE TABLE "label_template" (
+  "branch_id" uuid PRIMARY KEY,
+  "width" numeric NOT NULL DEFAULT 58,
+  "height" numeric NOT NULL DEFAULT 40,
+  "columns" int NOT NULL DEFAULT 3,
+  "font_size" numeric NOT NULL DEFAULT 9,
+  "barcode_type" label_barcode_type NOT NULL DEFAULT 'auto',
+  "dpi" int NOT NULL DEFAULT 203,
+  "created_at" timestamp DEFAULT (current_timestamp),
+  "updated_at" timestamp
+);
+
+ALTER TABLE "label_template" ADD FOREIGN KEY ("branch_id") REFERENCES "branch" ("id") ON DELETE CASCADE;
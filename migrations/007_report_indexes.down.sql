@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS "stock_movement_date_time_idx";
+
+DROP INDEX IF EXISTS "stock_movement_branch_id_barcode_date_time_idx";
+
+DROP INDEX IF EXISTS "coming_table_product_coming_table_id_idx";
+
+DROP INDEX IF EXISTS "coming_table_status_date_time_idx";
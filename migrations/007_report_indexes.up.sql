@@ -0,0 +1,7 @@
+CREATE INDEX IF NOT EXISTS "coming_table_status_date_time_idx" ON "coming_table" ("status", "date_time");
+
+CREATE INDEX IF NOT EXISTS "coming_table_product_coming_table_id_idx" ON "coming_table_product" ("coming_table_id");
+
+CREATE INDEX IF NOT EXISTS "stock_movement_branch_id_barcode_date_time_idx" ON "stock_movement" ("branch_id", "barcode", "date_time");
+
+CREATE INDEX IF NOT EXISTS "stock_movement_date_time_idx" ON "stock_movement" ("date_time");
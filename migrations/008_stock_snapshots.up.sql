@@ -0,0 +1,18 @@
+CREATE TABLE "stock_snapshot" (
+  "id" uuid PRIMARY KEY,
+  "branch_id" uuid NOT NULL,
+  "category_id" uuid,
+  "name" varchar NOT NULL,
+  "price" numeric NOT NULL,
+  "barcode" varchar NOT NULL,
+  "count" numeric NOT NULL DEFAULT 0,
+  "total_price" numeric DEFAULT 0,
+  "period_end" timestamp NOT NULL,
+  "created_at" timestamp DEFAULT (current_timestamp)
+);
+
+ALTER TABLE "stock_snapshot" ADD FOREIGN KEY ("branch_id") REFERENCES "branch" ("id");
+
+ALTER TABLE "stock_snapshot" ADD FOREIGN KEY ("category_id") REFERENCES "category" ("id");
+
+CREATE UNIQUE INDEX "stock_snapshot_period_end_branch_id_barcode_idx" ON "stock_snapshot" ("period_end", "branch_id", "barcode");
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "period_closing_log";
+
+DROP TYPE IF EXISTS period_closing_action;
+
+DROP TABLE IF EXISTS "period_closing";
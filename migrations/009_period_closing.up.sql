@@ -0,0 +1,23 @@
+CREATE TABLE "period_closing" (
+  "branch_id" uuid NOT NULL,
+  "month" date NOT NULL,
+  "closed_by" varchar NOT NULL,
+  "closed_at" timestamp DEFAULT (current_timestamp),
+  PRIMARY KEY ("branch_id", "month")
+);
+
+ALTER TABLE "period_closing" ADD FOREIGN KEY ("branch_id") REFERENCES "branch" ("id");
+
+CREATE TYPE period_closing_action AS ENUM ('close', 'reopen');
+
+CREATE TABLE "period_closing_log" (
+  "id" uuid PRIMARY KEY,
+  "branch_id" uuid NOT NULL,
+  "month" date NOT NULL,
+  "action" period_closing_action NOT NULL,
+  "user_name" varchar NOT NULL,
+  "reason" varchar,
+  "created_at" timestamp DEFAULT (current_timestamp)
+);
+
+ALTER TABLE "period_closing_log" ADD FOREIGN KEY ("branch_id") REFERENCES "branch" ("id");
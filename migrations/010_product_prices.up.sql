@@ -0,0 +1,22 @@
+CREATE TABLE "product_price" (
+  "id" uuid PRIMARY KEY,
+  "product_id" uuid NOT NULL,
+  "branch_id" uuid,
+  "price" numeric NOT NULL,
+  "effective_from" timestamp NOT NULL,
+  "applied_at" timestamp,
+  "created_by" varchar,
+  "created_at" timestamp DEFAULT (current_timestamp)
+);
+
+ALTER TABLE "product_price" ADD FOREIGN KEY ("product_id") REFERENCES "product" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "product_price" ADD FOREIGN KEY ("branch_id") REFERENCES "branch" ("id");
+
+CREATE INDEX "product_price_product_id_branch_id_effective_from_idx" ON "product_price" ("product_id", "branch_id", "effective_from" DESC);
+
+CREATE INDEX "product_price_pending_idx" ON "product_price" ("effective_from") WHERE "applied_at" IS NULL AND "branch_id" IS NULL;
+
+INSERT INTO "product_price"("id", "product_id", "price", "effective_from", "applied_at", "created_at")
+SELECT gen_random_uuid(), "id", "price", COALESCE("created_at", NOW()), NOW(), NOW()
+FROM "product";
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "promotion_usage";
+
+DROP TABLE IF EXISTS "promotion";
+
+DROP TYPE IF EXISTS promotion_type;
@@ -0,0 +1,42 @@
+CREATE TYPE promotion_type AS ENUM ('percent', 'buy_get', 'bundle_price');
+
+CREATE TABLE "promotion" (
+  "id" uuid PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "type" promotion_type NOT NULL,
+  "percent" numeric NOT NULL DEFAULT 0,
+  "buy_count" int NOT NULL DEFAULT 0,
+  "free_count" int NOT NULL DEFAULT 0,
+  "bundle_count" int NOT NULL DEFAULT 0,
+  "bundle_price" numeric NOT NULL DEFAULT 0,
+  "product_ids" uuid[] NOT NULL DEFAULT '{}',
+  "category_ids" uuid[] NOT NULL DEFAULT '{}',
+  "branch_id" uuid,
+  "starts_at" timestamp NOT NULL,
+  "ends_at" timestamp,
+  "priority" int NOT NULL DEFAULT 0,
+  "active" boolean NOT NULL DEFAULT true,
+  "created_at" timestamp DEFAULT (current_timestamp),
+  "updated_at" timestamp
+);
+
+ALTER TABLE "promotion" ADD FOREIGN KEY ("branch_id") REFERENCES "branch" ("id");
+
+CREATE INDEX "promotion_starts_at_ends_at_idx" ON "promotion" ("starts_at", "ends_at") WHERE "active";
+
+CREATE TABLE "promotion_usage" (
+  "id" uuid PRIMARY KEY,
+  "promotion_id" uuid NOT NULL,
+  "branch_id" uuid,
+  "document_id" varchar,
+  "barcode" varchar NOT NULL,
+  "count" int NOT NULL,
+  "discount" numeric NOT NULL,
+  "created_at" timestamp DEFAULT (current_timestamp)
+);
+
+ALTER TABLE "promotion_usage" ADD FOREIGN KEY ("promotion_id") REFERENCES "promotion" ("id");
+
+ALTER TABLE "promotion_usage" ADD FOREIGN KEY ("branch_id") REFERENCES "branch" ("id");
+
+CREATE INDEX "promotion_usage_created_at_idx" ON "promotion_usage" ("created_at");
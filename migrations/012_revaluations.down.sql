@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "revaluation_line";
+
+DROP TABLE IF EXISTS "revaluation";
@@ -0,0 +1,34 @@
+CREATE TABLE "revaluation" (
+  "id" uuid PRIMARY KEY,
+  "product_id" uuid,
+  "barcode" varchar NOT NULL,
+  "name" varchar NOT NULL,
+  "old_price" numeric NOT NULL,
+  "new_price" numeric NOT NULL,
+  "difference" numeric NOT NULL DEFAULT 0,
+  "source" varchar NOT NULL,
+  "created_by" varchar,
+  "created_at" timestamp DEFAULT (current_timestamp)
+);
+
+ALTER TABLE "revaluation" ADD FOREIGN KEY ("product_id") REFERENCES "product" ("id") ON DELETE SET NULL;
+
+CREATE INDEX "revaluation_barcode_idx" ON "revaluation" ("barcode");
+
+CREATE INDEX "revaluation_created_at_idx" ON "revaluation" ("created_at");
+
+CREATE TABLE "revaluation_line" (
+  "id" uuid PRIMARY KEY,
+  "revaluation_id" uuid NOT NULL,
+  "branch_id" uuid,
+  "count" numeric NOT NULL,
+  "old_price" numeric NOT NULL,
+  "new_price" numeric NOT NULL,
+  "old_total" numeric NOT NULL,
+  "new_total" numeric NOT NULL,
+  "difference" numeric NOT NULL
+);
+
+ALTER TABLE "revaluation_line" ADD FOREIGN KEY ("revaluation_id") REFERENCES "revaluation" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "revaluation_line" ADD FOREIGN KEY ("branch_id") REFERENCES "branch" ("id");
@@ -0,0 +1,5 @@
+ALTER TABLE "stock_movement" DROP COLUMN IF EXISTS "product_id";
+
+ALTER TABLE "coming_table_product" DROP COLUMN IF EXISTS "product_id";
+
+ALTER TABLE "remaining" DROP COLUMN IF EXISTS "product_id";
@@ -0,0 +1,23 @@
+ALTER TABLE "remaining" ADD COLUMN "product_id" uuid;
+
+ALTER TABLE "coming_table_product" ADD COLUMN "product_id" uuid;
+
+ALTER TABLE "stock_movement" ADD COLUMN "product_id" uuid;
+
+UPDATE "remaining" AS r SET "product_id" = p."id" FROM "product" AS p WHERE p."barcode" = r."barcode";
+
+UPDATE "coming_table_product" AS ctp SET "product_id" = p."id" FROM "product" AS p WHERE p."barcode" = ctp."barcode";
+
+UPDATE "stock_movement" AS m SET "product_id" = p."id" FROM "product" AS p WHERE p."barcode" = m."barcode";
+
+ALTER TABLE "remaining" ADD FOREIGN KEY ("product_id") REFERENCES "product" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "coming_table_product" ADD FOREIGN KEY ("product_id") REFERENCES "product" ("id") ON DELETE SET NULL;
+
+ALTER TABLE "stock_movement" ADD FOREIGN KEY ("product_id") REFERENCES "product" ("id") ON DELETE SET NULL;
+
+CREATE INDEX "remaining_product_id_idx" ON "remaining" ("product_id");
+
+CREATE INDEX "coming_table_product_product_id_idx" ON "coming_table_product" ("product_id");
+
+CREATE INDEX "stock_movement_product_id_idx" ON "stock_movement" ("product_id");
@@ -0,0 +1,47 @@
+DROP INDEX IF EXISTS "stock_movement_document_id_type_idx";
+
+DROP INDEX IF EXISTS "revaluation_product_id_idx";
+
+DROP INDEX IF EXISTS "promotion_branch_id_idx";
+
+DROP INDEX IF EXISTS "purchase_order_product_purchase_order_id_idx";
+
+DROP INDEX IF EXISTS "purchase_order_status_idx";
+
+DROP INDEX IF EXISTS "purchase_order_branch_id_idx";
+
+DROP INDEX IF EXISTS "purchase_order_supplier_id_idx";
+
+DROP INDEX IF EXISTS "product_category_id_idx";
+
+DROP INDEX IF EXISTS "product_status_idx";
+
+DROP INDEX IF EXISTS "coming_table_supplier_id_idx";
+
+DROP INDEX IF EXISTS "coming_table_branch_id_idx";
+
+DROP INDEX IF EXISTS "coming_table_coming_id_idx";
+
+DROP INDEX IF EXISTS "coming_table_product_category_id_idx";
+
+DROP INDEX IF EXISTS "coming_table_product_barcode_idx";
+
+DROP INDEX IF EXISTS "remaining_category_id_idx";
+
+DROP INDEX IF EXISTS "remaining_barcode_idx";
+
+CREATE INDEX IF NOT EXISTS "coming_table_product_coming_table_id_idx" ON "coming_table_product" ("coming_table_id");
+
+ALTER TABLE "product" DROP CONSTRAINT IF EXISTS "product_price_check";
+
+ALTER TABLE "purchase_order_product" DROP CONSTRAINT IF EXISTS "purchase_order_product_count_check";
+
+ALTER TABLE "coming_table_product" DROP CONSTRAINT IF EXISTS "coming_table_product_price_check";
+
+ALTER TABLE "coming_table_product" DROP CONSTRAINT IF EXISTS "coming_table_product_count_check";
+
+ALTER TABLE "remaining" DROP CONSTRAINT IF EXISTS "remaining_count_check";
+
+ALTER TABLE "coming_table_product" DROP CONSTRAINT IF EXISTS "coming_table_product_coming_table_id_barcode_key";
+
+ALTER TABLE "remaining" DROP CONSTRAINT IF EXISTS "remaining_branch_id_barcode_key";
@@ -0,0 +1,115 @@
+-- duplicated remaining of branch and barcode are merged into the oldest row before unique constraint is added
+WITH "duplicate" AS (
+  SELECT
+    "id",
+    FIRST_VALUE("id") OVER (PARTITION BY "branch_id", "barcode" ORDER BY "created_at", "id") AS "keep_id"
+  FROM "remaining"
+  WHERE "branch_id" IS NOT NULL
+),
+"merged" AS (
+  SELECT
+    d."keep_id",
+    SUM(r."count") AS "count",
+    SUM(COALESCE(r."total_price", 0)) AS "total_price"
+  FROM "duplicate" AS d
+  JOIN "remaining" AS r ON r."id" = d."id"
+  GROUP BY d."keep_id"
+  HAVING COUNT(*) > 1
+)
+UPDATE "remaining" AS r
+SET "count" = m."count", "total_price" = m."total_price", "updated_at" = NOW()
+FROM "merged" AS m
+WHERE r."id" = m."keep_id";
+
+DELETE FROM "remaining" AS r
+USING (
+  SELECT
+    "id",
+    FIRST_VALUE("id") OVER (PARTITION BY "branch_id", "barcode" ORDER BY "created_at", "id") AS "keep_id"
+  FROM "remaining"
+  WHERE "branch_id" IS NOT NULL
+) AS d
+WHERE r."id" = d."id" AND d."id" <> d."keep_id";
+
+-- the same for barcodes repeated in one coming table
+WITH "duplicate" AS (
+  SELECT
+    "id",
+    FIRST_VALUE("id") OVER (PARTITION BY "coming_table_id", "barcode" ORDER BY "created_at", "id") AS "keep_id"
+  FROM "coming_table_product"
+  WHERE "coming_table_id" IS NOT NULL
+),
+"merged" AS (
+  SELECT
+    d."keep_id",
+    SUM(ctp."count") AS "count",
+    SUM(COALESCE(ctp."total_price", 0)) AS "total_price"
+  FROM "duplicate" AS d
+  JOIN "coming_table_product" AS ctp ON ctp."id" = d."id"
+  GROUP BY d."keep_id"
+  HAVING COUNT(*) > 1
+)
+UPDATE "coming_table_product" AS ctp
+SET "count" = m."count", "total_price" = m."total_price", "updated_at" = NOW()
+FROM "merged" AS m
+WHERE ctp."id" = m."keep_id";
+
+DELETE FROM "coming_table_product" AS ctp
+USING (
+  SELECT
+    "id",
+    FIRST_VALUE("id") OVER (PARTITION BY "coming_table_id", "barcode" ORDER BY "created_at", "id") AS "keep_id"
+  FROM "coming_table_product"
+  WHERE "coming_table_id" IS NOT NULL
+) AS d
+WHERE ctp."id" = d."id" AND d."id" <> d."keep_id";
+
+ALTER TABLE "remaining" ADD CONSTRAINT "remaining_branch_id_barcode_key" UNIQUE ("branch_id", "barcode");
+
+ALTER TABLE "coming_table_product" ADD CONSTRAINT "coming_table_product_coming_table_id_barcode_key" UNIQUE ("coming_table_id", "barcode");
+
+-- existing negative rows are not validated so migration does not fail on them, new writes are checked
+ALTER TABLE "remaining" ADD CONSTRAINT "remaining_count_check" CHECK ("count" >= 0) NOT VALID;
+
+ALTER TABLE "coming_table_product" ADD CONSTRAINT "coming_table_product_count_check" CHECK ("count" >= 0) NOT VALID;
+
+ALTER TABLE "coming_table_product" ADD CONSTRAINT "coming_table_product_price_check" CHECK ("price" >= 0) NOT VALID;
+
+ALTER TABLE "purchase_order_product" ADD CONSTRAINT "purchase_order_product_count_check" CHECK ("count" >= 0 AND "received_count" >= 0) NOT VALID;
+
+ALTER TABLE "product" ADD CONSTRAINT "product_price_check" CHECK ("price" >= 0) NOT VALID;
+
+-- filters of list endpoints, remaining by branch and coming lines by coming table are covered by unique constraints
+DROP INDEX IF EXISTS "coming_table_product_coming_table_id_idx";
+
+CREATE INDEX IF NOT EXISTS "remaining_barcode_idx" ON "remaining" ("barcode");
+
+CREATE INDEX IF NOT EXISTS "remaining_category_id_idx" ON "remaining" ("category_id");
+
+CREATE INDEX IF NOT EXISTS "coming_table_product_barcode_idx" ON "coming_table_product" ("barcode");
+
+CREATE INDEX IF NOT EXISTS "coming_table_product_category_id_idx" ON "coming_table_product" ("category_id");
+
+CREATE INDEX IF NOT EXISTS "coming_table_coming_id_idx" ON "coming_table" ("coming_id");
+
+CREATE INDEX IF NOT EXISTS "coming_table_branch_id_idx" ON "coming_table" ("branch_id");
+
+CREATE INDEX IF NOT EXISTS "coming_table_supplier_id_idx" ON "coming_table" ("supplier_id");
+
+CREATE INDEX IF NOT EXISTS "product_status_idx" ON "product" ("status");
+
+CREATE INDEX IF NOT EXISTS "product_category_id_idx" ON "product" ("category_id");
+
+CREATE INDEX IF NOT EXISTS "purchase_order_supplier_id_idx" ON "purchase_order" ("supplier_id");
+
+CREATE INDEX IF NOT EXISTS "purchase_order_branch_id_idx" ON "purchase_order" ("branch_id");
+
+CREATE INDEX IF NOT EXISTS "purchase_order_status_idx" ON "purchase_order" ("status");
+
+CREATE INDEX IF NOT EXISTS "purchase_order_product_purchase_order_id_idx" ON "purchase_order_product" ("purchase_order_id");
+
+CREATE INDEX IF NOT EXISTS "promotion_branch_id_idx" ON "promotion" ("branch_id");
+
+CREATE INDEX IF NOT EXISTS "revaluation_product_id_idx" ON "revaluation" ("product_id");
+
+CREATE INDEX IF NOT EXISTS "stock_movement_document_id_type_idx" ON "stock_movement" ("document_id", "type");
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS "coming_table_product_bump_version" ON "coming_table_product";
+
+DROP TRIGGER IF EXISTS "remaining_bump_version" ON "remaining";
+
+DROP FUNCTION IF EXISTS "bump_version"();
+
+ALTER TABLE "coming_table_product" DROP COLUMN IF EXISTS "version";
+
+ALTER TABLE "remaining" DROP COLUMN IF EXISTS "version";
@@ -0,0 +1,17 @@
+ALTER TABLE "remaining" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+
+ALTER TABLE "coming_table_product" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+
+-- every update of stock row moves its version, so stale If-Match of any writer is detected
+CREATE OR REPLACE FUNCTION "bump_version"() RETURNS trigger AS $$
+BEGIN
+  NEW."version" := OLD."version" + 1;
+  RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER "remaining_bump_version" BEFORE UPDATE ON "remaining"
+  FOR EACH ROW EXECUTE FUNCTION "bump_version"();
+
+CREATE TRIGGER "coming_table_product_bump_version" BEFORE UPDATE ON "coming_table_product"
+  FOR EACH ROW EXECUTE FUNCTION "bump_version"();
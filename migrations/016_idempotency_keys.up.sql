@@ -0,0 +1,13 @@
+CREATE TABLE "idempotency_key" (
+  "key" varchar PRIMARY KEY,
+  "method" varchar NOT NULL,
+  "path" varchar NOT NULL,
+  "fingerprint" varchar NOT NULL,
+  "status_code" int,
+  "headers" jsonb,
+  "response" bytea,
+  "created_at" timestamp NOT NULL DEFAULT (current_timestamp),
+  "expires_at" timestamp NOT NULL
+);
+
+CREATE INDEX "idempotency_key_expires_at_idx" ON "idempotency_key" ("expires_at");
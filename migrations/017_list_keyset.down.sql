@@ -0,0 +1,28 @@
+CREATE INDEX IF NOT EXISTS "revaluation_created_at_idx" ON "revaluation" ("created_at");
+
+DROP INDEX IF EXISTS "revaluation_created_at_id_idx";
+ALTER TABLE "revaluation" ALTER COLUMN "created_at" DROP NOT NULL;
+
+DROP INDEX IF EXISTS "promotion_created_at_id_idx";
+ALTER TABLE "promotion" ALTER COLUMN "created_at" DROP NOT NULL;
+
+DROP INDEX IF EXISTS "purchase_order_created_at_id_idx";
+ALTER TABLE "purchase_order" ALTER COLUMN "created_at" DROP NOT NULL;
+
+DROP INDEX IF EXISTS "coming_table_product_created_at_id_idx";
+ALTER TABLE "coming_table_product" ALTER COLUMN "created_at" DROP NOT NULL;
+
+DROP INDEX IF EXISTS "coming_table_created_at_id_idx";
+ALTER TABLE "coming_table" ALTER COLUMN "created_at" DROP NOT NULL;
+
+DROP INDEX IF EXISTS "product_created_at_id_idx";
+ALTER TABLE "product" ALTER COLUMN "created_at" DROP NOT NULL;
+
+DROP INDEX IF EXISTS "supplier_created_at_id_idx";
+ALTER TABLE "supplier" ALTER COLUMN "created_at" DROP NOT NULL;
+
+DROP INDEX IF EXISTS "category_created_at_id_idx";
+ALTER TABLE "category" ALTER COLUMN "created_at" DROP NOT NULL;
+
+DROP INDEX IF EXISTS "branch_created_at_id_idx";
+ALTER TABLE "branch" ALTER COLUMN "created_at" DROP NOT NULL;
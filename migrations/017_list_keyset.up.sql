@@ -0,0 +1,38 @@
+-- keyset pagination of lists compares (created_at, id), so created_at can not be NULL
+UPDATE "branch" SET "created_at" = COALESCE("updated_at", NOW()) WHERE "created_at" IS NULL;
+ALTER TABLE "branch" ALTER COLUMN "created_at" SET NOT NULL;
+CREATE INDEX IF NOT EXISTS "branch_created_at_id_idx" ON "branch" ("created_at", "id");
+
+UPDATE "category" SET "created_at" = COALESCE("updated_at", NOW()) WHERE "created_at" IS NULL;
+ALTER TABLE "category" ALTER COLUMN "created_at" SET NOT NULL;
+CREATE INDEX IF NOT EXISTS "category_created_at_id_idx" ON "category" ("created_at", "id");
+
+UPDATE "supplier" SET "created_at" = COALESCE("updated_at", NOW()) WHERE "created_at" IS NULL;
+ALTER TABLE "supplier" ALTER COLUMN "created_at" SET NOT NULL;
+CREATE INDEX IF NOT EXISTS "supplier_created_at_id_idx" ON "supplier" ("created_at", "id");
+
+UPDATE "product" SET "created_at" = COALESCE("updated_at", NOW()) WHERE "created_at" IS NULL;
+ALTER TABLE "product" ALTER COLUMN "created_at" SET NOT NULL;
+CREATE INDEX IF NOT EXISTS "product_created_at_id_idx" ON "product" ("created_at", "id");
+
+UPDATE "coming_table" SET "created_at" = COALESCE("updated_at", NOW()) WHERE "created_at" IS NULL;
+ALTER TABLE "coming_table" ALTER COLUMN "created_at" SET NOT NULL;
+CREATE INDEX IF NOT EXISTS "coming_table_created_at_id_idx" ON "coming_table" ("created_at", "id");
+
+UPDATE "coming_table_product" SET "created_at" = COALESCE("updated_at", NOW()) WHERE "created_at" IS NULL;
+ALTER TABLE "coming_table_product" ALTER COLUMN "created_at" SET NOT NULL;
+CREATE INDEX IF NOT EXISTS "coming_table_product_created_at_id_idx" ON "coming_table_product" ("created_at", "id");
+
+UPDATE "purchase_order" SET "created_at" = COALESCE("updated_at", NOW()) WHERE "created_at" IS NULL;
+ALTER TABLE "purchase_order" ALTER COLUMN "created_at" SET NOT NULL;
+CREATE INDEX IF NOT EXISTS "purchase_order_created_at_id_idx" ON "purchase_order" ("created_at", "id");
+
+UPDATE "promotion" SET "created_at" = COALESCE("updated_at", NOW()) WHERE "created_at" IS NULL;
+ALTER TABLE "promotion" ALTER COLUMN "created_at" SET NOT NULL;
+CREATE INDEX IF NOT EXISTS "promotion_created_at_id_idx" ON "promotion" ("created_at", "id");
+
+UPDATE "revaluation" SET "created_at" = NOW() WHERE "created_at" IS NULL;
+ALTER TABLE "revaluation" ALTER COLUMN "created_at" SET NOT NULL;
+CREATE INDEX IF NOT EXISTS "revaluation_created_at_id_idx" ON "revaluation" ("created_at", "id");
+
+DROP INDEX IF EXISTS "revaluation_created_at_idx";
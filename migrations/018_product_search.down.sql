@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS "product_barcode_pattern_idx";
+
+DROP INDEX IF EXISTS "product_search_vector_idx";
+
+DROP INDEX IF EXISTS "product_search_name_trgm_idx";
+
+ALTER TABLE "product" DROP COLUMN IF EXISTS "search_vector";
+
+ALTER TABLE "product" DROP COLUMN IF EXISTS "search_name";
+
+DROP FUNCTION IF EXISTS product_search_text(text);
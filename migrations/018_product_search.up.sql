@@ -0,0 +1,26 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- product_search_text lowercases text and spells Cyrillic in Uzbek Latin without apostrophes, hard and soft signs,
+-- so Cyrillic and Latin names are searched alike. It must match search.Latin of the service.
+-- Capital Cyrillic letters are lowered by translate because lower() keeps them in C locale
+CREATE OR REPLACE FUNCTION product_search_text(value text) RETURNS text AS $$
+  SELECT translate(
+    replace(replace(replace(replace(replace(replace(replace(
+      lower(translate(value,
+        'АБВГДЕЁЖЗИЙКЛМНОПРСТУФХЦЧШЩЪЫЬЭЮЯЎҚҒҲ',
+        'абвгдеёжзийклмнопрстуфхцчшщъыьэюяўқғҳ')),
+      'щ', 'sh'), 'ш', 'sh'), 'ч', 'ch'), 'ё', 'yo'), 'ю', 'yu'), 'я', 'ya'), 'ц', 'ts'),
+    'абвгдежзийклмнопрстуфхыэўқғҳъь''`ʻʼ‘’',
+    'abvgdejziyklmnoprstufxieoqgh'
+  )
+$$ LANGUAGE sql IMMUTABLE PARALLEL SAFE;
+
+ALTER TABLE "product" ADD COLUMN "search_name" text GENERATED ALWAYS AS (product_search_text("name")) STORED;
+
+ALTER TABLE "product" ADD COLUMN "search_vector" tsvector GENERATED ALWAYS AS (to_tsvector('simple', product_search_text("name"))) STORED;
+
+CREATE INDEX IF NOT EXISTS "product_search_name_trgm_idx" ON "product" USING GIN ("search_name" gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS "product_search_vector_idx" ON "product" USING GIN ("search_vector");
+
+CREATE INDEX IF NOT EXISTS "product_barcode_pattern_idx" ON "product" ("barcode" varchar_pattern_ops);
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS "product_image";
+
+DROP INDEX IF EXISTS "product_variant_attributes_key";
+
+DROP INDEX IF EXISTS "product_parent_id_idx";
+
+ALTER TABLE "product" DROP COLUMN IF EXISTS "attributes";
+
+ALTER TABLE "product" DROP COLUMN IF EXISTS "parent_id";
+
+DROP TABLE IF EXISTS "category_attribute";
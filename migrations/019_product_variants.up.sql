@@ -0,0 +1,32 @@
+CREATE TABLE "category_attribute" (
+  "id" uuid PRIMARY KEY,
+  "category_id" uuid NOT NULL REFERENCES "category" ("id") ON DELETE CASCADE,
+  "name" varchar NOT NULL,
+  "values" varchar[] NOT NULL DEFAULT '{}',
+  "required" boolean NOT NULL DEFAULT false,
+  "position" int NOT NULL DEFAULT 0,
+  "created_at" timestamp NOT NULL DEFAULT (current_timestamp),
+  "updated_at" timestamp,
+  UNIQUE ("category_id", "name")
+);
+
+-- variant is a product with its own barcode and price under parent product, attributes tell variants apart
+ALTER TABLE "product" ADD COLUMN "parent_id" uuid REFERENCES "product" ("id");
+
+ALTER TABLE "product" ADD COLUMN "attributes" jsonb NOT NULL DEFAULT '{}';
+
+CREATE INDEX "product_parent_id_idx" ON "product" ("parent_id");
+
+CREATE UNIQUE INDEX "product_variant_attributes_key" ON "product" ("parent_id", "attributes") WHERE "parent_id" IS NOT NULL;
+
+CREATE TABLE "product_image" (
+  "id" uuid PRIMARY KEY,
+  "product_id" uuid NOT NULL REFERENCES "product" ("id") ON DELETE CASCADE,
+  "key" varchar NOT NULL,
+  "content_type" varchar NOT NULL,
+  "size" bigint NOT NULL,
+  "position" int NOT NULL DEFAULT 0,
+  "created_at" timestamp NOT NULL DEFAULT (current_timestamp)
+);
+
+CREATE INDEX "product_image_product_id_idx" ON "product_image" ("product_id", "position");
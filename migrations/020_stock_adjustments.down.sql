@@ -0,0 +1,10 @@
+-- enum values can not be dropped, the type is recreated without adjustment
+DELETE FROM "stock_movement" WHERE "type" = 'adjustment';
+
+ALTER TYPE stock_movement_type RENAME TO stock_movement_type_old;
+
+CREATE TYPE stock_movement_type AS ENUM ('income', 'income_reversal');
+
+ALTER TABLE "stock_movement" ALTER COLUMN "type" TYPE stock_movement_type USING "type"::text::stock_movement_type;
+
+DROP TYPE stock_movement_type_old;
@@ -0,0 +1,2 @@
+-- remaining changed by hand gets adjustment movement, so stock rebuilt from movements agrees with it
+ALTER TYPE stock_movement_type ADD VALUE IF NOT EXISTS 'adjustment';
@@ -0,0 +1,10 @@
+-- enum values can not be dropped, the type is recreated without revaluation
+DELETE FROM "stock_movement" WHERE "type" = 'revaluation';
+
+ALTER TYPE stock_movement_type RENAME TO stock_movement_type_old;
+
+CREATE TYPE stock_movement_type AS ENUM ('income', 'income_reversal', 'adjustment');
+
+ALTER TABLE "stock_movement" ALTER COLUMN "type" TYPE stock_movement_type USING "type"::text::stock_movement_type;
+
+DROP TYPE stock_movement_type_old;
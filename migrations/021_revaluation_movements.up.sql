@@ -0,0 +1,2 @@
+-- repricing of remaining gets value-only movement, so stock value rebuilt from movements follows new prices
+ALTER TYPE stock_movement_type ADD VALUE IF NOT EXISTS 'revaluation';
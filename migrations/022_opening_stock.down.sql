@@ -0,0 +1,18 @@
+-- opening adjustments are the only movements without document
+UPDATE "stock_snapshot" AS s
+SET
+  "count" = s."count" - o."count",
+  "total_price" = COALESCE(s."total_price", 0) - o."total_price"
+FROM (
+  SELECT
+    sn."id",
+    SUM(m."count") AS "count",
+    SUM(m."total_price") AS "total_price"
+  FROM "stock_snapshot" AS sn
+  JOIN "stock_movement" AS m ON m."branch_id" = sn."branch_id" AND m."barcode" = sn."barcode" AND m."date_time" < sn."period_end"
+  WHERE m."type" = 'adjustment' AND m."document_id" IS NULL
+  GROUP BY sn."id"
+) AS o
+WHERE s."id" = o."id";
+
+DELETE FROM "stock_movement" WHERE "type" = 'adjustment' AND "document_id" IS NULL;
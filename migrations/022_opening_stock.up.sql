@@ -0,0 +1,75 @@
+-- remaining which is not covered by movements was there before movements existed, it gets opening adjustment
+-- without document, so stock rebuilt from movements and snapshots includes it
+INSERT INTO "stock_movement"(
+  "id",
+  "branch_id",
+  "category_id",
+  "name",
+  "price",
+  "barcode",
+  "count",
+  "total_price",
+  "product_id",
+  "type",
+  "document_id",
+  "date_time",
+  "created_at")
+SELECT
+  gen_random_uuid(),
+  r."branch_id",
+  r."category_id",
+  r."name",
+  r."price",
+  r."barcode",
+  r."count" - COALESCE(m."count", 0),
+  COALESCE(r."total_price", 0) - COALESCE(m."total_price", 0),
+  r."product_id",
+  'adjustment',
+  NULL,
+  COALESCE(r."created_at", NOW()),
+  NOW()
+FROM "remaining" AS r
+LEFT JOIN (
+  SELECT
+    "branch_id",
+    "barcode",
+    SUM("count") AS "count",
+    SUM(COALESCE("total_price", 0)) AS "total_price"
+  FROM "stock_movement"
+  GROUP BY "branch_id", "barcode"
+) AS m ON m."branch_id" = r."branch_id" AND m."barcode" = r."barcode"
+WHERE r."branch_id" IS NOT NULL
+  AND (r."count" <> COALESCE(m."count", 0) OR COALESCE(r."total_price", 0) <> COALESCE(m."total_price", 0));
+
+-- snapshots taken already are missing the opening stock too
+INSERT INTO "stock_snapshot"(
+  "id",
+  "branch_id",
+  "category_id",
+  "name",
+  "price",
+  "barcode",
+  "count",
+  "total_price",
+  "period_end",
+  "created_at")
+SELECT
+  gen_random_uuid(),
+  m."branch_id",
+  m."category_id",
+  m."name",
+  m."price",
+  m."barcode",
+  m."count",
+  m."total_price",
+  s."period_end",
+  NOW()
+FROM "stock_movement" AS m
+JOIN (
+  SELECT DISTINCT "branch_id", "period_end" FROM "stock_snapshot"
+) AS s ON s."branch_id" = m."branch_id" AND s."period_end" > m."date_time"
+WHERE m."type" = 'adjustment' AND m."document_id" IS NULL
+ON CONFLICT ("period_end", "branch_id", "barcode") DO UPDATE
+SET
+  "count" = "stock_snapshot"."count" + EXCLUDED."count",
+  "total_price" = COALESCE("stock_snapshot"."total_price", 0) + EXCLUDED."total_price";
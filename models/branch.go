@@ -30,9 +30,11 @@ type BranchGetListRequest struct {
 	Page   int    `json:"page"`
 	Limit  int    `json:"limit"`
 	Search string `json:"search"`
+	ListOptions
 }
 
 type BranchGetListResponse struct {
-	Count    int       `json:"count"`
-	Branches []*Branch `json:"branches"`
+	Count      int       `json:"count"`
+	Branches   []*Branch `json:"branches"`
+	NextCursor string    `json:"next_cursor,omitempty"`
 }
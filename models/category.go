@@ -27,9 +27,11 @@ type CategoryGetListRequest struct {
 	Page   int    `json:"page"`
 	Limit  int    `json:"limit"`
 	Search string `json:"search"`
+	ListOptions
 }
 
 type CategoryGetListResponse struct {
 	Count      int         `json:"count"`
 	Categories []*Category `json:"categories"`
+	NextCursor string      `json:"next_cursor,omitempty"`
 }
@@ -0,0 +1,42 @@
+package models
+
+type CategoryAttributePrimaryKey struct {
+	Id string `json:"id"`
+}
+
+// CreateCategoryAttribute defines attribute products of the category have, like size or color.
+// Empty values allow any value, required attribute must be given to every product of the category
+type CreateCategoryAttribute struct {
+	CategoryId string   `json:"category_id"`
+	Name       string   `json:"name"`
+	Values     []string `json:"values"`
+	Required   bool     `json:"required"`
+	Position   int      `json:"position"`
+}
+
+type CategoryAttribute struct {
+	Id         string   `json:"id"`
+	CategoryId string   `json:"category_id"`
+	Name       string   `json:"name"`
+	Values     []string `json:"values"`
+	Required   bool     `json:"required"`
+	Position   int      `json:"position"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+}
+
+type UpdateCategoryAttribute struct {
+	Id       string   `json:"id"`
+	Name     string   `json:"name"`
+	Values   []string `json:"values"`
+	Required bool     `json:"required"`
+	Position int      `json:"position"`
+}
+
+type CategoryAttributeGetListRequest struct {
+	CategoryId string `json:"category_id"`
+}
+
+type CategoryAttributeGetListResponse struct {
+	Attributes []*CategoryAttribute `json:"attributes"`
+}
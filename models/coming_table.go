@@ -5,19 +5,22 @@ type ComingTablePrimaryKey struct {
 }
 
 type CreateComingTable struct {
-	ComingId string `json:"coming_id"`
-	BranchId string `json:"branch_id"`
-	DateTime string `json:"date_time"`
+	ComingId   string `json:"coming_id"`
+	BranchId   string `json:"branch_id"`
+	SupplierId string `json:"supplier_id"`
+	DateTime   string `json:"date_time"`
 }
 
 type ComingTable struct {
-	Id        string `json:"id"`
-	ComingId  string `json:"coming_id"`
-	BranchId  string `json:"branch_id"`
-	DateTime  string `json:"date_time"`
-	Status    string `json:"status"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	Id              string `json:"id"`
+	ComingId        string `json:"coming_id"`
+	BranchId        string `json:"branch_id"`
+	SupplierId      string `json:"supplier_id"`
+	PurchaseOrderId string `json:"purchase_order_id"`
+	DateTime        string `json:"date_time"`
+	Status          string `json:"status"`
+	CreatedAt       string `json:"created_at"`
+	UpdatedAt       string `json:"updated_at"`
 }
 
 type ComingIdResponse struct {
@@ -25,20 +28,85 @@ type ComingIdResponse struct {
 }
 
 type UpdateComingTable struct {
-	Id       string `json:"id"`
-	ComingId string `json:"coming_id"`
-	BranchId string `json:"branch_id"`
-	DateTime string `json:"date_time"`
+	Id         string `json:"id"`
+	ComingId   string `json:"coming_id"`
+	BranchId   string `json:"branch_id"`
+	SupplierId string `json:"supplier_id"`
+	DateTime   string `json:"date_time"`
 }
 
 type ComingTableGetListRequest struct {
-	Page     int    `json:"page"`
-	Limit    int    `json:"limit"`
-	BranchId string `json:"branch_id"`
-	ComingId string `json:"coming_id"`
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	BranchId   string `json:"branch_id"`
+	SupplierId string `json:"supplier_id"`
+	ComingId   string `json:"coming_id"`
+	ListOptions
 }
 
 type ComingTableGetListResponse struct {
 	Count        int            `json:"count"`
 	ComingTables []*ComingTable `json:"products"`
+	NextCursor   string         `json:"next_cursor,omitempty"`
+}
+
+const (
+	ComingTableDraft            = "draft"
+	ComingTableReceiving        = "receiving"
+	ComingTableAwaitingApproval = "awaiting_approval"
+	ComingTablePosted           = "posted"
+	ComingTableCancelled        = "cancelled"
+	ComingTableReversed         = "reversed"
+)
+
+// ComingTableTransitions lists statuses a coming_table is allowed to move to from each status
+var ComingTableTransitions = map[string][]string{
+	ComingTableDraft:            {ComingTableReceiving, ComingTableCancelled},
+	ComingTableReceiving:        {ComingTableAwaitingApproval, ComingTablePosted, ComingTableCancelled},
+	ComingTableAwaitingApproval: {ComingTableReceiving, ComingTablePosted, ComingTableCancelled},
+	ComingTablePosted:           {ComingTableReversed},
+}
+
+// ComingTableEditableStatuses are statuses in which header and products of coming_table may change
+var ComingTableEditableStatuses = []string{ComingTableDraft, ComingTableReceiving}
+
+// ComingTableSources returns statuses from which coming_table may move to status
+func ComingTableSources(status string) []string {
+	var sources []string
+	for from, targets := range ComingTableTransitions {
+		for _, to := range targets {
+			if to == status {
+				sources = append(sources, from)
+			}
+		}
+	}
+	return sources
+}
+
+type UpdateComingTableStatus struct {
+	Id     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type ComingTableFullProduct struct {
+	*ComingTableProduct
+	CategoryName string `json:"category_name"`
+}
+
+type ComingTableCategoryTotal struct {
+	CategoryId   string  `json:"category_id"`
+	CategoryName string  `json:"category_name"`
+	Count        int     `json:"count"`
+	TotalPrice   float64 `json:"total_price"`
+}
+
+// ComingTableFull is coming_table with its branch, supplier, products and totals
+type ComingTableFull struct {
+	ComingTable *ComingTable                `json:"coming_table"`
+	Branch      *Branch                     `json:"branch"`
+	Supplier    *Supplier                   `json:"supplier"`
+	Products    []*ComingTableFullProduct   `json:"products"`
+	Categories  []*ComingTableCategoryTotal `json:"categories"`
+	Count       int                         `json:"count"`
+	TotalPrice  float64                     `json:"total_price"`
 }
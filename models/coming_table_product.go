@@ -4,13 +4,12 @@ type ComingTableProductPrimaryKey struct {
 	Id string `json:"id"`
 }
 
-type ComingTableProductBarcode struct {
-	Barcode       string `json:"barcode"`
-	ComingTableId string `json:"coming_table_id"`
-}
-
 type CreateComingTableProductCount struct {
 	Count int `json:"count"`
+	// name, price and category_id create pending product when barcode is unknown
+	Name       string  `json:"name"`
+	Price      float64 `json:"price"`
+	CategoryId string  `json:"category_id"`
 }
 
 type CreateComingTableProduct struct {
@@ -25,6 +24,7 @@ type CreateComingTableProduct struct {
 
 type ComingTableProduct struct {
 	Id             string  `json:"id"`
+	ProductId      string  `json:"product_id"`
 	CategoryId     string  `json:"category_id"`
 	ProductName    string  `json:"name"`
 	ProductPrice   float64 `json:"price"`
@@ -32,6 +32,7 @@ type ComingTableProduct struct {
 	Count          int     `json:"count"`
 	TotalPrice     float64 `json:"total_price"`
 	ComingTableId  string  `json:"coming_table_id"`
+	Version        int     `json:"version"`
 	CreatedAt      string  `json:"created_at"`
 	UpdatedAt      string  `json:"updated_at"`
 }
@@ -45,16 +46,60 @@ type UpdateComingTableProduct struct {
 	Count          int     `json:"count"`
 	TotalPrice     float64 `json:"total_price"`
 	ComingTableId  string  `json:"coming_table_id"`
+	// Version is expected version of If-Match, 0 skips the check, after update it is the new version
+	Version int `json:"-"`
 }
 
 type ComingTableProductGetListRequest struct {
 	Page           int    `json:"page"`
 	Limit          int    `json:"limit"`
+	ComingTableId  string `json:"coming_table_id"`
 	CategoryId     string `json:"category_id"`
 	ProductBarcode string `json:"barcode"`
+	ListOptions
 }
 
 type ComingTableProductGetListResponse struct {
 	Count               int                   `json:"count"`
 	ComingTableProducts []*ComingTableProduct `json:"products"`
+	NextCursor          string                `json:"next_cursor,omitempty"`
+}
+
+// ComingTableProductScan is one scanned barcode, price overrides the product price when given
+type ComingTableProductScan struct {
+	Barcode string  `json:"barcode"`
+	Count   int     `json:"count"`
+	Price   float64 `json:"price"`
+}
+
+type CreateComingTableProductBulk struct {
+	ComingTableId string                    `json:"coming_table_id"`
+	Products      []*ComingTableProductScan `json:"products"`
+}
+
+const (
+	ScanAdded   = "added"
+	ScanUpdated = "updated"
+	ScanUnknown = "unknown"
+)
+
+// ComingTableProductScanResult tells what happened with one barcode of bulk scan
+type ComingTableProductScanResult struct {
+	Id         string  `json:"id"`
+	Barcode    string  `json:"barcode"`
+	Name       string  `json:"name"`
+	Count      int     `json:"count"`
+	TotalPrice float64 `json:"total_price"`
+	Result     string  `json:"result"`
+}
+
+type ComingTableProductBulkResponse struct {
+	Products []*ComingTableProductScanResult `json:"products"`
+	Unknown  int                             `json:"unknown"`
+}
+
+// ComingTableProductImportResponse is result of delivery note import with rows that could not be read
+type ComingTableProductImportResponse struct {
+	*ComingTableProductBulkResponse
+	Errors []*ImportRowError `json:"errors"`
 }
@@ -0,0 +1,23 @@
+package models
+
+type ConsistencyRequest struct {
+	// Limit is max number of sample rows of every check
+	Limit int `json:"limit"`
+}
+
+// ConsistencyIssue is row whose copy of product attribute differs from product it belongs to
+type ConsistencyIssue struct {
+	Check     string `json:"check"`
+	Table     string `json:"table"`
+	Id        string `json:"id"`
+	ProductId string `json:"product_id"`
+	Barcode   string `json:"barcode"`
+	Stored    string `json:"stored"`
+	Expected  string `json:"expected"`
+}
+
+// ConsistencyReport has number of drifted rows of every table and check, like "remaining.name", and their samples
+type ConsistencyReport struct {
+	Counts map[string]int      `json:"counts"`
+	Issues []*ConsistencyIssue `json:"issues"`
+}
@@ -0,0 +1,9 @@
+package models
+
+// ExportNames are names of rows referenced by exported list row, so exported files are readable without ids
+type ExportNames struct {
+	BranchName   string
+	CategoryName string
+	SupplierName string
+	ComingId     string
+}
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"net/http"
+	"time"
+)
+
+// IdempotencyKey is mutating request identified by Idempotency-Key header and its stored response,
+// StatusCode is 0 while the first request is still being handled
+type IdempotencyKey struct {
+	Key         string      `json:"key"`
+	Method      string      `json:"method"`
+	Path        string      `json:"path"`
+	Fingerprint string      `json:"fingerprint"`
+	StatusCode  int         `json:"status_code"`
+	Headers     http.Header `json:"headers"`
+	Response    []byte      `json:"response"`
+	ExpiresAt   time.Time   `json:"expires_at"`
+}
@@ -0,0 +1,30 @@
+package models
+
+// ImportRowError describes why row of imported file was skipped, row is counted from 1 including header
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Barcode string `json:"barcode"`
+	Error   string `json:"error"`
+}
+
+type ProductImportRow struct {
+	Row          int      `json:"row"`
+	Name         string   `json:"name"`
+	Barcode      string   `json:"barcode"`
+	Price        float64  `json:"price"`
+	CategoryPath []string `json:"category_path"`
+}
+
+type ProductImportRequest struct {
+	Rows   []*ProductImportRow `json:"rows"`
+	DryRun bool                `json:"dry_run"`
+}
+
+type ProductImportResponse struct {
+	DryRun            bool              `json:"dry_run"`
+	Total             int               `json:"total"`
+	Created           int               `json:"created"`
+	Updated           int               `json:"updated"`
+	CategoriesCreated int               `json:"categories_created"`
+	Errors            []*ImportRowError `json:"errors"`
+}
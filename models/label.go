@@ -0,0 +1,54 @@
+package models
+
+const (
+	BarcodeAuto    = "auto"
+	BarcodeEAN13   = "ean13"
+	BarcodeCode128 = "code128"
+
+	LabelFormatPDF = "pdf"
+	LabelFormatZPL = "zpl"
+)
+
+// LabelTemplate is size of shelf labels of branch in millimeters, columns is number of labels in a row of A4 sheet
+// and dpi is resolution of thermal printer used for zpl
+type LabelTemplate struct {
+	BranchId    string  `json:"branch_id"`
+	Width       float64 `json:"width"`
+	Height      float64 `json:"height"`
+	Columns     int     `json:"columns"`
+	FontSize    float64 `json:"font_size"`
+	BarcodeType string  `json:"barcode_type"`
+	Dpi         int     `json:"dpi"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+// DefaultLabelTemplate is used for branches without saved template
+func DefaultLabelTemplate(branchId string) *LabelTemplate {
+	return &LabelTemplate{
+		BranchId:    branchId,
+		Width:       58,
+		Height:      40,
+		Columns:     3,
+		FontSize:    9,
+		BarcodeType: BarcodeAuto,
+		Dpi:         203,
+	}
+}
+
+// LabelRequest prints labels of products or of all lines of coming_table,
+// without copies coming_table lines get one label per received item and products one label each
+type LabelRequest struct {
+	ProductIds    []string `json:"product_ids"`
+	ComingTableId string   `json:"coming_table_id"`
+	BranchId      string   `json:"branch_id"`
+	Format        string   `json:"format"`
+	Copies        int      `json:"copies"`
+}
+
+type LabelItem struct {
+	Name    string  `json:"name"`
+	Barcode string  `json:"barcode"`
+	Price   float64 `json:"price"`
+	Copies  int     `json:"copies"`
+}
@@ -0,0 +1,40 @@
+package models
+
+import "time"
+
+// MaxListLimit is the largest page size list endpoints return
+const MaxListLimit = 500
+
+// ListOptions are common options of paginated lists.
+// Cursor is next_cursor of previous page, when it is set page is ignored and rows after the cursor are returned.
+// Sort is a column of the entity, "-" prefix sorts descending, empty means default sort of the entity.
+// WithCount adds total count of filtered rows, it costs a full scan so cursor clients usually skip it.
+// Filters and DateFrom, DateTo narrow the list, the dates apply to created_at or date_time of documents
+type ListOptions struct {
+	Cursor    string       `json:"cursor"`
+	Sort      string       `json:"sort"`
+	WithCount bool         `json:"with_count"`
+	Filters   []ListFilter `json:"filters"`
+	DateFrom  time.Time    `json:"date_from"`
+	DateTo    time.Time    `json:"date_to"`
+}
+
+const (
+	FilterEq   = "eq"
+	FilterNe   = "ne"
+	FilterGt   = "gt"
+	FilterGte  = "gte"
+	FilterLt   = "lt"
+	FilterLte  = "lte"
+	FilterIn   = "in"
+	FilterNull = "null"
+	FilterLike = "like"
+)
+
+// ListFilter is a condition on field of listed entity given as field[op]=value query param,
+// value of in is comma separated list and value of null is true or false
+type ListFilter struct {
+	Field string `json:"field"`
+	Op    string `json:"op"`
+	Value string `json:"value"`
+}
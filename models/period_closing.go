@@ -0,0 +1,44 @@
+package models
+
+const (
+	PeriodClose  = "close"
+	PeriodReopen = "reopen"
+)
+
+// PeriodClosingRequest closes or reopens month of branch, month is like 2023-09
+type PeriodClosingRequest struct {
+	BranchId string `json:"branch_id"`
+	Month    string `json:"month"`
+	User     string `json:"user"`
+	Reason   string `json:"reason"`
+}
+
+type PeriodClosing struct {
+	BranchId string `json:"branch_id"`
+	Month    string `json:"month"`
+	ClosedBy string `json:"closed_by"`
+	ClosedAt string `json:"closed_at"`
+}
+
+type PeriodClosingGetListRequest struct {
+	BranchId string `json:"branch_id"`
+}
+
+type PeriodClosingGetListResponse struct {
+	Periods []*PeriodClosing `json:"periods"`
+}
+
+// PeriodClosingLog is one close or reopen of a month kept for audit
+type PeriodClosingLog struct {
+	Id        string `json:"id"`
+	BranchId  string `json:"branch_id"`
+	Month     string `json:"month"`
+	Action    string `json:"action"`
+	User      string `json:"user"`
+	Reason    string `json:"reason"`
+	CreatedAt string `json:"created_at"`
+}
+
+type PeriodClosingLogResponse struct {
+	Logs []*PeriodClosingLog `json:"logs"`
+}
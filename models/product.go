@@ -14,29 +14,49 @@ type ProductBarcodeResponse struct {
 	CategoryId string  `json:"category_id"`
 }
 
+// CreateProduct creates product, attributes are values of attributes defined for its category
 type CreateProduct struct {
-	Name       string  `json:"name"`
-	Price      float64 `json:"price"`
-	Barcode    string  `json:"barcode"`
-	CategoryId string  `json:"category_id"`
+	Name       string            `json:"name"`
+	Price      float64           `json:"price"`
+	Barcode    string            `json:"barcode"`
+	CategoryId string            `json:"category_id"`
+	Attributes map[string]string `json:"attributes"`
 }
 
+// Product is sellable item, variant has parent_id of product it is size or color of.
+// Variants and images are given only by GetByID
 type Product struct {
-	Id         string  `json:"id"`
-	Name       string  `json:"name"`
-	Price      float64 `json:"price"`
-	Barcode    string  `json:"barcode"`
-	CategoryId string  `json:"category_id"`
-	CreatedAt  string  `json:"created_at"`
-	UpdatedAt  string  `json:"updated_at"`
+	Id         string            `json:"id"`
+	ParentId   string            `json:"parent_id,omitempty"`
+	Name       string            `json:"name"`
+	Price      float64           `json:"price"`
+	Barcode    string            `json:"barcode"`
+	CategoryId string            `json:"category_id"`
+	Attributes map[string]string `json:"attributes"`
+	Status     string            `json:"status"`
+	CreatedAt  string            `json:"created_at"`
+	UpdatedAt  string            `json:"updated_at"`
+	Variants   []*Product        `json:"variants,omitempty"`
+	Images     []*ProductImage   `json:"images,omitempty"`
 }
 
 type UpdateProduct struct {
-	Id         string  `json:"id"`
-	Name       string  `json:"name"`
-	Price      float64 `json:"price"`
-	Barcode    string  `json:"barcode"`
-	CategoryId string  `json:"category_id"`
+	Id         string            `json:"id"`
+	Name       string            `json:"name"`
+	Price      float64           `json:"price"`
+	Barcode    string            `json:"barcode"`
+	CategoryId string            `json:"category_id"`
+	Attributes map[string]string `json:"attributes"`
+}
+
+// CreateProductVariant adds variant with its own barcode to parent product, it shares category of the parent.
+// Empty name is name of parent with attribute values and zero price is price of parent
+type CreateProductVariant struct {
+	ParentId   string            `json:"parent_id"`
+	Name       string            `json:"name"`
+	Price      float64           `json:"price"`
+	Barcode    string            `json:"barcode"`
+	Attributes map[string]string `json:"attributes"`
 }
 
 type ProductGetListRequest struct {
@@ -44,9 +64,47 @@ type ProductGetListRequest struct {
 	Limit   int    `json:"limit"`
 	Name    string `json:"name"`
 	Barcode string `json:"barcode"`
+	Status  string `json:"status"`
+	ListOptions
 }
 
 type ProductGetListResponse struct {
-	Count    int        `json:"count"`
-	Products []*Product `json:"products"`
+	Count      int        `json:"count"`
+	Products   []*Product `json:"products"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+}
+
+const (
+	ProductActive        = "active"
+	ProductPendingReview = "pending_review"
+)
+
+// MergeProduct moves products, remaining and movements of pending product to target product
+type MergeProduct struct {
+	Id       string `json:"id"`
+	TargetId string `json:"target_id"`
+}
+
+// MaxProductSearchLimit is the largest number of products search returns
+const MaxProductSearchLimit = 50
+
+// ProductSearchRequest searches products by words of name, barcode prefix and category name,
+// Cyrillic and Latin spellings and small typos match the same products
+type ProductSearchRequest struct {
+	Query      string `json:"query"`
+	Limit      int    `json:"limit"`
+	CategoryId string `json:"category_id"`
+	Status     string `json:"status"`
+}
+
+// ProductSearchResult is found product with its relevance, Highlight is its HTML escaped name with matched words in <mark>
+type ProductSearchResult struct {
+	Product
+	CategoryName string  `json:"category_name"`
+	Rank         float64 `json:"rank"`
+	Highlight    string  `json:"highlight"`
+}
+
+type ProductSearchResponse struct {
+	Products []*ProductSearchResult `json:"products"`
 }
@@ -0,0 +1,36 @@
+package models
+
+const (
+	// MaxProductImages is the largest number of images of one product
+	MaxProductImages = 20
+	// MaxProductImageSize is the largest accepted image file in bytes
+	MaxProductImageSize = 5 << 20
+)
+
+type ProductImagePrimaryKey struct {
+	Id string `json:"id"`
+}
+
+// CreateProductImage records file uploaded to file store under Key
+type CreateProductImage struct {
+	ProductId   string `json:"product_id"`
+	Key         string `json:"key"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+}
+
+// ProductImage is image of product, Url is where it is downloaded from
+type ProductImage struct {
+	Id          string `json:"id"`
+	ProductId   string `json:"product_id"`
+	Key         string `json:"-"`
+	Url         string `json:"url"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	Position    int    `json:"position"`
+	CreatedAt   string `json:"created_at"`
+}
+
+type ProductImageGetListResponse struct {
+	Images []*ProductImage `json:"images"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+const (
+	PriceSourceBranch  = "branch"
+	PriceSourceBase    = "base"
+	PriceSourceProduct = "product"
+)
+
+// CreateProductPrice schedules new price of product, empty branch_id changes base price of all branches
+// and empty effective_from means now
+type CreateProductPrice struct {
+	ProductId     string  `json:"product_id"`
+	BranchId      string  `json:"branch_id"`
+	Price         float64 `json:"price"`
+	EffectiveFrom string  `json:"effective_from"`
+	CreatedBy     string  `json:"created_by"`
+}
+
+type ProductPricePrimaryKey struct {
+	Id string `json:"id"`
+}
+
+type ProductPrice struct {
+	Id            string  `json:"id"`
+	ProductId     string  `json:"product_id"`
+	BranchId      string  `json:"branch_id"`
+	Price         float64 `json:"price"`
+	EffectiveFrom string  `json:"effective_from"`
+	AppliedAt     string  `json:"applied_at"`
+	CreatedBy     string  `json:"created_by"`
+	CreatedAt     string  `json:"created_at"`
+}
+
+type ProductPriceGetListRequest struct {
+	ProductId string `json:"product_id"`
+	BranchId  string `json:"branch_id"`
+}
+
+type ProductPriceGetListResponse struct {
+	Prices []*ProductPrice `json:"prices"`
+}
+
+// EffectivePriceRequest asks price of barcode at branch and moment, zero At means now
+type EffectivePriceRequest struct {
+	Barcode  string
+	BranchId string
+	At       time.Time
+}
+
+// EffectivePrice is price override of the branch when there is one, otherwise base price
+type EffectivePrice struct {
+	ProductId     string  `json:"product_id"`
+	Barcode       string  `json:"barcode"`
+	Name          string  `json:"name"`
+	BranchId      string  `json:"branch_id"`
+	Price         float64 `json:"price"`
+	EffectiveFrom string  `json:"effective_from"`
+	Source        string  `json:"source"`
+}
@@ -0,0 +1,135 @@
+package models
+
+const (
+	// PromotionPercent takes Percent off every matching unit
+	PromotionPercent = "percent"
+	// PromotionBuyGet gives FreeCount units free for every BuyCount paid units of the same product
+	PromotionBuyGet = "buy_get"
+	// PromotionBundlePrice sells every BundleCount matching units for BundlePrice
+	PromotionBundlePrice = "bundle_price"
+)
+
+type PromotionPrimaryKey struct {
+	Id string `json:"id"`
+}
+
+// CreatePromotion is a discount rule, empty product_ids and category_ids match every product,
+// category matches its subcategories too, empty branch_id matches every branch and empty ends_at never ends
+type CreatePromotion struct {
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Percent     float64  `json:"percent"`
+	BuyCount    int      `json:"buy_count"`
+	FreeCount   int      `json:"free_count"`
+	BundleCount int      `json:"bundle_count"`
+	BundlePrice float64  `json:"bundle_price"`
+	ProductIds  []string `json:"product_ids"`
+	CategoryIds []string `json:"category_ids"`
+	BranchId    string   `json:"branch_id"`
+	StartsAt    string   `json:"starts_at"`
+	EndsAt      string   `json:"ends_at"`
+	Priority    int      `json:"priority"`
+	Active      bool     `json:"active"`
+}
+
+type Promotion struct {
+	Id          string   `json:"id"`
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Percent     float64  `json:"percent"`
+	BuyCount    int      `json:"buy_count"`
+	FreeCount   int      `json:"free_count"`
+	BundleCount int      `json:"bundle_count"`
+	BundlePrice float64  `json:"bundle_price"`
+	ProductIds  []string `json:"product_ids"`
+	CategoryIds []string `json:"category_ids"`
+	BranchId    string   `json:"branch_id"`
+	StartsAt    string   `json:"starts_at"`
+	EndsAt      string   `json:"ends_at"`
+	Priority    int      `json:"priority"`
+	Active      bool     `json:"active"`
+	CreatedAt   string   `json:"created_at"`
+	UpdatedAt   string   `json:"updated_at"`
+}
+
+type UpdatePromotion struct {
+	Id string `json:"id"`
+	CreatePromotion
+}
+
+type PromotionGetListRequest struct {
+	Page     int    `json:"page"`
+	Limit    int    `json:"limit"`
+	Search   string `json:"search"`
+	BranchId string `json:"branch_id"`
+	ListOptions
+}
+
+type PromotionGetListResponse struct {
+	Count      int          `json:"count"`
+	Promotions []*Promotion `json:"promotions"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+// ActivePromotionRequest selects promotions running in branch at the moment, empty At means now
+type ActivePromotionRequest struct {
+	BranchId string `json:"branch_id"`
+	At       string `json:"at"`
+}
+
+type BasketLineRequest struct {
+	Barcode string `json:"barcode"`
+	Count   int    `json:"count"`
+}
+
+// BasketRequest is evaluated with prices and promotions of the branch at the moment,
+// with commit the granted discounts are saved for promotion reports under document_id
+type BasketRequest struct {
+	BranchId   string               `json:"branch_id"`
+	At         string               `json:"at"`
+	DocumentId string               `json:"document_id"`
+	Commit     bool                 `json:"commit"`
+	Lines      []*BasketLineRequest `json:"lines"`
+}
+
+type AppliedPromotion struct {
+	PromotionId string  `json:"promotion_id"`
+	Name        string  `json:"name"`
+	Count       int     `json:"count"`
+	Discount    float64 `json:"discount"`
+}
+
+type BasketLine struct {
+	ProductId   string              `json:"product_id"`
+	Barcode     string              `json:"barcode"`
+	Name        string              `json:"name"`
+	CategoryIds []string            `json:"-"`
+	Price       float64             `json:"price"`
+	Count       int                 `json:"count"`
+	Total       float64             `json:"total"`
+	Discount    float64             `json:"discount"`
+	ToPay       float64             `json:"to_pay"`
+	Promotions  []*AppliedPromotion `json:"promotions"`
+}
+
+type BasketResponse struct {
+	Lines    []*BasketLine `json:"lines"`
+	Total    float64       `json:"total"`
+	Discount float64       `json:"discount"`
+	ToPay    float64       `json:"to_pay"`
+}
+
+// PromotionReportRow is discount granted by promotion in the date range
+type PromotionReportRow struct {
+	PromotionId string  `json:"promotion_id"`
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+	Documents   int     `json:"documents"`
+	Count       int     `json:"count"`
+	Discount    float64 `json:"discount"`
+}
+
+type PromotionReportResponse struct {
+	Rows     []*PromotionReportRow `json:"rows"`
+	Discount float64               `json:"discount"`
+}
@@ -0,0 +1,90 @@
+package models
+
+type PurchaseOrderPrimaryKey struct {
+	Id string `json:"id"`
+}
+
+type CreatePurchaseOrderProduct struct {
+	Barcode string  `json:"barcode"`
+	Count   int     `json:"count"`
+	Price   float64 `json:"price"`
+}
+
+type CreatePurchaseOrder struct {
+	OrderNumber  string                        `json:"order_number"`
+	SupplierId   string                        `json:"supplier_id"`
+	BranchId     string                        `json:"branch_id"`
+	ExpectedDate string                        `json:"expected_date"`
+	Products     []*CreatePurchaseOrderProduct `json:"products"`
+}
+
+type PurchaseOrderProduct struct {
+	Id              string  `json:"id"`
+	PurchaseOrderId string  `json:"purchase_order_id"`
+	CategoryId      string  `json:"category_id"`
+	Name            string  `json:"name"`
+	Barcode         string  `json:"barcode"`
+	Count           int     `json:"count"`
+	Price           float64 `json:"price"`
+	TotalPrice      float64 `json:"total_price"`
+	ReceivedCount   int     `json:"received_count"`
+	CreatedAt       string  `json:"created_at"`
+	UpdatedAt       string  `json:"updated_at"`
+}
+
+type PurchaseOrder struct {
+	Id           string                  `json:"id"`
+	OrderNumber  string                  `json:"order_number"`
+	SupplierId   string                  `json:"supplier_id"`
+	BranchId     string                  `json:"branch_id"`
+	ExpectedDate string                  `json:"expected_date"`
+	Status       string                  `json:"status"`
+	CreatedAt    string                  `json:"created_at"`
+	UpdatedAt    string                  `json:"updated_at"`
+	Products     []*PurchaseOrderProduct `json:"products"`
+}
+
+type UpdatePurchaseOrder struct {
+	Id           string `json:"id"`
+	OrderNumber  string `json:"order_number"`
+	SupplierId   string `json:"supplier_id"`
+	BranchId     string `json:"branch_id"`
+	ExpectedDate string `json:"expected_date"`
+}
+
+type PurchaseOrderGetListRequest struct {
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	SupplierId string `json:"supplier_id"`
+	BranchId   string `json:"branch_id"`
+	Status     string `json:"status"`
+	ListOptions
+}
+
+type PurchaseOrderGetListResponse struct {
+	Count          int              `json:"count"`
+	PurchaseOrders []*PurchaseOrder `json:"purchase_orders"`
+	NextCursor     string           `json:"next_cursor,omitempty"`
+}
+
+// CreateComingTableFromOrder opens a coming_table that receives against a purchase order
+type CreateComingTableFromOrder struct {
+	PurchaseOrderId string `json:"purchase_order_id"`
+	ComingId        string `json:"coming_id"`
+	DateTime        string `json:"date_time"`
+}
+
+type PurchaseOrderProgressRequest struct {
+	PurchaseOrderId string `json:"purchase_order_id"`
+	ComingTableId   string `json:"coming_table_id"`
+	Barcode         string `json:"barcode"`
+}
+
+// PurchaseOrderProgress compares a scanned barcode with what was ordered
+type PurchaseOrderProgress struct {
+	OrderedCount  int  `json:"ordered_count"`
+	ReceivedCount int  `json:"received_count"`
+	ScannedCount  int  `json:"scanned_count"`
+	OverDelivery  bool `json:"over_delivery"`
+	Unknown       bool `json:"unknown"`
+}
@@ -1,12 +1,10 @@
 package models
 
+import "time"
+
 type RemainingPrimaryKey struct {
 	Id string `json:"id"`
 }
-type CheckingRemaining struct {
-	BranchId string `json:"branch_id"`
-	Barcode  string `json:"barcode"`
-}
 type CreateRemaining struct {
 	BranchId   string  `json:"branch_id"`
 	CategoryId string  `json:"category_id"`
@@ -19,6 +17,7 @@ type CreateRemaining struct {
 
 type Remaining struct {
 	Id         string  `json:"id"`
+	ProductId  string  `json:"product_id"`
 	BranchId   string  `json:"branch_id"`
 	CategoryId string  `json:"category_id"`
 	Name       string  `json:"name"`
@@ -26,6 +25,7 @@ type Remaining struct {
 	Barcode    string  `json:"barcode"`
 	Count      int     `json:"count"`
 	TotalPrice float64 `json:"total_price"`
+	Version    int     `json:"version"`
 	CreatedAt  string  `json:"created_at"`
 	UpdatedAt  string  `json:"updated_at"`
 }
@@ -39,6 +39,8 @@ type UpdateRemaining struct {
 	Barcode    string  `json:"barcode"`
 	Count      int     `json:"count"`
 	TotalPrice float64 `json:"total_price"`
+	// Version is expected version of If-Match, 0 skips the check, after update it is the new version
+	Version int `json:"-"`
 }
 
 type UpdateRemainingSoft struct {
@@ -50,15 +52,24 @@ type UpdateRemainingSoft struct {
 	Count      int     `json:"count"`
 }
 
+// RemainingGetListRequest lists current remaining or, when AsOf is set, remaining reconstructed at that moment
 type RemainingGetListRequest struct {
-	Page       int    `json:"page"`
-	Limit      int    `json:"limit"`
-	BranchId   string `json:"branch_id"`
-	Barcode    string `json:"barcode"`
-	CategoryId string `json:"category_id"`
+	Page       int       `json:"page"`
+	Limit      int       `json:"limit"`
+	BranchId   string    `json:"branch_id"`
+	Barcode    string    `json:"barcode"`
+	CategoryId string    `json:"category_id"`
+	AsOf       time.Time `json:"as_of"`
+	ListOptions
 }
 
 type RemainingGetListResponse struct {
 	Count      int          `json:"count"`
 	Remainings []*Remaining `json:"remainings"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+// StockSnapshotRequest persists closing balances of all branches before PeriodEnd
+type StockSnapshotRequest struct {
+	PeriodEnd time.Time `json:"period_end"`
 }
@@ -0,0 +1,84 @@
+package models
+
+import "time"
+
+const (
+	ReportByBranch   = "branch"
+	ReportBySupplier = "supplier"
+	ReportByCategory = "category"
+
+	PeriodDay   = "day"
+	PeriodWeek  = "week"
+	PeriodMonth = "month"
+)
+
+// ReportRequest is date range and filters shared by reports, To is inclusive
+type ReportRequest struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	BranchId string    `json:"branch_id"`
+	GroupBy  string    `json:"group_by"`
+	Period   string    `json:"period"`
+	Barcode  string    `json:"barcode"`
+	Limit    int       `json:"limit"`
+}
+
+// IncomeReportRow is income of posted coming tables of one branch, supplier or category in one period
+type IncomeReportRow struct {
+	Period       string  `json:"period"`
+	Id           string  `json:"id"`
+	Name         string  `json:"name"`
+	ComingTables int     `json:"coming_tables"`
+	Count        int     `json:"count"`
+	TotalPrice   float64 `json:"total_price"`
+}
+
+type IncomeReportResponse struct {
+	Rows       []*IncomeReportRow `json:"rows"`
+	Count      int                `json:"count"`
+	TotalPrice float64            `json:"total_price"`
+}
+
+type StockReportRow struct {
+	BranchId   string  `json:"branch_id"`
+	BranchName string  `json:"branch_name"`
+	Products   int     `json:"products"`
+	Count      int     `json:"count"`
+	TotalPrice float64 `json:"total_price"`
+}
+
+type StockReportResponse struct {
+	Rows       []*StockReportRow `json:"rows"`
+	Count      int               `json:"count"`
+	TotalPrice float64           `json:"total_price"`
+}
+
+type TopProductRow struct {
+	Barcode      string  `json:"barcode"`
+	Name         string  `json:"name"`
+	CategoryName string  `json:"category_name"`
+	Count        int     `json:"count"`
+	TotalPrice   float64 `json:"total_price"`
+}
+
+type TopProductsResponse struct {
+	Products []*TopProductRow `json:"products"`
+}
+
+// MovementReportRow is opening balance, income, outcome and closing balance of barcode in the date range
+type MovementReportRow struct {
+	Barcode      string  `json:"barcode"`
+	Name         string  `json:"name"`
+	OpeningCount int     `json:"opening_count"`
+	OpeningPrice float64 `json:"opening_price"`
+	InCount      int     `json:"in_count"`
+	InPrice      float64 `json:"in_price"`
+	OutCount     int     `json:"out_count"`
+	OutPrice     float64 `json:"out_price"`
+	ClosingCount int     `json:"closing_count"`
+	ClosingPrice float64 `json:"closing_price"`
+}
+
+type MovementReportResponse struct {
+	Rows []*MovementReportRow `json:"rows"`
+}
@@ -0,0 +1,58 @@
+package models
+
+const (
+	RevaluationProductUpdate  = "product_update"
+	RevaluationScheduledPrice = "scheduled_price"
+	RevaluationImport         = "import"
+)
+
+type RevaluationPrimaryKey struct {
+	Id string `json:"id"`
+}
+
+// Revaluation records change of product price, difference is the sum of remaining value changes of all branches
+type Revaluation struct {
+	Id         string  `json:"id"`
+	ProductId  string  `json:"product_id"`
+	Barcode    string  `json:"barcode"`
+	Name       string  `json:"name"`
+	OldPrice   float64 `json:"old_price"`
+	NewPrice   float64 `json:"new_price"`
+	Difference float64 `json:"difference"`
+	Source     string  `json:"source"`
+	CreatedBy  string  `json:"created_by"`
+	CreatedAt  string  `json:"created_at"`
+}
+
+// RevaluationLine is remaining of one branch before and after revaluation
+type RevaluationLine struct {
+	Id         string  `json:"id"`
+	BranchId   string  `json:"branch_id"`
+	BranchName string  `json:"branch_name"`
+	Count      int     `json:"count"`
+	OldPrice   float64 `json:"old_price"`
+	NewPrice   float64 `json:"new_price"`
+	OldTotal   float64 `json:"old_total"`
+	NewTotal   float64 `json:"new_total"`
+	Difference float64 `json:"difference"`
+}
+
+type RevaluationFull struct {
+	Revaluation *Revaluation       `json:"revaluation"`
+	Lines       []*RevaluationLine `json:"lines"`
+	Count       int                `json:"count"`
+}
+
+type RevaluationGetListRequest struct {
+	Page      int    `json:"page"`
+	Limit     int    `json:"limit"`
+	Barcode   string `json:"barcode"`
+	ProductId string `json:"product_id"`
+	ListOptions
+}
+
+type RevaluationGetListResponse struct {
+	Count        int            `json:"count"`
+	Revaluations []*Revaluation `json:"revaluations"`
+	NextCursor   string         `json:"next_cursor,omitempty"`
+}
@@ -0,0 +1,63 @@
+package models
+
+type SupplierPrimaryKey struct {
+	Id string `json:"id"`
+}
+
+type CreateSupplier struct {
+	Name        string `json:"name"`
+	Address     string `json:"address"`
+	PhoneNumber string `json:"phone_number"`
+}
+
+type Supplier struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Address     string `json:"address"`
+	PhoneNumber string `json:"phone_number"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updated_at"`
+}
+
+type UpdateSupplier struct {
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+	Address     string `json:"address"`
+	PhoneNumber string `json:"phone_number"`
+}
+
+type SupplierGetListRequest struct {
+	Page   int    `json:"page"`
+	Limit  int    `json:"limit"`
+	Search string `json:"search"`
+	ListOptions
+}
+
+type SupplierGetListResponse struct {
+	Count      int         `json:"count"`
+	Suppliers  []*Supplier `json:"suppliers"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// SupplierImportTemplate tells which columns of supplier delivery notes hold barcode, count and cost,
+// columns are given by header titles or by spreadsheet letters like "col:C"
+type SupplierImportTemplate struct {
+	SupplierId    string `json:"supplier_id"`
+	BarcodeColumn string `json:"barcode_column"`
+	CountColumn   string `json:"count_column"`
+	PriceColumn   string `json:"price_column"`
+	HeaderRow     int    `json:"header_row"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+}
+
+// DefaultSupplierImportTemplate is used for suppliers without saved template
+func DefaultSupplierImportTemplate(supplierId string) *SupplierImportTemplate {
+	return &SupplierImportTemplate{
+		SupplierId:    supplierId,
+		BarcodeColumn: "barcode",
+		CountColumn:   "count",
+		PriceColumn:   "price",
+		HeaderRow:     1,
+	}
+}
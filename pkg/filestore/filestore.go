@@ -0,0 +1,65 @@
+package filestore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"market/config"
+	"strings"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+)
+
+// ErrNotFound is returned by Get when there is no file with the key
+var ErrNotFound = errors.New("file not found")
+
+// Store keeps uploaded files by key, keys are slash separated paths like products/<id>/<file>
+type Store interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// Get returns content of the file and its content type, caller closes the content
+	Get(ctx context.Context, key string) (io.ReadCloser, string, error)
+	Delete(ctx context.Context, key string) error
+	// URL is the address clients download the file from
+	URL(key string) string
+}
+
+// New creates store of the configured driver: local directory or S3 compatible bucket
+func New(cfg config.Config) (Store, error) {
+	switch cfg.FilestoreDriver {
+	case DriverLocal:
+		return NewLocal(cfg.FilestoreDir, cfg.FilestorePublicURL), nil
+	case DriverS3:
+		return NewS3(S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+			PublicURL: cfg.FilestorePublicURL,
+		})
+	default:
+		return nil, fmt.Errorf("unknown filestore driver %q", cfg.FilestoreDriver)
+	}
+}
+
+// validKey rejects empty keys, absolute paths and ones leaving the store with ..
+func validKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") {
+		return fmt.Errorf("invalid file key %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("invalid file key %q", key)
+		}
+	}
+	return nil
+}
+
+// publicURL joins base address of files and key
+func publicURL(base, key string) string {
+	return strings.TrimRight(base, "/") + "/" + key
+}
@@ -4,26 +4,89 @@ import (
 	"crypto/rand"
 	"database/sql"
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 )
 
+// ReplaceQueryParams turns :name params of query into positional $n ones and returns their values in order.
+// Names are read whole, so :price does not clobber :price_to, and quoted text and ::casts are skipped.
+// Every occurrence of a name gets the same $n. Slice values other than []byte are expanded
+// into comma separated params, empty slice becomes NULL, e.g. "IN (:ids)" or "ARRAY[:ids]"
 func ReplaceQueryParams(namedQuery string, params map[string]interface{}) (string, []interface{}) {
 	var (
-		i    int = 1
-		args []interface{}
+		query    strings.Builder
+		args     []interface{}
+		position = make(map[string]string)
 	)
 
-	for k, v := range params {
-		if k != "" {
-			namedQuery = strings.ReplaceAll(namedQuery, ":"+k, "$"+strconv.Itoa(i))
-
-			args = append(args, v)
+	for i := 0; i < len(namedQuery); i++ {
+		c := namedQuery[i]
+
+		switch {
+		case c == '\'' || c == '"':
+			end := strings.IndexByte(namedQuery[i+1:], c)
+			if end < 0 {
+				query.WriteString(namedQuery[i:])
+				return query.String(), args
+			}
+			query.WriteString(namedQuery[i : i+end+2])
+			i += end + 1
+			continue
+		case c == ':' && i+1 < len(namedQuery) && namedQuery[i+1] == ':':
+			query.WriteString("::")
 			i++
+			continue
+		case c != ':':
+			query.WriteByte(c)
+			continue
+		}
+
+		end := i + 1
+		for end < len(namedQuery) && isParamChar(namedQuery[end]) {
+			end++
+		}
+
+		name := namedQuery[i+1 : end]
+		value, ok := params[name]
+		if name == "" || !ok {
+			query.WriteByte(c)
+			continue
 		}
+
+		if _, ok := position[name]; !ok {
+			position[name], args = paramPositions(value, args)
+		}
+		query.WriteString(position[name])
+		i = end - 1
+	}
+
+	return query.String(), args
+}
+
+func isParamChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// paramPositions appends value to args and returns its $n, slices are appended element by element
+func paramPositions(value interface{}, args []interface{}) (string, []interface{}) {
+	list := reflect.ValueOf(value)
+	if list.Kind() != reflect.Slice || list.Type().Elem().Kind() == reflect.Uint8 {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args)), args
+	}
+
+	if list.Len() == 0 {
+		return "NULL", args
+	}
+
+	positions := make([]string, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		args = append(args, list.Index(i).Interface())
+		positions = append(positions, "$"+strconv.Itoa(len(args)))
 	}
 
-	return namedQuery, args
+	return strings.Join(positions, ", "), args
 }
 
 func ReplaceSQL(old, searchPattern string) string {
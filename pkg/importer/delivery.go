@@ -0,0 +1,145 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"market/models"
+	"math"
+	"strings"
+)
+
+// letterPrefix marks column of template given by spreadsheet letters, like "col:C", instead of header title
+const letterPrefix = "col:"
+
+// ValidateTemplate checks that template has barcode and count columns and its letter references are valid
+func ValidateTemplate(template *models.SupplierImportTemplate) error {
+	if strings.TrimSpace(template.BarcodeColumn) == "" || strings.TrimSpace(template.CountColumn) == "" {
+		return errors.New("barcode_column and count_column are required")
+	}
+
+	for _, name := range []string{template.BarcodeColumn, template.CountColumn, template.PriceColumn} {
+		if ref, ok := letterRef(name); ok {
+			if _, err := columnLetters(ref); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// DeliveryRows reads supplier delivery note by columns of the template, rows above the header row are skipped,
+// price column may be left out of the template and empty price keeps the product price
+func DeliveryRows(rows [][]string, template *models.SupplierImportTemplate) ([]*models.ComingTableProductScan, []*models.ImportRowError, error) {
+	var (
+		scans     = make([]*models.ComingTableProductScan, 0, len(rows))
+		errs      = make([]*models.ImportRowError, 0)
+		headerRow = template.HeaderRow
+	)
+
+	if headerRow < 1 {
+		headerRow = 1
+	}
+	if len(rows) < headerRow {
+		return nil, nil, fmt.Errorf("file has no header row %d", headerRow)
+	}
+
+	cols := headerColumns(rows[headerRow-1])
+	barcodeCol, err := cols.column(template.BarcodeColumn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("barcode column: %w", err)
+	}
+	countCol, err := cols.column(template.CountColumn)
+	if err != nil {
+		return nil, nil, fmt.Errorf("count column: %w", err)
+	}
+	priceCol := -1
+	if template.PriceColumn != "" {
+		priceCol, err = cols.column(template.PriceColumn)
+		if err != nil {
+			return nil, nil, fmt.Errorf("price column: %w", err)
+		}
+	}
+
+	for i, row := range rows[headerRow:] {
+		line := headerRow + i + 1
+		if isEmpty(row) {
+			continue
+		}
+
+		scan := &models.ComingTableProductScan{Barcode: cell(row, barcodeCol)}
+		if scan.Barcode == "" {
+			errs = append(errs, rowError(line, "", "barcode is empty"))
+			continue
+		}
+
+		count, err := ParseNumber(cell(row, countCol))
+		if err != nil {
+			errs = append(errs, rowError(line, scan.Barcode, "invalid count: %s", err))
+			continue
+		}
+		if count <= 0 || count != math.Trunc(count) {
+			errs = append(errs, rowError(line, scan.Barcode, "count must be a positive whole number"))
+			continue
+		}
+		scan.Count = int(count)
+
+		if value := cell(row, priceCol); value != "" {
+			price, err := ParseNumber(value)
+			if err != nil {
+				errs = append(errs, rowError(line, scan.Barcode, "invalid price: %s", err))
+				continue
+			}
+			if price < 0 {
+				errs = append(errs, rowError(line, scan.Barcode, "price is negative"))
+				continue
+			}
+			scan.Price = price
+		}
+
+		scans = append(scans, scan)
+	}
+
+	return scans, errs, nil
+}
+
+// column finds column by header title or by spreadsheet letters written as "col:AB", a title which is not
+// in the header is an error, it is never read as letters
+func (c columns) column(name string) (int, error) {
+	if ref, ok := letterRef(name); ok {
+		return columnLetters(ref)
+	}
+
+	i, ok := c.index(name)
+	if !ok {
+		return -1, fmt.Errorf("%q is not in the header, spreadsheet letters are written like %sC", name, letterPrefix)
+	}
+	return i, nil
+}
+
+// letterRef returns letters of column reference like "col:C"
+func letterRef(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	if len(name) < len(letterPrefix) || !strings.EqualFold(name[:len(letterPrefix)], letterPrefix) {
+		return "", false
+	}
+	return name[len(letterPrefix):], true
+}
+
+// columnLetters converts spreadsheet column letters like "A" or "AB" to column index
+func columnLetters(letters string) (int, error) {
+	name := strings.ToUpper(strings.TrimSpace(letters))
+	if name == "" || len(name) > 3 {
+		return -1, fmt.Errorf("invalid column letters %q", letters)
+	}
+
+	i := 0
+	for _, r := range name {
+		if r < 'A' || r > 'Z' {
+			return -1, fmt.Errorf("invalid column letters %q", letters)
+		}
+		i = i*26 + int(r-'A'+1)
+	}
+
+	return i - 1, nil
+}
@@ -0,0 +1,198 @@
+package importer
+
+import (
+	"market/models"
+	"reflect"
+	"testing"
+)
+
+func TestColumnLetters(t *testing.T) {
+	tests := []struct {
+		letters string
+		want    int
+		valid   bool
+	}{
+		{"A", 0, true},
+		{"c", 2, true},
+		{"Z", 25, true},
+		{"AA", 26, true},
+		{"AB", 27, true},
+		{" az ", 51, true},
+		{"ZZ", 701, true},
+		{"AAA", 702, true},
+		{"", -1, false},
+		{"AAAA", -1, false},
+		{"A1", -1, false},
+		{"Ä", -1, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.letters, func(t *testing.T) {
+			got, err := columnLetters(test.letters)
+			if (err == nil) != test.valid {
+				t.Fatalf("error is %v, valid %v", err, test.valid)
+			}
+			if got != test.want {
+				t.Errorf("column is %d, want %d", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template models.SupplierImportTemplate
+		valid    bool
+	}{
+		{"titles", models.SupplierImportTemplate{BarcodeColumn: "barcode", CountColumn: "count"}, true},
+		{"letters", models.SupplierImportTemplate{BarcodeColumn: "col:B", CountColumn: "COL:AB", PriceColumn: "col:c"}, true},
+		{"no barcode", models.SupplierImportTemplate{CountColumn: "count"}, false},
+		{"blank count", models.SupplierImportTemplate{BarcodeColumn: "barcode", CountColumn: " "}, false},
+		{"bad letters", models.SupplierImportTemplate{BarcodeColumn: "col:1", CountColumn: "count"}, false},
+		{"bad price letters", models.SupplierImportTemplate{BarcodeColumn: "barcode", CountColumn: "count", PriceColumn: "col:"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateTemplate(&test.template)
+			if (err == nil) != test.valid {
+				t.Errorf("error is %v, valid %v", err, test.valid)
+			}
+		})
+	}
+}
+
+func TestDeliveryRows(t *testing.T) {
+	// delivery note with two title rows above the header and count in column AB
+	wide := func(barcode, count, price string) []string {
+		row := make([]string, 28)
+		row[1], row[2], row[27] = barcode, price, count
+		return row
+	}
+	header := wide("EAN", "Qty", "Cost")
+	header[0] = "No"
+
+	tests := []struct {
+		name     string
+		rows     [][]string
+		template models.SupplierImportTemplate
+		scans    []*models.ComingTableProductScan
+		errs     []*models.ImportRowError
+	}{
+		{
+			name: "header titles",
+			rows: [][]string{
+				{"Barcode", "Count", "Price"},
+				{"4600001", "2", "89,90"},
+				{"4600002", "1 000", ""},
+			},
+			template: models.SupplierImportTemplate{BarcodeColumn: "barcode", CountColumn: "count", PriceColumn: "price"},
+			scans: []*models.ComingTableProductScan{
+				{Barcode: "4600001", Count: 2, Price: 89.90},
+				{Barcode: "4600002", Count: 1000},
+			},
+			errs: []*models.ImportRowError{},
+		},
+		{
+			name: "letters below title rows",
+			rows: [][]string{
+				{"Delivery note 17"},
+				{},
+				header,
+				wide("4600001", "3", "10"),
+				{},
+				wide("4600002", "2,5", "10"),
+				wide("", "1", "10"),
+				wide("4600003", "0", "10"),
+				wide("4600004", "1", "free"),
+				wide("4600005", "1", "-2"),
+				wide("4600006", "", "10"),
+			},
+			template: models.SupplierImportTemplate{BarcodeColumn: "col:B", CountColumn: "col:AB", PriceColumn: "Cost", HeaderRow: 3},
+			scans: []*models.ComingTableProductScan{
+				{Barcode: "4600001", Count: 3, Price: 10},
+			},
+			// rows are numbered as the spreadsheet shows them, counting the title rows
+			errs: []*models.ImportRowError{
+				{Row: 6, Barcode: "4600002", Error: "count must be a positive whole number"},
+				{Row: 7, Barcode: "", Error: "barcode is empty"},
+				{Row: 8, Barcode: "4600003", Error: "count must be a positive whole number"},
+				{Row: 9, Barcode: "4600004", Error: `invalid price: "free" is not a number`},
+				{Row: 10, Barcode: "4600005", Error: "price is negative"},
+				{Row: 11, Barcode: "4600006", Error: "invalid count: value is empty"},
+			},
+		},
+		{
+			name: "header row zero is the first row",
+			rows: [][]string{
+				{"barcode", "count"},
+				{"4600001", "x"},
+			},
+			template: models.SupplierImportTemplate{BarcodeColumn: "barcode", CountColumn: "count"},
+			scans:    []*models.ComingTableProductScan{},
+			errs: []*models.ImportRowError{
+				{Row: 2, Barcode: "4600001", Error: `invalid count: "x" is not a number`},
+			},
+		},
+		{
+			name: "letters past the end of short row",
+			rows: [][]string{
+				{"barcode"},
+				{"4600001"},
+			},
+			template: models.SupplierImportTemplate{BarcodeColumn: "barcode", CountColumn: "col:C"},
+			scans:    []*models.ComingTableProductScan{},
+			errs: []*models.ImportRowError{
+				{Row: 2, Barcode: "4600001", Error: "invalid count: value is empty"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scans, errs, err := DeliveryRows(test.rows, &test.template)
+			if err != nil {
+				t.Fatalf("DeliveryRows: %v", err)
+			}
+			if !reflect.DeepEqual(scans, test.scans) {
+				for _, scan := range scans {
+					t.Logf("got %+v", scan)
+				}
+				t.Errorf("scans differ")
+			}
+			if !reflect.DeepEqual(errs, test.errs) {
+				for _, rowErr := range errs {
+					t.Logf("got %+v", rowErr)
+				}
+				t.Errorf("row errors differ")
+			}
+		})
+	}
+}
+
+func TestDeliveryRowsHeader(t *testing.T) {
+	rows := [][]string{
+		{"Delivery note 17"},
+		{"Barcode", "Count"},
+	}
+
+	tests := []struct {
+		name     string
+		template models.SupplierImportTemplate
+	}{
+		{"header row past the end", models.SupplierImportTemplate{BarcodeColumn: "barcode", CountColumn: "count", HeaderRow: 3}},
+		{"title above the header row", models.SupplierImportTemplate{BarcodeColumn: "Delivery note 17", CountColumn: "count", HeaderRow: 2}},
+		{"letters without prefix", models.SupplierImportTemplate{BarcodeColumn: "A", CountColumn: "count", HeaderRow: 2}},
+		{"missing price title", models.SupplierImportTemplate{BarcodeColumn: "barcode", CountColumn: "count", PriceColumn: "price", HeaderRow: 2}},
+		{"bad letters", models.SupplierImportTemplate{BarcodeColumn: "barcode", CountColumn: "col:C1", HeaderRow: 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, _, err := DeliveryRows(rows, &test.template); err == nil {
+				t.Error("error is nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"market/models"
+	"strconv"
+	"strings"
+)
+
+// columns maps header titles to column indexes, titles are compared case insensitively
+type columns map[string]int
+
+func headerColumns(header []string) columns {
+	cols := make(columns)
+	for i, title := range header {
+		cols[normalizeTitle(title)] = i
+	}
+	return cols
+}
+
+// index returns first column found by one of titles
+func (c columns) index(titles ...string) (int, bool) {
+	for _, title := range titles {
+		if i, ok := c[normalizeTitle(title)]; ok {
+			return i, true
+		}
+	}
+	return -1, false
+}
+
+func normalizeTitle(title string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(title)), " ", "_")
+}
+
+func cell(row []string, i int) string {
+	if i < 0 || i >= len(row) {
+		return ""
+	}
+	return strings.TrimSpace(row[i])
+}
+
+func isEmpty(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// ParseNumber accepts numbers written by spreadsheets with spaces between thousands and decimal comma
+func ParseNumber(value string) (float64, error) {
+	value = strings.NewReplacer(" ", "", "\u00a0", "", ",", ".").Replace(value)
+	if value == "" {
+		return 0, errors.New("value is empty")
+	}
+
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", value)
+	}
+
+	return number, nil
+}
+
+func rowError(row int, barcode string, format string, args ...interface{}) *models.ImportRowError {
+	return &models.ImportRowError{Row: row, Barcode: barcode, Error: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,36 @@
+package importer
+
+import "testing"
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		value string
+		want  float64
+		valid bool
+	}{
+		{"12", 12, true},
+		{"12.5", 12.5, true},
+		{"12,5", 12.5, true},
+		{" 7 ", 7, true},
+		{"1 234,56", 1234.56, true},
+		{"1 234 567", 1234567, true},
+		{"-3,25", -3.25, true},
+		{"", 0, false},
+		{"   ", 0, false},
+		{"abc", 0, false},
+		{"1.234,56", 0, false},
+		{"12 kg", 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			got, err := ParseNumber(test.value)
+			if (err == nil) != test.valid {
+				t.Fatalf("error is %v, valid %v", err, test.valid)
+			}
+			if got != test.want {
+				t.Errorf("number is %v, want %v", got, test.want)
+			}
+		})
+	}
+}
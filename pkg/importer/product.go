@@ -0,0 +1,88 @@
+package importer
+
+import (
+	"errors"
+	"market/models"
+	"strings"
+)
+
+// ProductRows validates rows of product catalog, first row is header with name, barcode, price and category columns,
+// category is a path of nested categories separated by "/" or ">"
+func ProductRows(rows [][]string) ([]*models.ProductImportRow, []*models.ImportRowError, error) {
+	var (
+		products = make([]*models.ProductImportRow, 0, len(rows))
+		errs     = make([]*models.ImportRowError, 0)
+		seen     = make(map[string]int)
+	)
+
+	if len(rows) == 0 {
+		return nil, nil, errors.New("file is empty")
+	}
+
+	cols := headerColumns(rows[0])
+	nameCol, ok := cols.index("name")
+	if !ok {
+		return nil, nil, errors.New("name column is missing")
+	}
+	barcodeCol, ok := cols.index("barcode")
+	if !ok {
+		return nil, nil, errors.New("barcode column is missing")
+	}
+	priceCol, ok := cols.index("price")
+	if !ok {
+		return nil, nil, errors.New("price column is missing")
+	}
+	categoryCol, _ := cols.index("category", "category_path")
+
+	for i, row := range rows[1:] {
+		line := i + 2
+		if isEmpty(row) {
+			continue
+		}
+
+		product := &models.ProductImportRow{
+			Row:     line,
+			Name:    cell(row, nameCol),
+			Barcode: cell(row, barcodeCol),
+		}
+
+		if product.Barcode == "" {
+			errs = append(errs, rowError(line, "", "barcode is empty"))
+			continue
+		}
+		if strings.ContainsAny(product.Barcode, " \t") {
+			errs = append(errs, rowError(line, product.Barcode, "barcode contains spaces"))
+			continue
+		}
+		if first, ok := seen[product.Barcode]; ok {
+			errs = append(errs, rowError(line, product.Barcode, "barcode is repeated, first seen in row %d", first))
+			continue
+		}
+		if product.Name == "" {
+			errs = append(errs, rowError(line, product.Barcode, "name is empty"))
+			continue
+		}
+
+		price, err := ParseNumber(cell(row, priceCol))
+		if err != nil {
+			errs = append(errs, rowError(line, product.Barcode, "invalid price: %s", err))
+			continue
+		}
+		if price < 0 {
+			errs = append(errs, rowError(line, product.Barcode, "price is negative"))
+			continue
+		}
+		product.Price = price
+
+		for _, name := range strings.FieldsFunc(cell(row, categoryCol), func(r rune) bool { return r == '/' || r == '>' }) {
+			if name = strings.TrimSpace(name); name != "" {
+				product.CategoryPath = append(product.CategoryPath, name)
+			}
+		}
+
+		seen[product.Barcode] = line
+		products = append(products, product)
+	}
+
+	return products, errs, nil
+}
@@ -0,0 +1,75 @@
+package importer
+
+import (
+	"market/models"
+	"reflect"
+	"testing"
+)
+
+func TestProductRows(t *testing.T) {
+	rows := [][]string{
+		{"Name", "Barcode", " PRICE ", "Category path"},
+		{"Milk", "4600001", "89,90", "Food / Dairy"},
+		{"", "", "", ""},
+		{"Bread", "", "40", ""},
+		{"Kefir", "46 00002", "70", ""},
+		{"Milk again", "4600001", "95", ""},
+		{"", "4600003", "10", ""},
+		{"Cheese", "4600004", "much", ""},
+		{"Butter", "4600005", "-1", ""},
+		{"Sugar", "4600006", "1 250,5", "Food>Grocery>"},
+		{"Salt", "4600007"},
+	}
+
+	products, errs, err := ProductRows(rows)
+	if err != nil {
+		t.Fatalf("ProductRows: %v", err)
+	}
+
+	wantProducts := []*models.ProductImportRow{
+		{Row: 2, Name: "Milk", Barcode: "4600001", Price: 89.90, CategoryPath: []string{"Food", "Dairy"}},
+		{Row: 10, Name: "Sugar", Barcode: "4600006", Price: 1250.5, CategoryPath: []string{"Food", "Grocery"}},
+	}
+	if !reflect.DeepEqual(products, wantProducts) {
+		for _, product := range products {
+			t.Logf("got %+v", product)
+		}
+		t.Errorf("products differ")
+	}
+
+	wantErrs := []*models.ImportRowError{
+		{Row: 4, Barcode: "", Error: "barcode is empty"},
+		{Row: 5, Barcode: "46 00002", Error: "barcode contains spaces"},
+		{Row: 6, Barcode: "4600001", Error: "barcode is repeated, first seen in row 2"},
+		{Row: 7, Barcode: "4600003", Error: "name is empty"},
+		{Row: 8, Barcode: "4600004", Error: `invalid price: "much" is not a number`},
+		{Row: 9, Barcode: "4600005", Error: "price is negative"},
+		{Row: 11, Barcode: "4600007", Error: "invalid price: value is empty"},
+	}
+	if !reflect.DeepEqual(errs, wantErrs) {
+		for _, rowErr := range errs {
+			t.Logf("got %+v", rowErr)
+		}
+		t.Errorf("row errors differ")
+	}
+}
+
+func TestProductRowsHeader(t *testing.T) {
+	tests := []struct {
+		name string
+		rows [][]string
+	}{
+		{"empty file", nil},
+		{"no name", [][]string{{"barcode", "price"}}},
+		{"no barcode", [][]string{{"name", "price"}}},
+		{"no price", [][]string{{"name", "barcode"}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, _, err := ProductRows(test.rows); err == nil {
+				t.Error("error is nil")
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package job
+
+import (
+	"context"
+	"market/pkg/logger"
+	"market/storage"
+	"time"
+)
+
+// PurgeIdempotencyKeys deletes idempotency keys whose TTL passed until ctx is done
+func PurgeIdempotencyKeys(ctx context.Context, strg storage.StorageI, log logger.LoggerI, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		keys, err := strg.Idempotency().DeleteExpired()
+		if err != nil {
+			log.Error("error while deleting expired idempotency keys:", logger.Error(err))
+		} else if keys > 0 {
+			log.Info("expired idempotency keys are deleted", logger.Int("keys", int(keys)))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package job
+
+import (
+	"context"
+	"market/pkg/logger"
+	"market/storage"
+	"time"
+)
+
+// ApplyPrices copies scheduled base prices which became effective to products until ctx is done
+func ApplyPrices(ctx context.Context, strg storage.StorageI, log logger.LoggerI, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		products, err := strg.ProductPrice().ApplyDue()
+		if err != nil {
+			log.Error("error while applying scheduled prices:", logger.Error(err))
+		} else if products > 0 {
+			log.Info("scheduled prices are applied", logger.Int("products", int(products)))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package job
+
+import (
+	"context"
+	"market/models"
+	"market/pkg/logger"
+	"market/storage"
+	"time"
+)
+
+// StockSnapshots persists closing balances of the previous month once a day until ctx is done,
+// documents posted later with earlier date are added to snapshots while posting
+func StockSnapshots(ctx context.Context, strg storage.StorageI, log logger.LoggerI, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		now := time.Now()
+		periodEnd := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+		rows, err := strg.Remaining().Snapshot(&models.StockSnapshotRequest{PeriodEnd: periodEnd})
+		if err != nil {
+			log.Error("error while taking stock snapshot:", logger.Error(err))
+		} else {
+			log.Info("stock snapshot is taken", logger.String("period_end", periodEnd.Format(time.DateOnly)), logger.Int("rows", int(rows)))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
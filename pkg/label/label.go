@@ -0,0 +1,59 @@
+package label
+
+import (
+	"market/models"
+	"strconv"
+)
+
+// BarcodeType returns ean13 for valid 13 digit EAN codes and code128 for everything else when template type is auto
+func BarcodeType(barcode, templateType string) string {
+	if templateType == models.BarcodeEAN13 && IsEAN13(barcode) || templateType == models.BarcodeCode128 {
+		return templateType
+	}
+	if IsEAN13(barcode) {
+		return models.BarcodeEAN13
+	}
+	return models.BarcodeCode128
+}
+
+// IsEAN13 checks length, digits and check digit of barcode
+func IsEAN13(barcode string) bool {
+	if len(barcode) != 13 {
+		return false
+	}
+
+	sum := 0
+	for i, r := range barcode {
+		if r < '0' || r > '9' {
+			return false
+		}
+		digit := int(r - '0')
+		if i == 12 {
+			return (10-sum%10)%10 == digit
+		}
+		if i%2 == 1 {
+			digit *= 3
+		}
+		sum += digit
+	}
+	return false
+}
+
+// Price formats shelf price without decimals when it is whole: 12 500 or 12 500.50
+func Price(price float64) string {
+	whole := strconv.FormatFloat(price, 'f', 2, 64)
+	integer, fraction := whole[:len(whole)-3], whole[len(whole)-2:]
+
+	var out []byte
+	for i := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 && integer[i-1] != '-' {
+			out = append(out, ' ')
+		}
+		out = append(out, integer[i])
+	}
+
+	if fraction == "00" {
+		return string(out)
+	}
+	return string(out) + "." + fraction
+}
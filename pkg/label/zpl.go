@@ -0,0 +1,58 @@
+package label
+
+import (
+	"fmt"
+	"io"
+	"market/models"
+	"strings"
+)
+
+// ZPL writes labels for zebra compatible thermal printers, one ^XA..^XZ block per product with ^PQ copies
+func ZPL(w io.Writer, template *models.LabelTemplate, items []*models.LabelItem) error {
+	var (
+		dots     = func(mm float64) int { return int(mm * float64(template.Dpi) / 25.4) }
+		width    = dots(template.Width)
+		margin   = dots(2)
+		fontSize = dots(template.FontSize * 0.35)
+	)
+
+	for _, item := range items {
+		if item.Copies < 1 {
+			continue
+		}
+
+		var b strings.Builder
+		b.WriteString("^XA^CI28\n")
+		fmt.Fprintf(&b, "^PW%d^LL%d\n", width, dots(template.Height))
+
+		// name may take two lines, ^FB wraps it inside label width
+		fmt.Fprintf(&b, "^FO%d,%d^A0N,%d,%d^FB%d,2,0,L^FD%s^FS\n", margin, margin, fontSize, fontSize, width-2*margin, zplText(item.Name))
+		fmt.Fprintf(&b, "^FO%d,%d^A0N,%d,%d^FD%s^FS\n", margin, margin+fontSize*2+margin/2, fontSize*2, fontSize*2, Price(item.Price))
+
+		barcodeY := margin + fontSize*4 + margin
+		barcodeHeight := dots(template.Height) - barcodeY - fontSize - margin*2
+		if barcodeHeight < dots(5) {
+			barcodeHeight = dots(5)
+		}
+		if BarcodeType(item.Barcode, template.BarcodeType) == models.BarcodeEAN13 {
+			// ^BE takes 12 digits and adds check digit itself
+			fmt.Fprintf(&b, "^FO%d,%d^BY2^BEN,%d,Y,N^FD%s^FS\n", margin, barcodeY, barcodeHeight, item.Barcode[:12])
+		} else {
+			fmt.Fprintf(&b, "^FO%d,%d^BY2^BCN,%d,Y,N,N^FD%s^FS\n", margin, barcodeY, barcodeHeight, zplText(item.Barcode))
+		}
+
+		fmt.Fprintf(&b, "^PQ%d\n^XZ\n", item.Copies)
+
+		_, err := io.WriteString(w, b.String())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// zplText removes characters which start zpl commands
+func zplText(text string) string {
+	return strings.NewReplacer("^", " ", "~", " ").Replace(text)
+}
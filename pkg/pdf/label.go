@@ -0,0 +1,130 @@
+package pdf
+
+import (
+	"io"
+	"market/models"
+	"market/pkg/label"
+
+	"github.com/boombuler/barcode"
+	"github.com/boombuler/barcode/code128"
+	"github.com/boombuler/barcode/ean"
+)
+
+// Labels renders sheets of A4 with shelf labels of template size, every item is repeated by its copies
+func Labels(w io.Writer, template *models.LabelTemplate, items []*models.LabelItem) error {
+	d, err := newDocument("P")
+	if err != nil {
+		return err
+	}
+	d.SetFooterFunc(nil)
+
+	pageWidth, pageHeight := d.GetPageSize()
+	columns := template.Columns
+	if fit := int((pageWidth - 2*margin) / template.Width); columns < 1 || columns > fit {
+		columns = fit
+	}
+	rows := int((pageHeight - 2*margin) / template.Height)
+	if columns < 1 || rows < 1 {
+		columns, rows = 1, 1
+	}
+
+	n := 0
+	for _, item := range items {
+		for i := 0; i < item.Copies; i++ {
+			if n > 0 && n%(columns*rows) == 0 {
+				d.AddPage()
+			}
+			place := n % (columns * rows)
+			x := margin + float64(place%columns)*template.Width
+			y := margin + float64(place/columns)*template.Height
+
+			err = d.label(x, y, template, item)
+			if err != nil {
+				return err
+			}
+			n++
+		}
+	}
+
+	return d.Output(w)
+}
+
+func (d *document) label(x, y float64, template *models.LabelTemplate, item *models.LabelItem) error {
+	const padding = 2.0
+	var (
+		width    = template.Width - 2*padding
+		fontSize = template.FontSize
+		line     = fontSize * 0.45
+	)
+
+	// light border is the cutting line
+	d.SetDrawColor(200, 200, 200)
+	d.Rect(x, y, template.Width, template.Height, "D")
+	d.SetDrawColor(0, 0, 0)
+
+	d.SetFont(fontFamily, "", fontSize)
+	lines := d.SplitText(item.Name, width)
+	if len(lines) > 2 {
+		lines = append(lines[:1], d.fit(lines[1]+" "+lines[2], width))
+	}
+	for i, text := range lines {
+		d.SetXY(x+padding, y+padding+float64(i)*line)
+		d.CellFormat(width, line, text, "", 0, "L", false, 0, "")
+	}
+
+	d.SetFont(fontFamily, "B", fontSize*1.8)
+	d.SetXY(x+padding, y+padding+2*line)
+	d.CellFormat(width, fontSize*0.8, label.Price(item.Price), "", 0, "L", false, 0, "")
+
+	barcodeY := y + padding + 2*line + fontSize*0.8 + 1
+	barcodeHeight := y + template.Height - padding - line - barcodeY
+	if barcodeHeight > 2 {
+		err := d.barcode(x+padding, barcodeY, width, barcodeHeight, item.Barcode, label.BarcodeType(item.Barcode, template.BarcodeType))
+		if err != nil {
+			return err
+		}
+	}
+
+	d.SetFont(fontFamily, "", fontSize*0.8)
+	d.SetXY(x+padding, y+template.Height-padding-line)
+	d.CellFormat(width, line, item.Barcode, "", 0, "C", false, 0, "")
+
+	return d.Error()
+}
+
+// barcode draws bars of the code as filled rectangles, so they stay sharp at any printer resolution
+func (d *document) barcode(x, y, width, height float64, code, kind string) error {
+	var (
+		bc  barcode.Barcode
+		err error
+	)
+	if kind == models.BarcodeEAN13 {
+		bc, err = ean.Encode(code)
+	} else {
+		bc, err = code128.Encode(code)
+	}
+	if err != nil {
+		return err
+	}
+
+	modules := bc.Bounds().Dx()
+	module := width / float64(modules)
+	d.SetFillColor(0, 0, 0)
+
+	for i := 0; i < modules; {
+		if r, _, _, _ := bc.At(i, 0).RGBA(); r != 0 {
+			i++
+			continue
+		}
+		start := i
+		for i < modules {
+			if r, _, _, _ := bc.At(i, 0).RGBA(); r != 0 {
+				break
+			}
+			i++
+		}
+		d.Rect(x+float64(start)*module, y, float64(i-start)*module, height, "F")
+	}
+
+	return nil
+}
@@ -0,0 +1,170 @@
+package pdf
+
+import (
+	"embed"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/go-pdf/fpdf"
+)
+
+// DejaVu fonts cover latin, cyrillic and uzbek latin letters like oʻ and gʻ
+//
+//go:embed fonts/DejaVuSans.ttf fonts/DejaVuSans-Bold.ttf
+var fonts embed.FS
+
+const (
+	fontFamily = "DejaVu"
+	margin     = 12.0
+	lineHeight = 6.0
+)
+
+// column of table, align is fpdf align string: "L", "C" or "R"
+type column struct {
+	title string
+	width float64
+	align string
+}
+
+type document struct {
+	*fpdf.Fpdf
+	columns []column
+}
+
+func newDocument(orientation string) (*document, error) {
+	pdf := fpdf.New(orientation, "mm", "A4", "")
+	pdf.SetMargins(margin, margin, margin)
+	pdf.SetAutoPageBreak(false, margin)
+
+	for style, name := range map[string]string{"": "fonts/DejaVuSans.ttf", "B": "fonts/DejaVuSans-Bold.ttf"} {
+		font, err := fonts.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		pdf.AddUTF8FontFromBytes(fontFamily, style, font)
+	}
+
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-margin)
+		pdf.SetFont(fontFamily, "", 8)
+		pdf.CellFormat(0, 4, fmt.Sprintf("%d / {nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+	pdf.AddPage()
+
+	return &document{Fpdf: pdf}, pdf.Error()
+}
+
+func (d *document) title(text string) {
+	d.SetFont(fontFamily, "B", 14)
+	d.CellFormat(0, 8, text, "", 1, "C", false, 0, "")
+	d.Ln(2)
+}
+
+// field prints bold label and its value on one line
+func (d *document) field(label, value string) {
+	d.SetFont(fontFamily, "B", 10)
+	d.CellFormat(35, lineHeight, label, "", 0, "L", false, 0, "")
+	d.SetFont(fontFamily, "", 10)
+	d.CellFormat(0, lineHeight, value, "", 1, "L", false, 0, "")
+}
+
+// table starts table with columns, header is repeated on every page
+func (d *document) table(columns ...column) {
+	d.columns = columns
+	d.Ln(2)
+	d.tableHeader()
+}
+
+func (d *document) tableHeader() {
+	d.SetFont(fontFamily, "B", 9)
+	d.SetFillColor(230, 230, 230)
+	for _, col := range d.columns {
+		d.CellFormat(col.width, lineHeight+1, col.title, "1", 0, "C", true, 0, "")
+	}
+	d.Ln(-1)
+}
+
+// row prints one table row, too long values are cut to the column width
+func (d *document) row(bold bool, values ...string) {
+	_, pageHeight := d.GetPageSize()
+	if d.GetY()+lineHeight > pageHeight-margin*1.5 {
+		d.AddPage()
+		d.tableHeader()
+	}
+
+	style := ""
+	if bold {
+		style = "B"
+	}
+	d.SetFont(fontFamily, style, 9)
+
+	for i, col := range d.columns {
+		var value string
+		if i < len(values) {
+			value = d.fit(values[i], col.width-2)
+		}
+		d.CellFormat(col.width, lineHeight, value, "1", 0, col.align, false, 0, "")
+	}
+	d.Ln(-1)
+}
+
+func (d *document) fit(value string, width float64) string {
+	if d.GetStringWidth(value) <= width {
+		return value
+	}
+
+	runes := []rune(value)
+	for len(runes) > 0 && d.GetStringWidth(string(runes)+"…") > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
+
+// signatures prints lines for names and signatures of people who sign the document
+func (d *document) signatures(roles ...string) {
+	_, pageHeight := d.GetPageSize()
+	if d.GetY()+float64(len(roles))*12+10 > pageHeight-margin*1.5 {
+		d.AddPage()
+	}
+
+	d.Ln(10)
+	d.SetFont(fontFamily, "", 10)
+	for _, role := range roles {
+		d.CellFormat(45, lineHeight, role, "", 0, "L", false, 0, "")
+		d.CellFormat(70, lineHeight, "", "B", 0, "L", false, 0, "")
+		d.CellFormat(10, lineHeight, "", "", 0, "L", false, 0, "")
+		d.CellFormat(45, lineHeight, "", "B", 1, "L", false, 0, "")
+
+		d.SetFont(fontFamily, "", 7)
+		d.CellFormat(45, 4, "", "", 0, "L", false, 0, "")
+		d.CellFormat(70, 4, "full name", "", 0, "C", false, 0, "")
+		d.CellFormat(10, 4, "", "", 0, "L", false, 0, "")
+		d.CellFormat(45, 4, "signature", "", 1, "C", false, 0, "")
+		d.SetFont(fontFamily, "", 10)
+		d.Ln(4)
+	}
+}
+
+// money formats amount with spaces between thousands and two decimals: 1 234 567.50
+func money(amount float64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	whole := fmt.Sprintf("%.2f", math.Round(amount*100)/100)
+	integer, fraction := whole[:len(whole)-3], whole[len(whole)-2:]
+
+	var b strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(digit)
+	}
+
+	return sign + b.String() + "." + fraction
+}
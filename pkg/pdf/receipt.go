@@ -0,0 +1,53 @@
+package pdf
+
+import (
+	"io"
+	"market/models"
+	"strconv"
+)
+
+// GoodsReceipt renders goods receipt note of coming_table with its lines, totals and signature fields
+func GoodsReceipt(w io.Writer, full *models.ComingTableFull) error {
+	d, err := newDocument("P")
+	if err != nil {
+		return err
+	}
+
+	d.title("GOODS RECEIPT NOTE № " + full.ComingTable.ComingId)
+
+	d.field("Date:", full.ComingTable.DateTime)
+	if full.Branch != nil {
+		d.field("Branch:", full.Branch.Name)
+		d.field("Address:", full.Branch.Address)
+	}
+	if full.Supplier != nil {
+		d.field("Supplier:", full.Supplier.Name)
+	}
+	d.field("Status:", full.ComingTable.Status)
+
+	d.table(
+		column{"№", 10, "C"},
+		column{"Name", 62, "L"},
+		column{"Barcode", 30, "L"},
+		column{"Category", 28, "L"},
+		column{"Count", 16, "R"},
+		column{"Price", 20, "R"},
+		column{"Total", 20, "R"},
+	)
+	for i, product := range full.Products {
+		d.row(false,
+			strconv.Itoa(i+1),
+			product.ProductName,
+			product.ProductBarcode,
+			product.CategoryName,
+			strconv.Itoa(product.Count),
+			money(product.ProductPrice),
+			money(product.TotalPrice),
+		)
+	}
+	d.row(true, "", "Total", "", "", strconv.Itoa(full.Count), "", money(full.TotalPrice))
+
+	d.signatures("Delivered by:", "Received by:", "Approved by:")
+
+	return d.Output(w)
+}
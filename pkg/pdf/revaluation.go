@@ -0,0 +1,57 @@
+package pdf
+
+import (
+	"io"
+	"market/models"
+	"strconv"
+)
+
+// RepricingSheet renders revaluation with old and new price, remaining of every branch and signature fields
+func RepricingSheet(w io.Writer, full *models.RevaluationFull) error {
+	d, err := newDocument("P")
+	if err != nil {
+		return err
+	}
+
+	revaluation := full.Revaluation
+	d.title("REPRICING SHEET")
+
+	d.field("Date:", revaluation.CreatedAt)
+	d.field("Product:", revaluation.Name)
+	d.field("Barcode:", revaluation.Barcode)
+	d.field("Old price:", money(revaluation.OldPrice))
+	d.field("New price:", money(revaluation.NewPrice))
+	d.field("Reason:", revaluation.Source)
+
+	d.table(
+		column{"№", 8, "C"},
+		column{"Branch", 44, "L"},
+		column{"Count", 16, "R"},
+		column{"Old price", 22, "R"},
+		column{"New price", 22, "R"},
+		column{"Old total", 24, "R"},
+		column{"New total", 24, "R"},
+		column{"Difference", 26, "R"},
+	)
+
+	var oldTotal, newTotal float64
+	for i, line := range full.Lines {
+		d.row(false,
+			strconv.Itoa(i+1),
+			line.BranchName,
+			strconv.Itoa(line.Count),
+			money(line.OldPrice),
+			money(line.NewPrice),
+			money(line.OldTotal),
+			money(line.NewTotal),
+			money(line.Difference),
+		)
+		oldTotal += line.OldTotal
+		newTotal += line.NewTotal
+	}
+	d.row(true, "", "Total", strconv.Itoa(full.Count), "", "", money(oldTotal), money(newTotal), money(revaluation.Difference))
+
+	d.signatures("Repriced by:", "Checked by:", "Approved by:")
+
+	return d.Output(w)
+}
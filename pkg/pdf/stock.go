@@ -0,0 +1,62 @@
+package pdf
+
+import (
+	"io"
+	"market/models"
+	"strconv"
+	"time"
+)
+
+// StockReport renders remaining of branch, rows gives remainings one by one to add
+func StockReport(w io.Writer, branch *models.Branch, rows func(add func(*models.Remaining, *models.ExportNames) error) error) error {
+	d, err := newDocument("P")
+	if err != nil {
+		return err
+	}
+
+	d.title("STOCK REPORT")
+
+	d.field("Date:", time.Now().Format(time.DateTime))
+	d.field("Branch:", branch.Name)
+	d.field("Address:", branch.Address)
+
+	d.table(
+		column{"№", 10, "C"},
+		column{"Name", 66, "L"},
+		column{"Barcode", 30, "L"},
+		column{"Category", 30, "L"},
+		column{"Count", 16, "R"},
+		column{"Price", 17, "R"},
+		column{"Total", 17, "R"},
+	)
+
+	var (
+		number     int
+		count      int
+		totalPrice float64
+	)
+	err = rows(func(remaining *models.Remaining, names *models.ExportNames) error {
+		number++
+		count += remaining.Count
+		totalPrice += remaining.TotalPrice
+
+		d.row(false,
+			strconv.Itoa(number),
+			remaining.Name,
+			remaining.Barcode,
+			names.CategoryName,
+			strconv.Itoa(remaining.Count),
+			money(remaining.Price),
+			money(remaining.TotalPrice),
+		)
+		return d.Error()
+	})
+	if err != nil {
+		return err
+	}
+	d.row(true, "", "Total", "", "", strconv.Itoa(count), "", money(totalPrice))
+
+	d.signatures("Storekeeper:", "Accountant:")
+
+	return d.Output(w)
+}
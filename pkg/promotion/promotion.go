@@ -0,0 +1,187 @@
+package promotion
+
+import (
+	"fmt"
+	"market/models"
+	"math"
+	"sort"
+)
+
+// Validate checks that parameters of the promotion type make sense
+func Validate(promotion *models.CreatePromotion) error {
+	switch promotion.Type {
+	case models.PromotionPercent:
+		if promotion.Percent <= 0 || promotion.Percent > 100 {
+			return fmt.Errorf("percent must be greater than 0 and not greater than 100")
+		}
+	case models.PromotionBuyGet:
+		if promotion.BuyCount <= 0 || promotion.FreeCount <= 0 {
+			return fmt.Errorf("buy_count and free_count must be positive")
+		}
+	case models.PromotionBundlePrice:
+		if promotion.BundleCount <= 1 || promotion.BundlePrice < 0 {
+			return fmt.Errorf("bundle_count must be greater than 1 and bundle_price can not be negative")
+		}
+	default:
+		return fmt.Errorf("unknown promotion type %q", promotion.Type)
+	}
+	return nil
+}
+
+// Apply gives discounts of promotions to basket lines, promotions are tried in the given order
+// and every unit takes part in one promotion at most, so promotions do not stack
+func Apply(lines []*models.BasketLine, promotions []*models.Promotion) *models.BasketResponse {
+	var (
+		resp = &models.BasketResponse{Lines: lines}
+		free = make([]int, len(lines))
+	)
+
+	for i, line := range lines {
+		free[i] = line.Count
+		line.Total = round(line.Price * float64(line.Count))
+		line.Promotions = make([]*models.AppliedPromotion, 0)
+	}
+
+	for _, promotion := range promotions {
+		var matched []int
+		for i, line := range lines {
+			if free[i] > 0 && matches(promotion, line) {
+				matched = append(matched, i)
+			}
+		}
+
+		switch promotion.Type {
+		case models.PromotionPercent:
+			for _, i := range matched {
+				discount := lines[i].Price * float64(free[i]) * promotion.Percent / 100
+				give(lines[i], promotion, free[i], discount)
+				free[i] = 0
+			}
+		case models.PromotionBuyGet:
+			if promotion.BuyCount <= 0 || promotion.FreeCount <= 0 {
+				continue
+			}
+			group := promotion.BuyCount + promotion.FreeCount
+			for _, i := range matched {
+				sets := free[i] / group
+				if sets == 0 {
+					continue
+				}
+				give(lines[i], promotion, sets*group, float64(sets*promotion.FreeCount)*lines[i].Price)
+				free[i] -= sets * group
+			}
+		case models.PromotionBundlePrice:
+			bundle(lines, free, matched, promotion)
+		}
+	}
+
+	for _, line := range lines {
+		line.ToPay = round(line.Total - line.Discount)
+		resp.Total += line.Total
+		resp.Discount += line.Discount
+	}
+	resp.Total = round(resp.Total)
+	resp.Discount = round(resp.Discount)
+	resp.ToPay = round(resp.Total - resp.Discount)
+
+	return resp
+}
+
+// bundle sells matched units by bundles starting from the most expensive ones,
+// discount of every bundle is shared by lines in proportion to the price of their units
+func bundle(lines []*models.BasketLine, free []int, matched []int, promotion *models.Promotion) {
+	if promotion.BundleCount <= 0 {
+		return
+	}
+
+	units := 0
+	for _, i := range matched {
+		units += free[i]
+	}
+	bundles := units / promotion.BundleCount
+	if bundles == 0 {
+		return
+	}
+
+	sort.SliceStable(matched, func(a, b int) bool {
+		return lines[matched[a]].Price > lines[matched[b]].Price
+	})
+
+	var (
+		left  = bundles * promotion.BundleCount
+		taken = make(map[int]int)
+		value float64
+	)
+	for _, i := range matched {
+		if left == 0 {
+			break
+		}
+		count := free[i]
+		if count > left {
+			count = left
+		}
+		taken[i] = count
+		left -= count
+		value += lines[i].Price * float64(count)
+	}
+
+	discount := value - float64(bundles)*promotion.BundlePrice
+	if discount <= 0 {
+		return
+	}
+
+	shared := 0.0
+	last := -1
+	for _, i := range matched {
+		if taken[i] > 0 {
+			last = i
+		}
+	}
+	for _, i := range matched {
+		if taken[i] == 0 {
+			continue
+		}
+		share := round(discount * lines[i].Price * float64(taken[i]) / value)
+		if i == last {
+			share = round(discount - shared)
+		}
+		shared += share
+		give(lines[i], promotion, taken[i], share)
+		free[i] -= taken[i]
+	}
+}
+
+func give(line *models.BasketLine, promotion *models.Promotion, count int, discount float64) {
+	discount = round(discount)
+	line.Discount = round(line.Discount + discount)
+	line.Promotions = append(line.Promotions, &models.AppliedPromotion{
+		PromotionId: promotion.Id,
+		Name:        promotion.Name,
+		Count:       count,
+		Discount:    discount,
+	})
+}
+
+// matches checks scope of the promotion, without products and categories it matches everything
+func matches(promotion *models.Promotion, line *models.BasketLine) bool {
+	if len(promotion.ProductIds) == 0 && len(promotion.CategoryIds) == 0 {
+		return true
+	}
+	for _, id := range promotion.ProductIds {
+		if id == line.ProductId {
+			return true
+		}
+	}
+	for _, id := range promotion.CategoryIds {
+		for _, categoryId := range line.CategoryIds {
+			if id == categoryId {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func round(value float64) float64 {
+	return math.Round(value*100) / 100
+}
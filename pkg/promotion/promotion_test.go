@@ -0,0 +1,238 @@
+package promotion
+
+import (
+	"market/models"
+	"math"
+	"testing"
+)
+
+func line(productId string, price float64, count int, categoryIds ...string) *models.BasketLine {
+	return &models.BasketLine{ProductId: productId, Price: price, Count: count, CategoryIds: categoryIds}
+}
+
+// applied is count and discount a line got from one promotion
+type applied struct {
+	id       string
+	count    int
+	discount float64
+}
+
+func TestApply(t *testing.T) {
+	var (
+		percent = func(id string, value float64, productIds ...string) *models.Promotion {
+			return &models.Promotion{Id: id, Type: models.PromotionPercent, Percent: value, ProductIds: productIds}
+		}
+		buyGet = func(id string, buy, free int, productIds ...string) *models.Promotion {
+			return &models.Promotion{Id: id, Type: models.PromotionBuyGet, BuyCount: buy, FreeCount: free, ProductIds: productIds}
+		}
+		bundlePrice = func(id string, count int, price float64, productIds ...string) *models.Promotion {
+			return &models.Promotion{Id: id, Type: models.PromotionBundlePrice, BundleCount: count, BundlePrice: price, ProductIds: productIds}
+		}
+	)
+
+	tests := []struct {
+		name       string
+		lines      []*models.BasketLine
+		promotions []*models.Promotion
+		// applied promotions of every line in order
+		want     [][]applied
+		discount float64
+		toPay    float64
+	}{
+		{
+			name:       "no promotions",
+			lines:      []*models.BasketLine{line("a", 10, 2)},
+			promotions: nil,
+			want:       [][]applied{{}},
+			discount:   0,
+			toPay:      20,
+		},
+		{
+			name:       "percent of every unit",
+			lines:      []*models.BasketLine{line("a", 9.99, 3)},
+			promotions: []*models.Promotion{percent("p", 15)},
+			want:       [][]applied{{{"p", 3, 4.50}}},
+			discount:   4.50,
+			toPay:      25.47,
+		},
+		{
+			name:       "percent out of scope",
+			lines:      []*models.BasketLine{line("a", 10, 1), line("b", 10, 1)},
+			promotions: []*models.Promotion{percent("p", 50, "b")},
+			want:       [][]applied{{}, {{"p", 1, 5}}},
+			discount:   5,
+			toPay:      15,
+		},
+		{
+			name:       "percent by category",
+			lines:      []*models.BasketLine{line("a", 10, 1, "drinks", "food"), line("b", 10, 1, "toys")},
+			promotions: []*models.Promotion{{Id: "p", Type: models.PromotionPercent, Percent: 10, CategoryIds: []string{"food"}}},
+			want:       [][]applied{{{"p", 1, 1}}, {}},
+			discount:   1,
+			toPay:      19,
+		},
+		{
+			name:       "buy two get one for whole sets only",
+			lines:      []*models.BasketLine{line("a", 4, 7)},
+			promotions: []*models.Promotion{buyGet("g", 2, 1)},
+			want:       [][]applied{{{"g", 6, 8}}},
+			discount:   8,
+			toPay:      20,
+		},
+		{
+			name:       "buy get without a whole set",
+			lines:      []*models.BasketLine{line("a", 4, 2)},
+			promotions: []*models.Promotion{buyGet("g", 2, 1)},
+			want:       [][]applied{{}},
+			discount:   0,
+			toPay:      8,
+		},
+		{
+			name:       "buy get counts every product apart",
+			lines:      []*models.BasketLine{line("a", 4, 2), line("b", 6, 1)},
+			promotions: []*models.Promotion{buyGet("g", 2, 1)},
+			want:       [][]applied{{}, {}},
+			discount:   0,
+			toPay:      14,
+		},
+		{
+			name:       "bundle of the most expensive units",
+			lines:      []*models.BasketLine{line("a", 1, 2), line("b", 3, 2)},
+			promotions: []*models.Promotion{bundlePrice("b3", 3, 5)},
+			// b units 6 and one a unit 1 make bundle of 7 sold for 5
+			want:     [][]applied{{{"b3", 1, 0.29}}, {{"b3", 2, 1.71}}},
+			discount: 2,
+			toPay:    6,
+		},
+		{
+			name:       "bundle dearer than its units",
+			lines:      []*models.BasketLine{line("a", 1, 3)},
+			promotions: []*models.Promotion{bundlePrice("b3", 3, 5)},
+			want:       [][]applied{{}},
+			discount:   0,
+			toPay:      3,
+		},
+		{
+			name:       "bundle rounding remainder goes to the last line",
+			lines:      []*models.BasketLine{line("a", 0.10, 1), line("b", 0.10, 1), line("c", 0.10, 1)},
+			promotions: []*models.Promotion{bundlePrice("b3", 3, 0.25)},
+			want:       [][]applied{{{"b3", 1, 0.02}}, {{"b3", 1, 0.02}}, {{"b3", 1, 0.01}}},
+			discount:   0.05,
+			toPay:      0.25,
+		},
+		{
+			name:       "earlier promotion takes units first",
+			lines:      []*models.BasketLine{line("a", 10, 3)},
+			promotions: []*models.Promotion{buyGet("g", 1, 1), percent("p", 50)},
+			// two units go to buy one get one, the third one is left to percent
+			want:     [][]applied{{{"g", 2, 10}, {"p", 1, 5}}},
+			discount: 15,
+			toPay:    15,
+		},
+		{
+			name:       "promotions do not stack on the same units",
+			lines:      []*models.BasketLine{line("a", 10, 2)},
+			promotions: []*models.Promotion{percent("p", 10), percent("q", 50)},
+			want:       [][]applied{{{"p", 2, 2}}},
+			discount:   2,
+			toPay:      18,
+		},
+		{
+			name:       "bundle takes what percent of other product left",
+			lines:      []*models.BasketLine{line("a", 2, 2), line("b", 2, 3)},
+			promotions: []*models.Promotion{percent("p", 50, "a"), bundlePrice("b3", 3, 3)},
+			want:       [][]applied{{{"p", 2, 2}}, {{"b3", 3, 3}}},
+			discount:   5,
+			toPay:      5,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := Apply(test.lines, test.promotions)
+
+			for i, line := range resp.Lines {
+				if len(line.Promotions) != len(test.want[i]) {
+					t.Fatalf("line %d got %d promotions, want %d", i, len(line.Promotions), len(test.want[i]))
+				}
+				for j, got := range line.Promotions {
+					want := test.want[i][j]
+					if got.PromotionId != want.id || got.Count != want.count || got.Discount != want.discount {
+						t.Errorf("line %d promotion %d is %s for %d with %v, want %s for %d with %v",
+							i, j, got.PromotionId, got.Count, got.Discount, want.id, want.count, want.discount)
+					}
+				}
+				if line.ToPay != round(line.Total-line.Discount) {
+					t.Errorf("line %d pays %v of %v with %v discount", i, line.ToPay, line.Total, line.Discount)
+				}
+			}
+
+			if resp.Discount != test.discount || resp.ToPay != test.toPay {
+				t.Errorf("basket discount %v to pay %v, want %v and %v", resp.Discount, resp.ToPay, test.discount, test.toPay)
+			}
+		})
+	}
+}
+
+// TestBundleSumsToBundlePrice checks that whatever the prices, bundled units of all lines cost exactly bundle price
+func TestBundleSumsToBundlePrice(t *testing.T) {
+	prices := [][]float64{
+		{0.10, 0.10, 0.10},
+		{1.99, 2.49, 3.33},
+		{0.07, 13.13, 5.55, 0.01},
+		{9.99, 9.99, 9.99, 9.99, 9.99, 9.99},
+	}
+
+	for _, linePrices := range prices {
+		var (
+			lines []*models.BasketLine
+			value float64
+		)
+		for i, price := range linePrices {
+			lines = append(lines, line(string(rune('a'+i)), price, 1))
+			value += price
+		}
+		bundleCount := len(linePrices)
+		bundlePrice := math.Floor(value*0.7*100) / 100
+
+		resp := Apply(lines, []*models.Promotion{{Id: "b", Type: models.PromotionBundlePrice, BundleCount: bundleCount, BundlePrice: bundlePrice}})
+
+		var toPay float64
+		for _, line := range resp.Lines {
+			if line.Discount < 0 {
+				t.Errorf("prices %v: line of %v got negative discount %v", linePrices, line.Price, line.Discount)
+			}
+			toPay += line.ToPay
+		}
+		if round(toPay) != bundlePrice || resp.ToPay != bundlePrice {
+			t.Errorf("prices %v: lines pay %v and basket %v, want bundle price %v", linePrices, round(toPay), resp.ToPay, bundlePrice)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		promotion models.CreatePromotion
+		valid     bool
+	}{
+		{"percent", models.CreatePromotion{Type: models.PromotionPercent, Percent: 100}, true},
+		{"percent zero", models.CreatePromotion{Type: models.PromotionPercent}, false},
+		{"percent over 100", models.CreatePromotion{Type: models.PromotionPercent, Percent: 101}, false},
+		{"buy get", models.CreatePromotion{Type: models.PromotionBuyGet, BuyCount: 2, FreeCount: 1}, true},
+		{"buy get nothing free", models.CreatePromotion{Type: models.PromotionBuyGet, BuyCount: 2}, false},
+		{"bundle", models.CreatePromotion{Type: models.PromotionBundlePrice, BundleCount: 2, BundlePrice: 0}, true},
+		{"bundle of one", models.CreatePromotion{Type: models.PromotionBundlePrice, BundleCount: 1, BundlePrice: 5}, false},
+		{"bundle negative price", models.CreatePromotion{Type: models.PromotionBundlePrice, BundleCount: 2, BundlePrice: -1}, false},
+		{"unknown type", models.CreatePromotion{Type: "gift"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(&test.promotion)
+			if (err == nil) != test.valid {
+				t.Errorf("error is %v, valid %v", err, test.valid)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+// latin spells Cyrillic letters of Uzbek and Russian in Uzbek Latin, apostrophes of o‘ and g‘,
+// hard and soft signs are dropped, so "Ўзбек", "O‘zbek" and "ozbek" are written the same.
+// It must match product_search_text function of migrations, names are indexed by it
+var latin = strings.NewReplacer(
+	"а", "a", "б", "b", "в", "v", "г", "g", "д", "d", "е", "e", "ё", "yo", "ж", "j", "з", "z",
+	"и", "i", "й", "y", "к", "k", "л", "l", "м", "m", "н", "n", "о", "o", "п", "p", "р", "r",
+	"с", "s", "т", "t", "у", "u", "ф", "f", "х", "x", "ц", "ts", "ч", "ch", "ш", "sh", "щ", "sh",
+	"ы", "i", "э", "e", "ю", "yu", "я", "ya", "ў", "o", "қ", "q", "ғ", "g", "ҳ", "h",
+	"ъ", "", "ь", "", "'", "", "`", "", "ʻ", "", "ʼ", "", "‘", "", "’", "",
+)
+
+// Latin lowercases text and transliterates it to Uzbek Latin without apostrophes
+func Latin(text string) string {
+	return latin.Replace(strings.ToLower(text))
+}
+
+// Words splits text transliterated by Latin into words of letters and digits
+func Words(text string) []string {
+	return strings.FieldsFunc(Latin(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// PrefixQuery is tsquery matching texts having all words of query as prefixes of their words,
+// empty when query has no words. Words are letters and digits only, so the query can not inject operators
+func PrefixQuery(query string) string {
+	words := Words(query)
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+	return strings.Join(words, " & ")
+}
+
+// Highlight wraps words of text whose transliteration starts with one of words of query into <mark>,
+// so Cyrillic name is highlighted for Latin query and the other way round. Result is HTML, text is escaped
+func Highlight(text, query string) string {
+	words := Words(query)
+	if len(words) == 0 {
+		return html.EscapeString(text)
+	}
+
+	var (
+		result strings.Builder
+		start  = -1
+	)
+	mark := func(end int) {
+		word := text[start:end]
+		latin := Latin(word)
+		for _, w := range words {
+			if strings.HasPrefix(latin, w) {
+				result.WriteString("<mark>" + html.EscapeString(word) + "</mark>")
+				return
+			}
+		}
+		result.WriteString(html.EscapeString(word))
+	}
+
+	for i, r := range text {
+		inWord := unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("'`ʻʼ‘’", r)
+		switch {
+		case inWord && start < 0:
+			start = i
+		case !inWord && start >= 0:
+			mark(i)
+			start = -1
+		}
+		if !inWord {
+			result.WriteString(html.EscapeString(string(r)))
+		}
+	}
+	if start >= 0 {
+		mark(len(text))
+	}
+
+	return result.String()
+}
+
+// likeEscape escapes wildcards of LIKE pattern with backslash, patterns are used with ESCAPE '\'
+var likeEscape = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// LikePrefix is LIKE pattern of values starting with prefix, its wildcards are escaped
+func LikePrefix(prefix string) string {
+	return likeEscape.Replace(prefix) + "%"
+}
+
+// LikeContains is LIKE pattern of values containing text, its wildcards are escaped
+func LikeContains(text string) string {
+	return "%" + likeEscape.Replace(text) + "%"
+}
@@ -0,0 +1,91 @@
+package sheet
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatXLSX = "xlsx"
+)
+
+var ErrUnknownFormat = errors.New("only csv and xlsx files are supported")
+
+// Format returns sheet format by file name extension
+func Format(filename string) (string, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".xlsx":
+		return FormatXLSX, nil
+	}
+	return "", ErrUnknownFormat
+}
+
+// Read returns all rows of csv file or first sheet of xlsx file
+func Read(r io.Reader, filename string) ([][]string, error) {
+	format, err := Format(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == FormatXLSX {
+		return readXLSX(r)
+	}
+	return readCSV(r)
+}
+
+func readXLSX(r io.Reader) ([][]string, error) {
+	file, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("xlsx file has no sheets")
+	}
+
+	return file.GetRows(sheets[0])
+}
+
+// readCSV accepts both comma and semicolon separated files, spreadsheets save the latter in many locales
+func readCSV(r io.Reader) ([][]string, error) {
+	reader := bufio.NewReader(r)
+
+	firstLine, err := reader.Peek(4096)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+	if i := bytes.IndexByte(firstLine, '\n'); i >= 0 {
+		firstLine = firstLine[:i]
+	}
+
+	csvReader := csv.NewReader(reader)
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+	if bytes.Count(firstLine, []byte{';'}) > bytes.Count(firstLine, []byte{','}) {
+		csvReader.Comma = ';'
+	}
+
+	rows, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	// excel puts byte order mark in front of utf-8 csv files
+	if len(rows) > 0 && len(rows[0]) > 0 {
+		rows[0][0] = strings.TrimPrefix(rows[0][0], "\uFEFF")
+	}
+
+	return rows, nil
+}
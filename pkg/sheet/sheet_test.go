@@ -0,0 +1,83 @@
+package sheet
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadCSV(t *testing.T) {
+	tests := []struct {
+		name string
+		file string
+		want [][]string
+	}{
+		{
+			name: "comma",
+			file: "name,barcode,price\nMilk,4600001,89.90\n",
+			want: [][]string{{"name", "barcode", "price"}, {"Milk", "4600001", "89.90"}},
+		},
+		{
+			name: "semicolon with decimal comma",
+			file: "name;barcode;price\nMilk;4600001;89,90\n",
+			want: [][]string{{"name", "barcode", "price"}, {"Milk", "4600001", "89,90"}},
+		},
+		{
+			name: "delimiter is taken from the first line only",
+			file: "name,barcode\n\"a;b;c\",1\n",
+			want: [][]string{{"name", "barcode"}, {"a;b;c", "1"}},
+		},
+		{
+			name: "byte order mark",
+			file: "\uFEFFname;barcode\r\nMilk; 4600001\r\n",
+			want: [][]string{{"name", "barcode"}, {"Milk", "4600001"}},
+		},
+		{
+			name: "rows of different length",
+			file: "name,barcode,price\nMilk\n",
+			want: [][]string{{"name", "barcode", "price"}, {"Milk"}},
+		},
+		{
+			name: "no line break",
+			file: "a;b",
+			want: [][]string{{"a", "b"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rows, err := readCSV(strings.NewReader(test.file))
+			if err != nil {
+				t.Fatalf("readCSV: %v", err)
+			}
+			if !reflect.DeepEqual(rows, test.want) {
+				t.Errorf("rows are %q, want %q", rows, test.want)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     string
+	}{
+		{"prices.csv", FormatCSV},
+		{"Prices.XLSX", FormatXLSX},
+		{"prices.xls", ""},
+		{"prices", ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filename, func(t *testing.T) {
+			format, err := Format(test.filename)
+			if format != test.want {
+				t.Errorf("format is %q, want %q", format, test.want)
+			}
+			if test.want == "" && !errors.Is(err, ErrUnknownFormat) {
+				t.Errorf("error is %v, want %v", err, ErrUnknownFormat)
+			}
+		})
+	}
+}
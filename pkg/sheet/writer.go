@@ -0,0 +1,144 @@
+package sheet
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Writer writes rows one by one, Close must be called to finish the file or Discard to drop it
+type Writer interface {
+	Write(values []interface{}) error
+	Close() error
+	Discard()
+}
+
+// NewWriter returns csv or xlsx writer to w
+func NewWriter(w io.Writer, format string) (Writer, error) {
+	var (
+		writer Writer
+		err    error
+	)
+
+	switch format {
+	case FormatCSV:
+		writer, err = newCSVWriter(w)
+	case FormatXLSX:
+		writer, err = newXLSXWriter(w)
+	default:
+		return nil, ErrUnknownFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return writer, nil
+}
+
+// ContentType returns mime type of the format
+func ContentType(format string) string {
+	if format == FormatXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+type csvWriter struct {
+	w    *csv.Writer
+	rows int
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	// byte order mark makes excel open utf-8 csv files with right encoding
+	_, err := io.WriteString(w, "\uFEFF")
+	if err != nil {
+		return nil, err
+	}
+	return &csvWriter{w: csv.NewWriter(w)}, nil
+}
+
+func (c *csvWriter) Write(values []interface{}) error {
+	record := make([]string, len(values))
+	for i, value := range values {
+		switch v := value.(type) {
+		case nil:
+		case string:
+			record[i] = v
+		case float64:
+			record[i] = strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+
+	err := c.w.Write(record)
+	if err != nil {
+		return err
+	}
+
+	// flush from time to time so big exports are sent while being read from db
+	c.rows++
+	if c.rows%500 == 0 {
+		c.w.Flush()
+		return c.w.Error()
+	}
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// Discard can not take back already sent rows, the rest is just not flushed
+func (c *csvWriter) Discard() {}
+
+// xlsxWriter uses stream writer of excelize which keeps big sheets in temporary file instead of memory
+type xlsxWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	rows   int
+}
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+
+	stream, err := file.NewStreamWriter("Sheet1")
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &xlsxWriter{out: w, file: file, stream: stream}, nil
+}
+
+func (x *xlsxWriter) Write(values []interface{}) error {
+	x.rows++
+
+	cell, err := excelize.CoordinatesToCellName(1, x.rows)
+	if err != nil {
+		return err
+	}
+
+	return x.stream.SetRow(cell, values)
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+
+	err := x.stream.Flush()
+	if err != nil {
+		return err
+	}
+
+	return x.file.Write(x.out)
+}
+
+// Discard removes temporary files of the unfinished sheet
+func (x *xlsxWriter) Discard() {
+	x.file.Close()
+}
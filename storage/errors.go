@@ -0,0 +1,62 @@
+package storage
+
+import "fmt"
+
+// StatusError is returned when document is in a status that does not allow requested change
+type StatusError struct {
+	Document string `json:"document"`
+	Id       string `json:"id"`
+	Status   string `json:"status"`
+	Target   string `json:"target,omitempty"`
+}
+
+func (e *StatusError) Error() string {
+	if e.Target != "" {
+		return fmt.Sprintf("%s with ID %s can not move from %s to %s", e.Document, e.Id, e.Status, e.Target)
+	}
+	return fmt.Sprintf("%s with ID %s is %s and can not be changed", e.Document, e.Id, e.Status)
+}
+
+// PeriodClosedError is returned when stock document is dated inside closed accounting period of its branch
+type PeriodClosedError struct {
+	BranchId string `json:"branch_id"`
+	Month    string `json:"month"`
+}
+
+func (e *PeriodClosedError) Error() string {
+	return fmt.Sprintf("period %s of branch %s is closed, stock documents dated in it can not be changed", e.Month, e.BranchId)
+}
+
+// ConstraintError is returned when change would break unique or check constraint of stock data,
+// e.g. second remaining of the same branch and barcode or negative count
+type ConstraintError struct {
+	Constraint string `json:"constraint"`
+	Detail     string `json:"detail,omitempty"`
+}
+
+func (e *ConstraintError) Error() string {
+	return fmt.Sprintf("change violates constraint %s", e.Constraint)
+}
+
+// VersionError is returned when row was changed by someone else after the version given by client was read
+type VersionError struct {
+	Table    string `json:"table"`
+	Id       string `json:"id"`
+	Version  int    `json:"version"`
+	Expected int    `json:"expected"`
+}
+
+func (e *VersionError) Error() string {
+	return fmt.Sprintf("%s with ID %s was changed, its version is %d but %d was expected", e.Table, e.Id, e.Version, e.Expected)
+}
+
+// ListError is returned when sort or cursor of list request is not valid for the entity
+type ListError struct {
+	Param  string `json:"param"`
+	Value  string `json:"value"`
+	Reason string `json:"reason"`
+}
+
+func (e *ListError) Error() string {
+	return fmt.Sprintf("invalid %s %q: %s", e.Param, e.Value, e.Reason)
+}
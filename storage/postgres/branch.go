@@ -97,7 +97,30 @@ func (r *branchRepo) GetByID(req *models.BranchPrimaryKey) (*models.Branch, erro
 	}, nil
 }
 
+// branchList are sorts and filters of branch list, sorts by created_at or name
+var branchList = listSpec{
+	key:      listColumn{`"id"`, "uuid"},
+	fallback: "-created_at",
+	sorts: map[string][]listColumn{
+		"created_at": {{`"created_at"`, "timestamp"}},
+		"name":       {{`"name"`, "text"}},
+	},
+	filters: map[string]listColumn{
+		"name":         {`"name"`, "text"},
+		"address":      {`"address"`, "text"},
+		"phone_number": {`"phone_number"`, "text"},
+		"created_at":   {`"created_at"`, "timestamp"},
+		"updated_at":   {`"updated_at"`, "timestamp"},
+	},
+	date: "created_at",
+}
+
 func (r *branchRepo) GetList(req *models.BranchGetListRequest) (*models.BranchGetListResponse, error) {
+	page, err := newListPage(branchList, req.Page, req.Limit, req.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
 	params := make(map[string]interface{})
 	var resp = &models.BranchGetListResponse{}
 
@@ -106,7 +129,7 @@ func (r *branchRepo) GetList(req *models.BranchGetListRequest) (*models.BranchGe
 	filter := " WHERE true "
 	query := `
 			SELECT
-				COUNT(*) OVER(),
+				` + page.countColumn() + `,
 				"id", 
 				"name",
 				"address",
@@ -120,11 +143,13 @@ func (r *branchRepo) GetList(req *models.BranchGetListRequest) (*models.BranchGe
 		params["search"] = req.Search
 	}
 
-	offset := (req.Page - 1) * req.Limit
-	params["limit"] = req.Limit
-	params["offset"] = offset
+	where, err := listWhere(branchList, req.ListOptions, params)
+	if err != nil {
+		return nil, err
+	}
+	filter += where
 
-	query = query + filter + " ORDER BY created_at DESC OFFSET :offset LIMIT :limit "
+	query = page.query(query+filter, params)
 	rquery, pArr := helper.ReplaceQueryParams(query, params)
 
 	rows, err := r.db.Query(context.Background(), rquery, pArr...)
@@ -142,7 +167,7 @@ func (r *branchRepo) GetList(req *models.BranchGetListRequest) (*models.BranchGe
 			createdAt   sql.NullString
 			updatedAt   sql.NullString
 		)
-		err := rows.Scan(
+		err := rows.Scan(page.dest(
 			&resp.Count,
 			&id,
 			&name,
@@ -150,7 +175,7 @@ func (r *branchRepo) GetList(req *models.BranchGetListRequest) (*models.BranchGe
 			&phoneNumber,
 			&createdAt,
 			&updatedAt,
-		)
+		)...)
 		if err != nil {
 			return nil, err
 		}
@@ -163,6 +188,7 @@ func (r *branchRepo) GetList(req *models.BranchGetListRequest) (*models.BranchGe
 			UpdatedAt:   updatedAt.String,
 		})
 	}
+	resp.NextCursor = page.next()
 	return resp, nil
 
 }
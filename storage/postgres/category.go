@@ -109,7 +109,29 @@ func (r *categoryRepo) GetByID(req *models.CategoryPrimaryKey) (*models.Category
 	}, nil
 }
 
+// categoryList are sorts and filters of category list, sorts by created_at or name
+var categoryList = listSpec{
+	key:      listColumn{`"id"`, "uuid"},
+	fallback: "-created_at",
+	sorts: map[string][]listColumn{
+		"created_at": {{`"created_at"`, "timestamp"}},
+		"name":       {{`"name"`, "text"}},
+	},
+	filters: map[string]listColumn{
+		"name":       {`"name"`, "text"},
+		"parent_id":  {`"parent_id"`, "uuid"},
+		"created_at": {`"created_at"`, "timestamp"},
+		"updated_at": {`"updated_at"`, "timestamp"},
+	},
+	date: "created_at",
+}
+
 func (r *categoryRepo) GetList(req *models.CategoryGetListRequest) (*models.CategoryGetListResponse, error) {
+	page, err := newListPage(categoryList, req.Page, req.Limit, req.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
 	params := make(map[string]interface{})
 	var resp = &models.CategoryGetListResponse{}
 
@@ -118,7 +140,7 @@ func (r *categoryRepo) GetList(req *models.CategoryGetListRequest) (*models.Cate
 	filter := " WHERE true "
 	query := `
 			SELECT
-				COUNT(*) OVER(),
+				` + page.countColumn() + `,
 				"id", 
 				"name",
 				"parent_id",
@@ -131,11 +153,13 @@ func (r *categoryRepo) GetList(req *models.CategoryGetListRequest) (*models.Cate
 		params["search"] = req.Search
 	}
 
-	offset := (req.Page - 1) * req.Limit
-	params["limit"] = req.Limit
-	params["offset"] = offset
+	where, err := listWhere(categoryList, req.ListOptions, params)
+	if err != nil {
+		return nil, err
+	}
+	filter += where
 
-	query = query + filter + " ORDER BY created_at DESC OFFSET :offset LIMIT :limit "
+	query = page.query(query+filter, params)
 	rquery, pArr := helper.ReplaceQueryParams(query, params)
 
 	rows, err := r.db.Query(context.Background(), rquery, pArr...)
@@ -152,14 +176,14 @@ func (r *categoryRepo) GetList(req *models.CategoryGetListRequest) (*models.Cate
 			createdAt sql.NullString
 			updatedAt sql.NullString
 		)
-		err := rows.Scan(
+		err := rows.Scan(page.dest(
 			&resp.Count,
 			&id,
 			&name,
 			&parent_id,
 			&createdAt,
 			&updatedAt,
-		)
+		)...)
 		if err != nil {
 			return nil, err
 		}
@@ -171,6 +195,7 @@ func (r *categoryRepo) GetList(req *models.CategoryGetListRequest) (*models.Cate
 			UpdatedAt: updatedAt.String,
 		})
 	}
+	resp.NextCursor = page.next()
 	return resp, nil
 }
 func (r *categoryRepo) Update(req *models.UpdateCategory) (string, error) {
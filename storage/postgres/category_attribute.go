@@ -0,0 +1,280 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"market/models"
+	"market/storage"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type categoryAttributeRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCategoryAttributeRepo(db *pgxpool.Pool) *categoryAttributeRepo {
+	return &categoryAttributeRepo{
+		db: db,
+	}
+}
+
+func (r *categoryAttributeRepo) Create(req *models.CreateCategoryAttribute) (string, error) {
+	id := uuid.NewString()
+
+	query := `
+		INSERT INTO "category_attribute"(
+			"id",
+			"category_id",
+			"name",
+			"values",
+			"required",
+			"position",
+			"created_at")
+		VALUES ($1, $2, $3, COALESCE($4::varchar[], '{}'), $5, $6, NOW())
+	`
+
+	_, err := r.db.Exec(context.Background(), query,
+		id,
+		req.CategoryId,
+		req.Name,
+		req.Values,
+		req.Required,
+		req.Position,
+	)
+	if err != nil {
+		return "", constraintError(err)
+	}
+
+	return id, nil
+}
+
+func (r *categoryAttributeRepo) GetList(req *models.CategoryAttributeGetListRequest) (*models.CategoryAttributeGetListResponse, error) {
+	resp := &models.CategoryAttributeGetListResponse{Attributes: make([]*models.CategoryAttribute, 0)}
+
+	query := `
+		SELECT
+			"id",
+			"category_id",
+			"name",
+			"values",
+			"required",
+			"position",
+			"created_at",
+			"updated_at"
+		FROM "category_attribute"
+		WHERE "category_id" = $1
+		ORDER BY "position", "name"
+	`
+
+	rows, err := r.db.Query(context.Background(), query, req.CategoryId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			attribute   models.CategoryAttribute
+			category_id sql.NullString
+			created_at  sql.NullString
+			updated_at  sql.NullString
+		)
+
+		err := rows.Scan(
+			&attribute.Id,
+			&category_id,
+			&attribute.Name,
+			&attribute.Values,
+			&attribute.Required,
+			&attribute.Position,
+			&created_at,
+			&updated_at,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		attribute.CategoryId = category_id.String
+		attribute.CreatedAt = created_at.String
+		attribute.UpdatedAt = updated_at.String
+		resp.Attributes = append(resp.Attributes, &attribute)
+	}
+
+	return resp, rows.Err()
+}
+
+// Update changes attribute definition and renames the attribute in products of the category,
+// values products already have are kept even when they are not allowed anymore
+func (r *categoryAttributeRepo) Update(req *models.UpdateCategoryAttribute) (string, error) {
+	var (
+		ctx         = context.Background()
+		category_id string
+		oldName     string
+	)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `SELECT "category_id", "name" FROM "category_attribute" WHERE "id" = $1 FOR UPDATE`, req.Id).Scan(&category_id, &oldName)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", fmt.Errorf("category attribute with ID %s not found", req.Id)
+		}
+		return "", err
+	}
+
+	query := `
+		UPDATE
+			"category_attribute"
+		SET
+			"name" = $2,
+			"values" = COALESCE($3::varchar[], '{}'),
+			"required" = $4,
+			"position" = $5,
+			"updated_at" = NOW()
+		WHERE "id" = $1
+	`
+
+	_, err = tx.Exec(ctx, query,
+		req.Id,
+		req.Name,
+		req.Values,
+		req.Required,
+		req.Position,
+	)
+	if err != nil {
+		return "", constraintError(err)
+	}
+
+	if oldName != req.Name {
+		query = `
+			UPDATE
+				"product"
+			SET
+				"attributes" = ("attributes" - $2::text) || jsonb_build_object($3::text, "attributes" -> $2::text),
+				"updated_at" = NOW()
+			WHERE "category_id" = $1 AND "attributes" ? $2::text
+		`
+
+		_, err = tx.Exec(ctx, query, category_id, oldName, req.Name)
+		if err != nil {
+			return "", constraintError(err)
+		}
+	}
+
+	return req.Id, tx.Commit(ctx)
+}
+
+// Delete deletes attribute definition and its values from products of the category
+func (r *categoryAttributeRepo) Delete(req *models.CategoryAttributePrimaryKey) error {
+	var (
+		ctx         = context.Background()
+		category_id string
+		name        string
+	)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `DELETE FROM "category_attribute" WHERE "id" = $1 RETURNING "category_id", "name"`, req.Id).Scan(&category_id, &name)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("category attribute with ID %s not found", req.Id)
+		}
+		return err
+	}
+
+	query := `
+		UPDATE
+			"product"
+		SET
+			"attributes" = "attributes" - $2::text,
+			"updated_at" = NOW()
+		WHERE "category_id" = $1 AND "attributes" ? $2::text
+	`
+
+	_, err = tx.Exec(ctx, query, category_id, name)
+	if err != nil {
+		return constraintError(err)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// checkAttributes validates attributes of product against attributes defined for its category: every one must
+// be defined, have allowed value and required ones must be given. Product without category has no attributes
+func checkAttributes(ctx context.Context, tx pgx.Tx, categoryId string, attributes map[string]string) error {
+	defined := make(map[string]bool)
+
+	if categoryId != "" {
+		query := `
+			SELECT
+				"name",
+				"values",
+				"required"
+			FROM "category_attribute"
+			WHERE "category_id" = $1
+		`
+
+		rows, err := tx.Query(ctx, query, categoryId)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var (
+				name     string
+				values   []string
+				required bool
+			)
+
+			err := rows.Scan(&name, &values, &required)
+			if err != nil {
+				return err
+			}
+			defined[name] = true
+
+			value, ok := attributes[name]
+			if !ok || value == "" {
+				if required {
+					return &storage.ConstraintError{Constraint: "category_attribute", Detail: fmt.Sprintf("attribute %s is required", name)}
+				}
+				continue
+			}
+
+			if len(values) > 0 && !slices.Contains(values, value) {
+				return &storage.ConstraintError{Constraint: "category_attribute", Detail: fmt.Sprintf("%s must be one of %s", name, strings.Join(values, ", "))}
+			}
+		}
+		if err := rows.Err(); err != nil {
+			return err
+		}
+	}
+
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		if !defined[name] {
+			names = append(names, name)
+		}
+	}
+	if len(names) > 0 {
+		sort.Strings(names)
+		return &storage.ConstraintError{Constraint: "category_attribute", Detail: "attributes not defined for the category: " + strings.Join(names, ", ")}
+	}
+
+	return nil
+}
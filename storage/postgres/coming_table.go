@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"market/models"
 	"market/pkg/helper"
+	"market/storage"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -27,19 +29,26 @@ func (r *comingTableRepo) Create(req *models.CreateComingTable) (string, error)
 		id = uuid.NewString()
 	)
 
+	err := checkPeriod(context.Background(), r.db, req.BranchId, req.DateTime)
+	if err != nil {
+		return "", err
+	}
+
 	query := `
 				INSERT INTO "coming_table"(
 					"id",
 					"coming_id",
 					"branch_id",
+					"supplier_id",
 					"date_time",
 					"created_at")
-				VALUES ($1, $2, $3, $4, NOW())`
+				VALUES ($1, $2, $3, $4, $5, NOW())`
 
-	_, err := r.db.Exec(context.Background(), query,
+	_, err = r.db.Exec(context.Background(), query,
 		id,
 		req.ComingId,
 		req.BranchId,
+		helper.NewNullString(req.SupplierId),
 		req.DateTime,
 	)
 
@@ -53,13 +62,15 @@ func (r *comingTableRepo) Create(req *models.CreateComingTable) (string, error)
 func (r *comingTableRepo) GetByID(req *models.ComingTablePrimaryKey) (*models.ComingTable, error) {
 
 	var (
-		id         sql.NullString
-		coming_id  sql.NullString
-		branch_id  sql.NullString
-		date_time  sql.NullTime
-		status     sql.NullString
-		created_at sql.NullString
-		updated_at sql.NullString
+		id                sql.NullString
+		coming_id         sql.NullString
+		branch_id         sql.NullString
+		supplier_id       sql.NullString
+		purchase_order_id sql.NullString
+		date_time         sql.NullTime
+		status            sql.NullString
+		created_at        sql.NullString
+		updated_at        sql.NullString
 	)
 
 	query := `
@@ -67,6 +78,8 @@ func (r *comingTableRepo) GetByID(req *models.ComingTablePrimaryKey) (*models.Co
 			"id", 
 			"coming_id",
 			"branch_id",
+			"supplier_id",
+			"purchase_order_id",
 			"date_time",
 			"status",
 			"created_at",
@@ -79,6 +92,8 @@ func (r *comingTableRepo) GetByID(req *models.ComingTablePrimaryKey) (*models.Co
 		&id,
 		&coming_id,
 		&branch_id,
+		&supplier_id,
+		&purchase_order_id,
 		&date_time,
 		&status,
 		&created_at,
@@ -90,50 +105,71 @@ func (r *comingTableRepo) GetByID(req *models.ComingTablePrimaryKey) (*models.Co
 	}
 
 	return &models.ComingTable{
-		Id:        id.String,
-		ComingId:  coming_id.String,
-		BranchId:  branch_id.String,
-		DateTime:  date_time.Time.Format(time.DateTime),
-		Status:    status.String,
-		CreatedAt: created_at.String,
-		UpdatedAt: updated_at.String,
+		Id:              id.String,
+		ComingId:        coming_id.String,
+		BranchId:        branch_id.String,
+		SupplierId:      supplier_id.String,
+		PurchaseOrderId: purchase_order_id.String,
+		DateTime:        date_time.Time.Format(time.DateTime),
+		Status:          status.String,
+		CreatedAt:       created_at.String,
+		UpdatedAt:       updated_at.String,
 	}, nil
 }
 
+// comingTableList are sorts and filters of coming table list, sorts by created_at, coming_id or date_time.
+// Undated ones are sorted by created_at, date_from and date_to apply to date_time
+var comingTableList = listSpec{
+	key:      listColumn{`"id"`, "uuid"},
+	fallback: "-created_at",
+	sorts: map[string][]listColumn{
+		"created_at": {{`"created_at"`, "timestamp"}},
+		"coming_id":  {{`"coming_id"`, "text"}},
+		"date_time":  {{`COALESCE("date_time", "created_at")`, "timestamp"}},
+	},
+	filters: map[string]listColumn{
+		"coming_id":         {`"coming_id"`, "text"},
+		"branch_id":         {`"branch_id"`, "uuid"},
+		"supplier_id":       {`"supplier_id"`, "uuid"},
+		"purchase_order_id": {`"purchase_order_id"`, "uuid"},
+		"status":            {`"status"::text`, "text"},
+		"date_time":         {`"date_time"`, "timestamp"},
+		"created_at":        {`"created_at"`, "timestamp"},
+		"updated_at":        {`"updated_at"`, "timestamp"},
+	},
+	date: "date_time",
+}
+
 func (r *comingTableRepo) GetList(req *models.ComingTableGetListRequest) (*models.ComingTableGetListResponse, error) {
-	params := make(map[string]interface{})
+	page, err := newListPage(comingTableList, req.Page, req.Limit, req.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
 	var resp = &models.ComingTableGetListResponse{}
 
 	resp.ComingTables = make([]*models.ComingTable, 0)
 
-	filter := " WHERE true "
+	filter, params, err := comingTableFilter(req)
+	if err != nil {
+		return nil, err
+	}
 	query := `
 			SELECT
-				COUNT(*) OVER(),
+				` + page.countColumn() + `,
 				"id", 
 				"coming_id",
 				"branch_id",
+				"supplier_id",
+				"purchase_order_id",
 				"date_time",
 				"status",
 				"created_at",
 				"updated_at" 
 			FROM "coming_table"
 		`
-	if req.ComingId != "" {
-		filter += ` AND ("coming_id" = :coming_id)`
-		params["coming_id"] = req.ComingId
-	}
 
-	if req.BranchId != "" {
-		filter += ` AND ("branch_id" = :branch_id)`
-		params["branch_id"] = req.BranchId
-	}
-
-	offset := (req.Page - 1) * req.Limit
-	params["limit"] = req.Limit
-	params["offset"] = offset
-
-	query = query + filter + " ORDER BY created_at DESC OFFSET :offset LIMIT :limit "
+	query = page.query(query+filter, params)
 	rquery, pArr := helper.ReplaceQueryParams(query, params)
 
 	rows, err := r.db.Query(context.Background(), rquery, pArr...)
@@ -144,38 +180,45 @@ func (r *comingTableRepo) GetList(req *models.ComingTableGetListRequest) (*model
 
 	for rows.Next() {
 		var (
-			id         sql.NullString
-			coming_id  sql.NullString
-			branch_id  sql.NullString
-			date_time  sql.NullTime
-			status     sql.NullString
-			created_at sql.NullString
-			updated_at sql.NullString
+			id                sql.NullString
+			coming_id         sql.NullString
+			branch_id         sql.NullString
+			supplier_id       sql.NullString
+			purchase_order_id sql.NullString
+			date_time         sql.NullTime
+			status            sql.NullString
+			created_at        sql.NullString
+			updated_at        sql.NullString
 		)
-		err := rows.Scan(
+		err := rows.Scan(page.dest(
 			&resp.Count,
 			&id,
 			&coming_id,
 			&branch_id,
+			&supplier_id,
+			&purchase_order_id,
 			&date_time,
 			&status,
 			&created_at,
 			&updated_at,
-		)
+		)...)
 		if err != nil {
 			return nil, err
 		}
 
 		resp.ComingTables = append(resp.ComingTables, &models.ComingTable{
-			Id:        id.String,
-			ComingId:  coming_id.String,
-			BranchId:  branch_id.String,
-			DateTime:  date_time.Time.Format(time.DateTime),
-			Status:    status.String,
-			CreatedAt: created_at.String,
-			UpdatedAt: updated_at.String,
+			Id:              id.String,
+			ComingId:        coming_id.String,
+			BranchId:        branch_id.String,
+			SupplierId:      supplier_id.String,
+			PurchaseOrderId: purchase_order_id.String,
+			DateTime:        date_time.Time.Format(time.DateTime),
+			Status:          status.String,
+			CreatedAt:       created_at.String,
+			UpdatedAt:       updated_at.String,
 		})
 	}
+	resp.NextCursor = page.next()
 	return resp, nil
 }
 
@@ -185,21 +228,31 @@ func (r *comingTableRepo) Update(req *models.UpdateComingTable) (string, error)
 		params map[string]interface{}
 	)
 
+	err := checkPeriod(context.Background(), r.db, req.BranchId, req.DateTime)
+	if err != nil {
+		return "", err
+	}
+
 	query = `
 		UPDATE
 			"coming_table"
 		SET
-				"coming_id" = :coming_id
-				"branch_id" = :branch_id
-				"date_time" = :date_time
+				"coming_id" = :coming_id,
+				"branch_id" = :branch_id,
+				"supplier_id" = :supplier_id,
+				"date_time" = :date_time,
 				"updated_at" = NOW()
-				WHERE id = :id
+				WHERE id = :id AND "status"::text IN (:statuses)
+					AND ` + openPeriod(`"coming_table"."branch_id"`, `"coming_table"."date_time"`) + `
 	`
 
 	params = map[string]interface{}{
-		"id":        req.Id,
-		"coming_id": req.ComingId,
-		"branch_id": req.BranchId,
+		"id":          req.Id,
+		"coming_id":   req.ComingId,
+		"branch_id":   req.BranchId,
+		"supplier_id": helper.NewNullString(req.SupplierId),
+		"date_time":   req.DateTime,
+		"statuses":    models.ComingTableEditableStatuses,
 	}
 
 	query, args := helper.ReplaceQueryParams(query, params)
@@ -210,7 +263,7 @@ func (r *comingTableRepo) Update(req *models.UpdateComingTable) (string, error)
 	}
 
 	if result.RowsAffected() == 0 {
-		return "", fmt.Errorf("coming_table with ID %s not found", req.Id)
+		return "", comingTableStatusError(context.Background(), r.db, req.Id, "")
 	}
 
 	return req.Id, nil
@@ -219,20 +272,32 @@ func (r *comingTableRepo) Update(req *models.UpdateComingTable) (string, error)
 func (r *comingTableRepo) Delete(req *models.ComingTablePrimaryKey) error {
 	ctx := context.Background()
 
-	result, err := r.db.Exec(ctx, "DELETE FROM coming_table WHERE id = $1", req.Id)
+	query := `
+		DELETE FROM "coming_table"
+		WHERE id = $1 AND "status" NOT IN ('posted', 'reversed')
+			AND ` + openPeriod(`"coming_table"."branch_id"`, `"coming_table"."date_time"`) + `
+	`
+
+	result, err := r.db.Exec(ctx, query, req.Id)
 	if err != nil {
 		return err
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("coming_table with ID %s not found", req.Id)
-
+		return comingTableStatusError(ctx, r.db, req.Id, "")
 	}
 
 	return nil
 }
 
-func (r *comingTableRepo) UpdateStatus(req *models.ComingTablePrimaryKey) (string, error) {
+// UpdateStatus moves coming_table to the requested status when transition is allowed,
+// posting and reversal have their own operations because they change remaining
+func (r *comingTableRepo) UpdateStatus(req *models.UpdateComingTableStatus) (string, error) {
+	ctx := context.Background()
+
+	if req.Status == models.ComingTablePosted || req.Status == models.ComingTableReversed {
+		return "", fmt.Errorf("coming_table can not be %s by status update", req.Status)
+	}
 
 	query := `
 		UPDATE
@@ -240,21 +305,38 @@ func (r *comingTableRepo) UpdateStatus(req *models.ComingTablePrimaryKey) (strin
 		SET
 				"status" = $1,
 				"updated_at" = NOW()
-				WHERE id = $2
+				WHERE id = $2 AND "status"::text = ANY($3)
+					AND ` + openPeriod(`"coming_table"."branch_id"`, `"coming_table"."date_time"`) + `
 	`
 
-	result, err := r.db.Exec(context.Background(), query, "finished", req.Id)
+	result, err := r.db.Exec(ctx, query, req.Status, req.Id, models.ComingTableSources(req.Status))
 	if err != nil {
 		return "", err
 	}
 
 	if result.RowsAffected() == 0 {
-		return "", fmt.Errorf("coming_table with ID %s not found", req.Id)
+		return "", comingTableStatusError(ctx, r.db, req.Id, req.Status)
 	}
 
 	return req.Id, nil
 }
 
+// StartReceiving moves draft coming_table to receiving, other statuses are left as is
+func (r *comingTableRepo) StartReceiving(req *models.ComingTablePrimaryKey) error {
+	query := `
+		UPDATE
+			"coming_table"
+		SET
+			"status" = 'receiving',
+			"updated_at" = NOW()
+		WHERE id = $1 AND "status" = 'draft'
+	`
+
+	_, err := r.db.Exec(context.Background(), query, req.Id)
+	return err
+}
+
+// GetStatus returns branch_id of coming_table when its products still may be changed
 func (r *comingTableRepo) GetStatus(req *models.ComingTablePrimaryKey) (string, error) {
 	var status sql.NullString
 	var branch_id sql.NullString
@@ -280,9 +362,471 @@ func (r *comingTableRepo) GetStatus(req *models.ComingTablePrimaryKey) (string,
 		return "", err
 	}
 
-	if status.String == "finished" {
-		return "", fmt.Errorf("coming table already finished")
+	if !isEditable(status.String) {
+		return "", &storage.StatusError{Document: "coming_table", Id: req.Id, Status: status.String}
 	}
 
 	return branch_id.String, nil
 }
+
+// Post writes income stock movements of coming_table products, adds them to remaining
+// and refreshes received quantities of its purchase order
+func (r *comingTableRepo) Post(req *models.ComingTablePrimaryKey) error {
+	ctx := context.Background()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = r.lockForTransition(ctx, tx, req.Id, models.ComingTablePosted)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO "stock_movement"(
+			"id",
+			"branch_id",
+			"category_id",
+			"name",
+			"price",
+			"barcode",
+			"count",
+			"total_price",
+			"product_id",
+			"type",
+			"document_id",
+			"date_time",
+			"created_at")
+		SELECT
+			gen_random_uuid(),
+			ct."branch_id",
+			(array_agg(ctp."category_id"))[1],
+			MAX(ctp."name"),
+			MAX(ctp."price"),
+			ctp."barcode",
+			SUM(ctp."count"),
+			SUM(ctp."total_price"),
+			COALESCE((array_agg(ctp."product_id") FILTER (WHERE ctp."product_id" IS NOT NULL))[1], ` + productIdByBarcode(`ctp."barcode"`) + `),
+			'income',
+			ct."id",
+			COALESCE(ct."date_time", NOW()),
+			NOW()
+		FROM "coming_table_product" AS ctp
+		JOIN "coming_table" AS ct ON ct."id" = ctp."coming_table_id"
+		WHERE ct."id" = $1
+		GROUP BY ct."id", ct."branch_id", ct."date_time", ctp."barcode"
+	`
+
+	result, err := tx.Exec(ctx, query, req.Id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("coming_table with ID %s has no products", req.Id)
+	}
+
+	err = applyStockMovements(ctx, tx, req.Id, movementIncome)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE
+			"coming_table"
+		SET
+			"status" = 'posted',
+			"posted_at" = NOW(),
+			"updated_at" = NOW()
+		WHERE id = $1
+	`
+
+	_, err = tx.Exec(ctx, query, req.Id)
+	if err != nil {
+		return err
+	}
+
+	err = refreshPurchaseOrder(ctx, tx, req.Id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Reverse writes compensating movements for posted coming_table, subtracts them from remaining
+// and refreshes received quantities of its purchase order
+func (r *comingTableRepo) Reverse(req *models.ComingTablePrimaryKey) error {
+	ctx := context.Background()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	err = r.lockForTransition(ctx, tx, req.Id, models.ComingTableReversed)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO "stock_movement"(
+			"id",
+			"branch_id",
+			"category_id",
+			"name",
+			"price",
+			"barcode",
+			"count",
+			"total_price",
+			"product_id",
+			"type",
+			"document_id",
+			"date_time",
+			"created_at")
+		SELECT
+			gen_random_uuid(),
+			"branch_id",
+			"category_id",
+			"name",
+			"price",
+			"barcode",
+			-"count",
+			-"total_price",
+			"product_id",
+			'income_reversal',
+			"document_id",
+			NOW(),
+			NOW()
+		FROM "stock_movement"
+		WHERE "document_id" = $1 AND "type" = 'income'
+	`
+
+	result, err := tx.Exec(ctx, query, req.Id)
+	if err != nil {
+		return err
+	}
+
+	// without income movements nothing would be taken out of remaining while coming_table is marked reversed
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("coming_table with ID %s has no income movements to reverse", req.Id)
+	}
+
+	err = applyStockMovements(ctx, tx, req.Id, movementIncomeReversal)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE
+			"coming_table"
+		SET
+			"status" = 'reversed',
+			"reversed_at" = NOW(),
+			"updated_at" = NOW()
+		WHERE id = $1
+	`
+
+	_, err = tx.Exec(ctx, query, req.Id)
+	if err != nil {
+		return err
+	}
+
+	err = refreshPurchaseOrder(ctx, tx, req.Id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// lockForTransition locks coming_table row and checks that it may move to status
+// and that its date is not inside closed period
+func (r *comingTableRepo) lockForTransition(ctx context.Context, tx pgx.Tx, id, status string) error {
+	var current sql.NullString
+
+	query := `
+		SELECT
+			"status"
+		FROM "coming_table"
+		WHERE "id" = $1
+		FOR UPDATE
+	`
+
+	err := tx.QueryRow(ctx, query, id).Scan(&current)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("coming_table with ID %s not found", id)
+		}
+		return err
+	}
+
+	for _, target := range models.ComingTableTransitions[current.String] {
+		if target == status {
+			return checkComingTablePeriod(ctx, tx, id)
+		}
+	}
+
+	return &storage.StatusError{Document: "coming_table", Id: id, Status: current.String, Target: status}
+}
+
+// comingTableStatusError explains why coming_table was not changed: it is missing,
+// dated inside closed period or its status does not allow it
+func comingTableStatusError(ctx context.Context, q querier, id, target string) error {
+	var status sql.NullString
+
+	err := q.QueryRow(ctx, `SELECT "status" FROM "coming_table" WHERE "id" = $1`, id).Scan(&status)
+	if err != nil {
+		return fmt.Errorf("coming_table with ID %s not found", id)
+	}
+
+	err = checkComingTablePeriod(ctx, q, id)
+	if err != nil {
+		return err
+	}
+
+	return &storage.StatusError{Document: "coming_table", Id: id, Status: status.String, Target: target}
+}
+
+func isEditable(status string) bool {
+	for _, editable := range models.ComingTableEditableStatuses {
+		if status == editable {
+			return true
+		}
+	}
+	return false
+}
+
+// GetFull returns coming_table with branch, supplier, products with category names and totals
+func (r *comingTableRepo) GetFull(req *models.ComingTablePrimaryKey) (*models.ComingTableFull, error) {
+	comingTable, err := r.GetByID(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.ComingTableFull{
+		ComingTable: comingTable,
+		Products:    make([]*models.ComingTableFullProduct, 0),
+		Categories:  make([]*models.ComingTableCategoryTotal, 0),
+	}
+
+	if comingTable.BranchId != "" {
+		resp.Branch, err = NewBranchRepo(r.db).GetByID(&models.BranchPrimaryKey{Id: comingTable.BranchId})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if comingTable.SupplierId != "" {
+		resp.Supplier, err = NewSupplierRepo(r.db).GetByID(&models.SupplierPrimaryKey{Id: comingTable.SupplierId})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	query := `
+		SELECT
+			ctp."id",
+			ctp."category_id",
+			c."name",
+			ctp."name",
+			ctp."price",
+			ctp."barcode",
+			ctp."count",
+			ctp."total_price",
+			ctp."coming_table_id",
+			ctp."created_at",
+			ctp."updated_at"
+		FROM ` + comingLinesResolved + ` AS ctp
+		LEFT JOIN "category" AS c ON c."id" = ctp."category_id"
+		WHERE ctp."coming_table_id" = $1
+		ORDER BY c."name", ctp."created_at"
+	`
+
+	rows, err := r.db.Query(context.Background(), query, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := make(map[string]*models.ComingTableCategoryTotal)
+	for rows.Next() {
+		var (
+			id              sql.NullString
+			category_id     sql.NullString
+			category_name   sql.NullString
+			name            sql.NullString
+			price           sql.NullFloat64
+			barcode         sql.NullString
+			count           sql.NullInt64
+			total_price     sql.NullFloat64
+			coming_table_id sql.NullString
+			created_at      sql.NullString
+			updated_at      sql.NullString
+		)
+
+		err := rows.Scan(
+			&id,
+			&category_id,
+			&category_name,
+			&name,
+			&price,
+			&barcode,
+			&count,
+			&total_price,
+			&coming_table_id,
+			&created_at,
+			&updated_at,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Products = append(resp.Products, &models.ComingTableFullProduct{
+			ComingTableProduct: &models.ComingTableProduct{
+				Id:             id.String,
+				CategoryId:     category_id.String,
+				ProductName:    name.String,
+				ProductPrice:   price.Float64,
+				ProductBarcode: barcode.String,
+				Count:          int(count.Int64),
+				TotalPrice:     total_price.Float64,
+				ComingTableId:  coming_table_id.String,
+				CreatedAt:      created_at.String,
+				UpdatedAt:      updated_at.String,
+			},
+			CategoryName: category_name.String,
+		})
+
+		category, ok := categories[category_id.String]
+		if !ok {
+			category = &models.ComingTableCategoryTotal{
+				CategoryId:   category_id.String,
+				CategoryName: category_name.String,
+			}
+			categories[category_id.String] = category
+			resp.Categories = append(resp.Categories, category)
+		}
+		category.Count += int(count.Int64)
+		category.TotalPrice += total_price.Float64
+
+		resp.Count += int(count.Int64)
+		resp.TotalPrice += total_price.Float64
+	}
+
+	return resp, rows.Err()
+}
+
+// comingTableFilter builds WHERE clause of list filters, it is shared by GetList and Export
+func comingTableFilter(req *models.ComingTableGetListRequest) (string, map[string]interface{}, error) {
+	params := make(map[string]interface{})
+	filter := " WHERE true "
+
+	if req.ComingId != "" {
+		filter += ` AND ("coming_id" = :coming_id)`
+		params["coming_id"] = req.ComingId
+	}
+
+	if req.BranchId != "" {
+		filter += ` AND ("branch_id" = :branch_id)`
+		params["branch_id"] = req.BranchId
+	}
+
+	if req.SupplierId != "" {
+		filter += ` AND ("supplier_id" = :supplier_id)`
+		params["supplier_id"] = req.SupplierId
+	}
+
+	where, err := listWhere(comingTableList, req.ListOptions, params)
+	return filter + where, params, err
+}
+
+// Export streams all coming tables matching list filters with branch and supplier names to fn
+func (r *comingTableRepo) Export(req *models.ComingTableGetListRequest, fn func(*models.ComingTable, *models.ExportNames) error) error {
+	filter, params, err := comingTableFilter(req)
+	if err != nil {
+		return err
+	}
+	order, err := listOrder(comingTableList, req.Sort)
+	if err != nil {
+		return err
+	}
+	query := `
+		SELECT
+			ct."id",
+			ct."coming_id",
+			ct."branch_id",
+			b."name",
+			ct."supplier_id",
+			s."name",
+			ct."purchase_order_id",
+			ct."date_time",
+			ct."status",
+			ct."created_at",
+			ct."updated_at"
+		FROM (SELECT *, ROW_NUMBER() OVER (ORDER BY ` + order + `) AS "list_row" FROM "coming_table" ` + filter + `) AS ct
+		LEFT JOIN "branch" AS b ON b."id" = ct."branch_id"
+		LEFT JOIN "supplier" AS s ON s."id" = ct."supplier_id"
+		ORDER BY ct."list_row"
+	`
+	rquery, pArr := helper.ReplaceQueryParams(query, params)
+
+	rows, err := r.db.Query(context.Background(), rquery, pArr...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id                sql.NullString
+			coming_id         sql.NullString
+			branch_id         sql.NullString
+			branch_name       sql.NullString
+			supplier_id       sql.NullString
+			supplier_name     sql.NullString
+			purchase_order_id sql.NullString
+			date_time         sql.NullTime
+			status            sql.NullString
+			created_at        sql.NullString
+			updated_at        sql.NullString
+		)
+		err := rows.Scan(
+			&id,
+			&coming_id,
+			&branch_id,
+			&branch_name,
+			&supplier_id,
+			&supplier_name,
+			&purchase_order_id,
+			&date_time,
+			&status,
+			&created_at,
+			&updated_at,
+		)
+		if err != nil {
+			return err
+		}
+
+		err = fn(&models.ComingTable{
+			Id:              id.String,
+			ComingId:        coming_id.String,
+			BranchId:        branch_id.String,
+			SupplierId:      supplier_id.String,
+			PurchaseOrderId: purchase_order_id.String,
+			DateTime:        date_time.Time.Format(time.DateTime),
+			Status:          status.String,
+			CreatedAt:       created_at.String,
+			UpdatedAt:       updated_at.String,
+		}, &models.ExportNames{BranchName: branch_name.String, SupplierName: supplier_name.String})
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -7,11 +7,23 @@ import (
 	"fmt"
 	"market/models"
 	"market/pkg/helper"
+	"market/storage"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
+// editableComingTable matches coming_table while its status allows changes of products and its period is open,
+// the row is share locked so posting waits until the change is committed
+func editableComingTable(comingTableId, statuses string) string {
+	return `
+		SELECT 1 FROM "coming_table"
+		WHERE "id" = ` + comingTableId + ` AND "status"::text = ANY(` + statuses + `)
+			AND ` + openPeriod(`"coming_table"."branch_id"`, `"coming_table"."date_time"`) + `
+		FOR SHARE`
+}
+
 type comingTableProduct struct {
 	db *pgxpool.Pool
 }
@@ -37,10 +49,12 @@ func (r *comingTableProduct) Create(req *models.CreateComingTableProduct) (strin
 					"count",
 					"total_price",
 					"coming_table_id",
+					"product_id",
 					"created_at")
-				VALUES ($1, $2, $3, $4, $5, $6, $7, $8,  NOW())`
+				SELECT $1::uuid, $2::uuid, $3, $4::numeric, $5, $6::numeric, $7::numeric, $8::uuid, ` + productIdByBarcode("$5") + `, NOW()
+				WHERE EXISTS (` + editableComingTable("$8", "$9") + `)`
 
-	_, err := r.db.Exec(context.Background(), query,
+	result, err := r.db.Exec(context.Background(), query,
 		id,
 		req.CategoryId,
 		req.ProductName,
@@ -49,10 +63,15 @@ func (r *comingTableProduct) Create(req *models.CreateComingTableProduct) (strin
 		req.Count,
 		req.TotalPrice,
 		req.ComingTableId,
+		models.ComingTableEditableStatuses,
 	)
 
 	if err != nil {
-		return "", err
+		return "", constraintError(err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return "", comingTableStatusError(context.Background(), r.db, req.ComingTableId, "")
 	}
 
 	return id, nil
@@ -69,6 +88,8 @@ func (r *comingTableProduct) GetByID(req *models.ComingTableProductPrimaryKey) (
 		count           sql.NullInt16
 		total_price     sql.NullFloat64
 		coming_table_id sql.NullString
+		product_id      sql.NullString
+		version         int
 		created_at      sql.NullString
 		updated_at      sql.NullString
 	)
@@ -83,9 +104,11 @@ func (r *comingTableProduct) GetByID(req *models.ComingTableProductPrimaryKey) (
 					"count",
 					"total_price",
 					"coming_table_id",
-					"created_at"
+					"product_id",
+					"version",
+					"created_at",
 					"updated_at" 
-			FROM "coming_table_product"
+			FROM ` + comingLinesResolved + ` AS "coming_table_product"
 			WHERE id = $1 `
 
 	err := r.db.QueryRow(context.Background(), query, req.Id).Scan(
@@ -97,6 +120,8 @@ func (r *comingTableProduct) GetByID(req *models.ComingTableProductPrimaryKey) (
 		&count,
 		&total_price,
 		&coming_table_id,
+		&product_id,
+		&version,
 		&created_at,
 		&updated_at,
 	)
@@ -107,6 +132,7 @@ func (r *comingTableProduct) GetByID(req *models.ComingTableProductPrimaryKey) (
 
 	return &models.ComingTableProduct{
 		Id:             id.String,
+		ProductId:      product_id.String,
 		CategoryId:     category_id.String,
 		ProductName:    name.String,
 		ProductPrice:   price.Float64,
@@ -114,21 +140,57 @@ func (r *comingTableProduct) GetByID(req *models.ComingTableProductPrimaryKey) (
 		Count:          int(count.Int16),
 		TotalPrice:     total_price.Float64,
 		ComingTableId:  coming_table_id.String,
+		Version:        version,
 		CreatedAt:      created_at.String,
 		UpdatedAt:      updated_at.String,
 	}, nil
 }
 
+// comingTableProductList are sorts and filters of coming table product list,
+// sorts by created_at, name, price, count, total_price or barcode
+var comingTableProductList = listSpec{
+	key:      listColumn{`"id"`, "uuid"},
+	fallback: "-created_at",
+	sorts: map[string][]listColumn{
+		"created_at":  {{`"created_at"`, "timestamp"}},
+		"name":        {{`"name"`, "text"}},
+		"price":       {{`"price"`, "numeric"}},
+		"count":       {{`"count"`, "numeric"}},
+		"total_price": {{`COALESCE("total_price", 0)`, "numeric"}},
+		"barcode":     {{`"barcode"`, "text"}},
+	},
+	filters: map[string]listColumn{
+		"name":            {`"name"`, "text"},
+		"barcode":         {`"barcode"`, "text"},
+		"price":           {`"price"`, "numeric"},
+		"count":           {`"count"`, "numeric"},
+		"total_price":     {`"total_price"`, "numeric"},
+		"category_id":     {`"category_id"`, "uuid"},
+		"product_id":      {`"product_id"`, "uuid"},
+		"coming_table_id": {`"coming_table_id"`, "uuid"},
+		"created_at":      {`"created_at"`, "timestamp"},
+		"updated_at":      {`"updated_at"`, "timestamp"},
+	},
+	date: "created_at",
+}
+
 func (r *comingTableProduct) GetList(req *models.ComingTableProductGetListRequest) (*models.ComingTableProductGetListResponse, error) {
-	params := make(map[string]interface{})
+	page, err := newListPage(comingTableProductList, req.Page, req.Limit, req.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
 	var resp = &models.ComingTableProductGetListResponse{}
 
 	resp.ComingTableProducts = make([]*models.ComingTableProduct, 0)
 
-	filter := " WHERE true "
+	filter, params, err := comingTableProductFilter(req)
+	if err != nil {
+		return nil, err
+	}
 	query := `
 			SELECT
-				COUNT(*) OVER(),
+				` + page.countColumn() + `,
 				"id",
 				"category_id",
 				"name",
@@ -137,25 +199,14 @@ func (r *comingTableProduct) GetList(req *models.ComingTableProductGetListReques
 				"count",
 				"total_price",
 				"coming_table_id",
+				"product_id",
+				"version",
 				"created_at",
 				"updated_at" 
-			FROM "coming_table_product"
+			FROM ` + comingLinesResolved + ` AS "coming_table_product"
 		`
-	if req.CategoryId != "" {
-		filter += ` AND ("category_id" = :category_id)`
-		params["category_id"] = req.CategoryId
-	}
-
-	if req.ProductBarcode != "" {
-		filter += ` AND ("barcode" = :barcode)`
-		params["barcode"] = req.ProductBarcode
-	}
 
-	offset := (req.Page - 1) * req.Limit
-	params["limit"] = req.Limit
-	params["offset"] = offset
-
-	query = query + filter + " ORDER BY created_at DESC OFFSET :offset LIMIT :limit "
+	query = page.query(query+filter, params)
 	rquery, pArr := helper.ReplaceQueryParams(query, params)
 
 	rows, err := r.db.Query(context.Background(), rquery, pArr...)
@@ -174,11 +225,13 @@ func (r *comingTableProduct) GetList(req *models.ComingTableProductGetListReques
 			count           sql.NullInt16
 			total_price     sql.NullFloat64
 			coming_table_id sql.NullString
+			product_id      sql.NullString
+			version         int
 			created_at      sql.NullString
 			updated_at      sql.NullString
 		)
 
-		err := rows.Scan(
+		err := rows.Scan(page.dest(
 			&resp.Count,
 			&id,
 			&category_id,
@@ -188,15 +241,18 @@ func (r *comingTableProduct) GetList(req *models.ComingTableProductGetListReques
 			&count,
 			&total_price,
 			&coming_table_id,
+			&product_id,
+			&version,
 			&created_at,
 			&updated_at,
-		)
+		)...)
 		if err != nil {
 			return nil, err
 		}
 
 		resp.ComingTableProducts = append(resp.ComingTableProducts, &models.ComingTableProduct{
 			Id:             id.String,
+			ProductId:      product_id.String,
 			CategoryId:     category_id.String,
 			ProductName:    name.String,
 			ProductPrice:   price.Float64,
@@ -204,46 +260,56 @@ func (r *comingTableProduct) GetList(req *models.ComingTableProductGetListReques
 			Count:          int(count.Int16),
 			TotalPrice:     total_price.Float64,
 			ComingTableId:  coming_table_id.String,
+			Version:        version,
 			CreatedAt:      created_at.String,
 			UpdatedAt:      updated_at.String,
 		})
 	}
+	resp.NextCursor = page.next()
 	return resp, nil
 }
 
+// Update replaces product of editable coming_table, with req.Version set it is applied only to that version of the row
+// Update changes barcode and count of the line, name, category and price are taken from the product the same
+// way create does, line of unknown barcode keeps its stored ones, so total_price is never given by the client
 func (r *comingTableProduct) Update(req *models.UpdateComingTableProduct) (string, error) {
+	ctx := context.Background()
 
 	query := `
 		UPDATE
 			"coming_table_product"
 		SET
-				"category_id" = $1,
-				"name" = $2,
-				"price" = $3,
-				"barcode" = $4,
-				"count" = $5,
-				"total_price" = $6,
-				"coming_table_id" = $7,
+				"category_id" = COALESCE(p."category_id", "coming_table_product"."category_id"),
+				"name" = COALESCE(p."name", "coming_table_product"."name"),
+				"price" = COALESCE(p."price", "coming_table_product"."price"),
+				"barcode" = b."barcode",
+				"count" = $2,
+				"total_price" = COALESCE(p."price", "coming_table_product"."price") * $2,
+				"product_id" = p."id",
 				"updated_at" = NOW()
-				WHERE id = $8
+		FROM (SELECT $1::varchar AS "barcode") AS b
+		LEFT JOIN "product" AS p ON p."barcode" = b."barcode"
+				WHERE "coming_table_product"."id" = $3 AND ($5 = 0 OR "version" = $5)
+					AND EXISTS (` + editableComingTable(`"coming_table_product"."coming_table_id"`, "$4") + `)
+		RETURNING "version", "coming_table_product"."price", "coming_table_product"."total_price"
 	`
 
-	result, err := r.db.Exec(context.Background(), query,
-		req.CategoryId,
-		req.ProductName,
-		req.ProductPrice,
+	err := r.db.QueryRow(ctx, query,
 		req.ProductBarcode,
 		req.Count,
-		req.TotalPrice,
-		req.ComingTableId,
 		req.Id,
-	)
-	if err != nil {
-		return "", err
+		models.ComingTableEditableStatuses,
+		req.Version,
+	).Scan(&req.Version, &req.ProductPrice, &req.TotalPrice)
+	if err == pgx.ErrNoRows {
+		err = versionError(ctx, r.db, "coming_table_product", req.Id, req.Version)
+		if err != nil {
+			return "", err
+		}
+		return "", r.statusError(req.Id)
 	}
-
-	if result.RowsAffected() == 0 {
-		return "", fmt.Errorf("coming_table_product with ID %s not found", req.Id)
+	if err != nil {
+		return "", constraintError(err)
 	}
 
 	return req.Id, nil
@@ -252,76 +318,76 @@ func (r *comingTableProduct) Update(req *models.UpdateComingTableProduct) (strin
 func (r *comingTableProduct) Delete(req *models.ComingTableProductPrimaryKey) error {
 	ctx := context.Background()
 
-	result, err := r.db.Exec(ctx, "DELETE FROM coming_table_product WHERE id = $1", req.Id)
+	query := `
+		DELETE FROM "coming_table_product"
+		WHERE id = $1 AND EXISTS (` + editableComingTable(`"coming_table_product"."coming_table_id"`, "$2") + `)
+	`
+
+	result, err := r.db.Exec(ctx, query, req.Id, models.ComingTableEditableStatuses)
 	if err != nil {
 		return err
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("coming_table_product with ID %s not found", req.Id)
-
+		return r.statusError(req.Id)
 	}
 
 	return nil
 }
 
-func (r *comingTableProduct) CheckExistProduct(req *models.ComingTableProductBarcode) (string, error) {
-	var id sql.NullString
-
-	query := `
-		SELECT
-			"id"
-		FROM "coming_table_product"
-		WHERE "barcode" = $1 and "coming_table_id" = $2`
-
-	err := r.db.QueryRow(context.Background(), query, req.Barcode, req.ComingTableId).Scan(&id)
-
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return "", errors.New("not found")
-		}
-		return "", err
-	}
-
-	return id.String, nil
-}
+// Upsert adds product to coming_table or adds count and total_price to the line of the same barcode in one statement,
+// created is false when existing line was updated
+func (r *comingTableProduct) Upsert(req *models.CreateComingTableProduct) (string, bool, error) {
+	var (
+		id      string
+		created bool
+	)
 
-func (r *comingTableProduct) UpdateIdExists(req *models.UpdateComingTableProduct) (string, error) {
 	query := `
-		UPDATE
-			"coming_table_product"
+		INSERT INTO "coming_table_product"(
+			"id",
+			"category_id",
+			"name",
+			"price",
+			"barcode",
+			"count",
+			"total_price",
+			"coming_table_id",
+			"product_id",
+			"created_at")
+		SELECT $1::uuid, $2::uuid, $3, $4::numeric, $5, $6::numeric, $7::numeric, $8::uuid, ` + productIdByBarcode("$5") + `, NOW()
+		WHERE EXISTS (` + editableComingTable("$8", "$9") + `)
+		ON CONFLICT ("coming_table_id", "barcode") DO UPDATE
 		SET
-			"category_id" = $1,
-			"barcode" = $2,
-			"name" = $3,
-			"price" = $4,
-			"count" = "count" + $5,
-			"total_price" = "total_price" + $6,
-			"coming_table_id" = $7,
+			"category_id" = EXCLUDED."category_id",
+			"name" = EXCLUDED."name",
+			"price" = EXCLUDED."price",
+			"count" = "coming_table_product"."count" + EXCLUDED."count",
+			"total_price" = "coming_table_product"."total_price" + EXCLUDED."total_price",
+			"product_id" = EXCLUDED."product_id",
 			"updated_at" = NOW()
-		WHERE
-			"id" = $8
+		RETURNING "id", (xmax = 0)
 	`
 
-	result, err := r.db.Exec(context.Background(), query,
-		req.CategoryId,
-		req.ProductBarcode,
+	err := r.db.QueryRow(context.Background(), query,
+		uuid.NewString(),
+		helper.NewNullString(req.CategoryId),
 		req.ProductName,
 		req.ProductPrice,
+		req.ProductBarcode,
 		req.Count,
 		req.TotalPrice,
 		req.ComingTableId,
-		req.Id,
-	)
+		models.ComingTableEditableStatuses,
+	).Scan(&id, &created)
 	if err != nil {
-		return "", err
-	}
-
-	if result.RowsAffected() == 0 {
-		return "", fmt.Errorf("coming_table_product with id %s not found", req.ComingTableId)
+		if err == pgx.ErrNoRows {
+			return "", false, comingTableStatusError(context.Background(), r.db, req.ComingTableId, "")
+		}
+		return "", false, constraintError(err)
 	}
 
-	return req.ComingTableId, nil
+	return id, created, nil
 }
 
 func (r *comingTableProduct) GetByComingTableId(req *models.ComingTableProductPrimaryKey) (*models.ComingTableProduct, error) {
@@ -345,9 +411,9 @@ func (r *comingTableProduct) GetByComingTableId(req *models.ComingTableProductPr
 					"barcode",
 					sum("count"),
 					sum("total_price")
-			FROM "coming_table_product"
+			FROM ` + comingLinesResolved + ` AS "coming_table_product"
 			WHERE "coming_table_id" = $1 
-			GROUP BY "id", "barcode"
+			GROUP BY "id", "category_id", "name", "price", "barcode"
 			`
 
 	err := r.db.QueryRow(context.Background(), query, req.Id).Scan(
@@ -374,3 +440,426 @@ func (r *comingTableProduct) GetByComingTableId(req *models.ComingTableProductPr
 		TotalPrice:     total_price.Float64,
 	}, nil
 }
+
+// statusError explains why product of coming_table was not changed: it is missing or its parent is locked
+func (r *comingTableProduct) statusError(id string) error {
+	var comingTableId sql.NullString
+
+	query := `
+		SELECT
+			"coming_table_id"
+		FROM "coming_table_product"
+		WHERE "id" = $1
+	`
+
+	err := r.db.QueryRow(context.Background(), query, id).Scan(&comingTableId)
+	if err != nil {
+		return fmt.Errorf("coming_table_product with ID %s not found", id)
+	}
+
+	return comingTableStatusError(context.Background(), r.db, comingTableId.String, "")
+}
+
+// CreateBulk adds scanned barcodes to coming_table in one transaction, merging them with existing products
+func (r *comingTableProduct) CreateBulk(req *models.CreateComingTableProductBulk) (*models.ComingTableProductBulkResponse, error) {
+	var (
+		ctx      = context.Background()
+		resp     = &models.ComingTableProductBulkResponse{Products: make([]*models.ComingTableProductScanResult, 0)}
+		barcodes = make([]string, 0, len(req.Products))
+		counts   = make(map[string]int)
+		prices   = make(map[string]float64)
+	)
+
+	// duplicated barcodes of one request are merged into one product, the last given price wins
+	for _, scan := range req.Products {
+		if _, ok := counts[scan.Barcode]; !ok {
+			barcodes = append(barcodes, scan.Barcode)
+		}
+		counts[scan.Barcode] += scan.Count
+		if scan.Price > 0 {
+			prices[scan.Barcode] = scan.Price
+		}
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = lockEditable(ctx, tx, req.ComingTableId)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := r.productsByBarcode(ctx, tx, barcodes)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, barcode := range barcodes {
+		result := &models.ComingTableProductScanResult{
+			Barcode: barcode,
+			Count:   counts[barcode],
+		}
+		resp.Products = append(resp.Products, result)
+
+		product, ok := products[barcode]
+		if !ok {
+			result.Result = models.ScanUnknown
+			resp.Unknown++
+			continue
+		}
+
+		price, ok := prices[barcode]
+		if !ok {
+			price = product.Price
+		}
+
+		result.Name = product.Name
+		result.TotalPrice = price * float64(result.Count)
+
+		var created bool
+
+		query := `
+			INSERT INTO "coming_table_product"(
+				"id",
+				"category_id",
+				"name",
+				"price",
+				"barcode",
+				"count",
+				"total_price",
+				"coming_table_id",
+				"product_id",
+				"created_at")
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, ` + productIdByBarcode("$5") + `, NOW())
+			ON CONFLICT ("coming_table_id", "barcode") DO UPDATE
+			SET
+				"category_id" = EXCLUDED."category_id",
+				"name" = EXCLUDED."name",
+				"price" = EXCLUDED."price",
+				"count" = "coming_table_product"."count" + EXCLUDED."count",
+				"total_price" = "coming_table_product"."total_price" + EXCLUDED."total_price",
+				"product_id" = EXCLUDED."product_id",
+				"updated_at" = NOW()
+			RETURNING "id", (xmax = 0)
+		`
+
+		err = tx.QueryRow(ctx, query,
+			uuid.NewString(),
+			helper.NewNullString(product.CategoryId),
+			product.Name,
+			price,
+			barcode,
+			result.Count,
+			result.TotalPrice,
+			req.ComingTableId,
+		).Scan(&result.Id, &created)
+		if err != nil {
+			return nil, constraintError(err)
+		}
+
+		result.Result = models.ScanUpdated
+		if created {
+			result.Result = models.ScanAdded
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// productsByBarcode resolves all barcodes with one query
+func (r *comingTableProduct) productsByBarcode(ctx context.Context, tx pgx.Tx, barcodes []string) (map[string]*models.ProductBarcodeResponse, error) {
+	products := make(map[string]*models.ProductBarcodeResponse)
+
+	query := `
+		SELECT
+			"barcode",
+			"name",
+			"price",
+			"category_id"
+		FROM "product"
+		WHERE "barcode" = ANY($1)
+	`
+
+	rows, err := tx.Query(ctx, query, barcodes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			barcode     sql.NullString
+			name        sql.NullString
+			price       sql.NullFloat64
+			category_id sql.NullString
+		)
+
+		err := rows.Scan(&barcode, &name, &price, &category_id)
+		if err != nil {
+			return nil, err
+		}
+
+		products[barcode.String] = &models.ProductBarcodeResponse{
+			Name:       name.String,
+			Price:      price.Float64,
+			CategoryId: category_id.String,
+		}
+	}
+
+	return products, rows.Err()
+}
+
+// CreateWithProduct adds product of unknown barcode as pending review and its coming_table product in one transaction,
+// the same barcode scanned concurrently reuses the product and adds to the line. The line takes id, name,
+// category and price of the product, so product created by someone else wins over the request
+func (r *comingTableProduct) CreateWithProduct(req *models.CreateComingTableProduct) (string, error) {
+	var (
+		ctx         = context.Background()
+		id          string
+		productId   sql.NullString
+		name        sql.NullString
+		price       sql.NullFloat64
+		category_id sql.NullString
+	)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	err = lockEditable(ctx, tx, req.ComingTableId)
+	if err != nil {
+		return "", err
+	}
+
+	// product is created inside savepoint, barcode taken meanwhile rolls back only the savepoint
+	productTx, err := tx.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	err = insertProduct(ctx, productTx, uuid.NewString(), &models.CreateProduct{
+		Name:       req.ProductName,
+		Price:      req.ProductPrice,
+		Barcode:    req.ProductBarcode,
+		CategoryId: req.CategoryId,
+	}, models.ProductPendingReview)
+	if err != nil {
+		var constraintErr *storage.ConstraintError
+		if !errors.As(err, &constraintErr) || constraintErr.Constraint != "product_barcode_key" {
+			return "", err
+		}
+		err = productTx.Rollback(ctx)
+	} else {
+		err = productTx.Commit(ctx)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	query := `
+		SELECT
+			"id",
+			"name",
+			"price",
+			"category_id"
+		FROM "product"
+		WHERE "barcode" = $1
+		FOR SHARE
+	`
+
+	err = tx.QueryRow(ctx, query, req.ProductBarcode).Scan(&productId, &name, &price, &category_id)
+	if err != nil {
+		return "", err
+	}
+
+	query = `
+		INSERT INTO "coming_table_product"(
+			"id",
+			"category_id",
+			"name",
+			"price",
+			"barcode",
+			"count",
+			"total_price",
+			"coming_table_id",
+			"product_id",
+			"created_at")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW())
+		ON CONFLICT ("coming_table_id", "barcode") DO UPDATE
+		SET
+			"count" = "coming_table_product"."count" + EXCLUDED."count",
+			"total_price" = "coming_table_product"."total_price" + EXCLUDED."total_price",
+			"updated_at" = NOW()
+		RETURNING "id"
+	`
+
+	err = tx.QueryRow(ctx, query,
+		uuid.NewString(),
+		category_id,
+		name.String,
+		price.Float64,
+		req.ProductBarcode,
+		req.Count,
+		price.Float64*float64(req.Count),
+		req.ComingTableId,
+		productId,
+	).Scan(&id)
+	if err != nil {
+		return "", constraintError(err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// lockEditable share locks coming_table inside transaction and checks that its products may change
+func lockEditable(ctx context.Context, tx pgx.Tx, comingTableId string) error {
+	var status sql.NullString
+
+	err := tx.QueryRow(ctx, `SELECT "status" FROM "coming_table" WHERE "id" = $1 FOR SHARE`, comingTableId).Scan(&status)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("coming_table with ID %s not found", comingTableId)
+		}
+		return err
+	}
+
+	if !isEditable(status.String) {
+		return &storage.StatusError{Document: "coming_table", Id: comingTableId, Status: status.String}
+	}
+
+	return checkComingTablePeriod(ctx, tx, comingTableId)
+}
+
+// comingTableProductFilter builds WHERE clause of list filters, it is shared by GetList and Export
+func comingTableProductFilter(req *models.ComingTableProductGetListRequest) (string, map[string]interface{}, error) {
+	params := make(map[string]interface{})
+	filter := " WHERE true "
+
+	if req.ComingTableId != "" {
+		filter += ` AND ("coming_table_id" = :coming_table_id)`
+		params["coming_table_id"] = req.ComingTableId
+	}
+
+	if req.CategoryId != "" {
+		filter += ` AND ("category_id" = :category_id)`
+		params["category_id"] = req.CategoryId
+	}
+
+	if req.ProductBarcode != "" {
+		filter += ` AND ("barcode" = :barcode)`
+		params["barcode"] = req.ProductBarcode
+	}
+
+	where, err := listWhere(comingTableProductList, req.ListOptions, params)
+	return filter + where, params, err
+}
+
+// Export streams all coming table products matching list filters with category names and coming ids to fn
+func (r *comingTableProduct) Export(req *models.ComingTableProductGetListRequest, fn func(*models.ComingTableProduct, *models.ExportNames) error) error {
+	filter, params, err := comingTableProductFilter(req)
+	if err != nil {
+		return err
+	}
+	order, err := listOrder(comingTableProductList, req.Sort)
+	if err != nil {
+		return err
+	}
+	query := `
+		SELECT
+			p."id",
+			p."category_id",
+			c."name",
+			p."name",
+			p."price",
+			p."barcode",
+			p."count",
+			p."total_price",
+			p."coming_table_id",
+			ct."coming_id",
+			p."product_id",
+			p."created_at",
+			p."updated_at"
+		FROM (SELECT *, ROW_NUMBER() OVER (ORDER BY ` + order + `) AS "list_row" FROM ` + comingLinesResolved + ` AS "coming_table_product" ` + filter + `) AS p
+		LEFT JOIN "category" AS c ON c."id" = p."category_id"
+		LEFT JOIN "coming_table" AS ct ON ct."id" = p."coming_table_id"
+		ORDER BY p."list_row"
+	`
+	rquery, pArr := helper.ReplaceQueryParams(query, params)
+
+	rows, err := r.db.Query(context.Background(), rquery, pArr...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id              sql.NullString
+			category_id     sql.NullString
+			category_name   sql.NullString
+			name            sql.NullString
+			price           sql.NullFloat64
+			barcode         sql.NullString
+			count           sql.NullInt64
+			total_price     sql.NullFloat64
+			coming_table_id sql.NullString
+			coming_id       sql.NullString
+			product_id      sql.NullString
+			created_at      sql.NullString
+			updated_at      sql.NullString
+		)
+
+		err := rows.Scan(
+			&id,
+			&category_id,
+			&category_name,
+			&name,
+			&price,
+			&barcode,
+			&count,
+			&total_price,
+			&coming_table_id,
+			&coming_id,
+			&product_id,
+			&created_at,
+			&updated_at,
+		)
+		if err != nil {
+			return err
+		}
+
+		err = fn(&models.ComingTableProduct{
+			Id:             id.String,
+			CategoryId:     category_id.String,
+			ProductName:    name.String,
+			ProductPrice:   price.Float64,
+			ProductBarcode: barcode.String,
+			Count:          int(count.Int64),
+			TotalPrice:     total_price.Float64,
+			ComingTableId:  coming_table_id.String,
+			ProductId:      product_id.String,
+			CreatedAt:      created_at.String,
+			UpdatedAt:      updated_at.String,
+		}, &models.ExportNames{CategoryName: category_name.String, ComingId: coming_id.String})
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,319 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"market/models"
+	"market/storage"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// testDB connects to database of POSTGRES_TEST_DSN migrated to the latest migration,
+// tests are skipped when it is not set
+func testDB(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN is not set")
+	}
+
+	db, err := pgxpool.Connect(context.Background(), dsn)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(db.Close)
+
+	return db
+}
+
+// testBranch adds branch whose stock rows and coming tables are deleted after the test
+func testBranch(t *testing.T, db *pgxpool.Pool) string {
+	t.Helper()
+
+	var (
+		ctx = context.Background()
+		id  = uuid.NewString()
+	)
+
+	_, err := db.Exec(ctx, `INSERT INTO "branch"("id", "name") VALUES ($1, $2)`, id, "test "+id)
+	if err != nil {
+		t.Fatalf("insert branch: %v", err)
+	}
+
+	t.Cleanup(func() {
+		queries := []string{
+			`DELETE FROM "stock_snapshot" WHERE "branch_id" = $1`,
+			`DELETE FROM "stock_movement" WHERE "branch_id" = $1`,
+			`DELETE FROM "remaining" WHERE "branch_id" = $1`,
+			`DELETE FROM "coming_table_product" WHERE "coming_table_id" IN (SELECT "id" FROM "coming_table" WHERE "branch_id" = $1)`,
+			`DELETE FROM "coming_table" WHERE "branch_id" = $1`,
+			`DELETE FROM "branch" WHERE "id" = $1`,
+		}
+		for _, query := range queries {
+			_, err := db.Exec(ctx, query, id)
+			if err != nil {
+				t.Errorf("cleanup: %v", err)
+			}
+		}
+	})
+
+	return id
+}
+
+// testComingTable adds receiving coming_table of the branch with one line of barcode
+func testComingTable(t *testing.T, db *pgxpool.Pool, branchId, barcode string, count int, price float64) string {
+	t.Helper()
+
+	var (
+		ctx = context.Background()
+		id  = uuid.NewString()
+	)
+
+	_, err := db.Exec(ctx, `
+		INSERT INTO "coming_table"("id", "coming_id", "branch_id", "date_time", "status")
+		VALUES ($1, $2, $3, NOW(), 'receiving')`,
+		id, "test "+id, branchId,
+	)
+	if err != nil {
+		t.Fatalf("insert coming_table: %v", err)
+	}
+
+	_, err = db.Exec(ctx, `
+		INSERT INTO "coming_table_product"("id", "name", "price", "barcode", "count", "total_price", "coming_table_id")
+		VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+		uuid.NewString(), "test "+barcode, price, barcode, count, float64(count)*price, id,
+	)
+	if err != nil {
+		t.Fatalf("insert coming_table_product: %v", err)
+	}
+
+	return id
+}
+
+// checkStock fails the test when remaining of the branch and barcode or sum of its movements differ from count and total
+func checkStock(t *testing.T, db *pgxpool.Pool, branchId, barcode string, count int, total float64) {
+	t.Helper()
+
+	var (
+		ctx                        = context.Background()
+		remainingCount, movedCount float64
+		remainingTotal, movedTotal float64
+	)
+
+	err := db.QueryRow(ctx, `
+		SELECT COALESCE(SUM("count"), 0), COALESCE(SUM("total_price"), 0)
+		FROM "remaining"
+		WHERE "branch_id" = $1 AND "barcode" = $2`,
+		branchId, barcode,
+	).Scan(&remainingCount, &remainingTotal)
+	if err != nil {
+		t.Fatalf("read remaining: %v", err)
+	}
+
+	err = db.QueryRow(ctx, `
+		SELECT COALESCE(SUM("count"), 0), COALESCE(SUM("total_price"), 0)
+		FROM "stock_movement"
+		WHERE "branch_id" = $1 AND "barcode" = $2`,
+		branchId, barcode,
+	).Scan(&movedCount, &movedTotal)
+	if err != nil {
+		t.Fatalf("read stock movements: %v", err)
+	}
+
+	if remainingCount != float64(count) || remainingTotal != total {
+		t.Errorf("remaining is %v for %v, want %v for %v", remainingCount, remainingTotal, count, total)
+	}
+	if movedCount != float64(count) || movedTotal != total {
+		t.Errorf("stock movements sum to %v for %v, want %v for %v", movedCount, movedTotal, count, total)
+	}
+}
+
+// run calls f n times at once and returns errors of the calls
+func run(n int, f func(i int) error) []error {
+	var (
+		wg    sync.WaitGroup
+		start = make(chan struct{})
+		errs  = make([]error, n)
+	)
+
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			errs[i] = f(i)
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	return errs
+}
+
+func TestRemainingUpsertConcurrent(t *testing.T) {
+	var (
+		db       = testDB(t)
+		repo     = NewRemainingRepo(db)
+		branchId = testBranch(t, db)
+		barcode  = "test-" + uuid.NewString()
+		n        = 20
+	)
+
+	errs := run(n, func(int) error {
+		_, err := repo.Upsert(&models.CreateRemaining{
+			BranchId:   branchId,
+			Name:       "test",
+			Price:      10,
+			Barcode:    barcode,
+			Count:      1,
+			TotalPrice: 10,
+		})
+		return err
+	})
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("upsert: %v", err)
+		}
+	}
+
+	var rows int
+	err := db.QueryRow(context.Background(),
+		`SELECT COUNT(*) FROM "remaining" WHERE "branch_id" = $1 AND "barcode" = $2`, branchId, barcode,
+	).Scan(&rows)
+	if err != nil {
+		t.Fatalf("count remaining: %v", err)
+	}
+	if rows != 1 {
+		t.Errorf("%d remaining rows, want 1", rows)
+	}
+
+	checkStock(t, db, branchId, barcode, n, float64(n)*10)
+}
+
+func TestRemainingUpdateStaleVersion(t *testing.T) {
+	var (
+		db       = testDB(t)
+		repo     = NewRemainingRepo(db)
+		branchId = testBranch(t, db)
+		barcode  = "test-" + uuid.NewString()
+	)
+
+	id, err := repo.Create(&models.CreateRemaining{
+		BranchId:   branchId,
+		Name:       "test",
+		Price:      10,
+		Barcode:    barcode,
+		Count:      5,
+		TotalPrice: 50,
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	remaining, err := repo.GetByID(&models.RemainingPrimaryKey{Id: id})
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+
+	// both writers read the same version, only one of them may apply it
+	errs := run(2, func(i int) error {
+		_, err := repo.Update(&models.UpdateRemaining{
+			Id:         id,
+			BranchId:   branchId,
+			Name:       "test",
+			Price:      10,
+			Barcode:    barcode,
+			Count:      6 + i,
+			TotalPrice: float64(6+i) * 10,
+			Version:    remaining.Version,
+		})
+		return err
+	})
+
+	var (
+		updated int
+		count   int
+	)
+	for i, err := range errs {
+		var versionErr *storage.VersionError
+		switch {
+		case err == nil:
+			updated++
+			count = 6 + i
+		case errors.As(err, &versionErr):
+			if versionErr.Expected != remaining.Version || versionErr.Version != remaining.Version+1 {
+				t.Errorf("version error %+v, want expected %d and version %d", versionErr, remaining.Version, remaining.Version+1)
+			}
+		default:
+			t.Fatalf("update: %v", err)
+		}
+	}
+	if updated != 1 {
+		t.Fatalf("%d updates applied, want 1", updated)
+	}
+
+	checkStock(t, db, branchId, barcode, count, float64(count)*10)
+}
+
+func TestComingTablePostReverseSameBarcode(t *testing.T) {
+	var (
+		db       = testDB(t)
+		repo     = NewComingTableRepo(db)
+		branchId = testBranch(t, db)
+		barcode  = "test-" + uuid.NewString()
+		n        = 10
+		ids      = make([]string, n)
+	)
+
+	for i := range ids {
+		ids[i] = testComingTable(t, db, branchId, barcode, 2, 10)
+	}
+
+	// the same coming table is posted once however many times it is posted at once
+	errs := run(3, func(int) error {
+		return repo.Post(&models.ComingTablePrimaryKey{Id: ids[0]})
+	})
+	posted := 0
+	for _, err := range errs {
+		var statusErr *storage.StatusError
+		switch {
+		case err == nil:
+			posted++
+		case !errors.As(err, &statusErr):
+			t.Fatalf("post: %v", err)
+		}
+	}
+	if posted != 1 {
+		t.Fatalf("coming table posted %d times, want 1", posted)
+	}
+
+	errs = run(n/2-1, func(i int) error {
+		return repo.Post(&models.ComingTablePrimaryKey{Id: ids[i+1]})
+	})
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("post: %v", err)
+		}
+	}
+	checkStock(t, db, branchId, barcode, n, float64(n)*20/2)
+
+	// first half is reversed while the second half is posted
+	errs = run(n, func(i int) error {
+		if i < n/2 {
+			return repo.Reverse(&models.ComingTablePrimaryKey{Id: ids[i]})
+		}
+		return repo.Post(&models.ComingTablePrimaryKey{Id: ids[i]})
+	})
+	for _, err := range errs {
+		if err != nil {
+			t.Fatalf("post or reverse: %v", err)
+		}
+	}
+	checkStock(t, db, branchId, barcode, n, float64(n)*20/2)
+}
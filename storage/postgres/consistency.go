@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"market/models"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// consistencyCheck finds rows of table t whose stored value differs from expected one of product p
+type consistencyCheck struct {
+	table    string
+	name     string
+	join     string
+	stored   string
+	expected string
+}
+
+// linkedJoin joins product linked to row, barcodeJoin joins product of barcode to unlinked row
+// and orphanJoin keeps unlinked rows whose barcode is unknown to catalog
+const (
+	linkedJoin  = `JOIN "product" AS p ON p."id" = t."product_id"`
+	barcodeJoin = `JOIN "product" AS p ON p."barcode" = t."barcode" AND t."product_id" IS NULL`
+	orphanJoin  = `LEFT JOIN "product" AS p ON p."barcode" = t."barcode"`
+)
+
+var consistencyChecks = []consistencyCheck{
+	{`remaining`, "unlinked", barcodeJoin, `NULL::uuid`, `p."id"`},
+	{`remaining`, "barcode", linkedJoin, `t."barcode"`, `p."barcode"`},
+	{`remaining`, "name", linkedJoin, `t."name"`, `p."name"`},
+	{`remaining`, "category", linkedJoin, `t."category_id"`, `p."category_id"`},
+	{`remaining`, "price", linkedJoin, `t."price"`, `p."price"`},
+	{`remaining`, "total_price", `LEFT ` + linkedJoin, `t."total_price"`, `t."count" * t."price"`},
+	{`coming_table_product`, "unlinked", barcodeJoin, `NULL::uuid`, `p."id"`},
+	{`coming_table_product`, "barcode", linkedJoin, `t."barcode"`, `p."barcode"`},
+	{`stock_movement`, "unlinked", barcodeJoin, `NULL::uuid`, `p."id"`},
+	{`stock_movement`, "barcode", linkedJoin, `t."barcode"`, `p."barcode"`},
+	{`remaining`, "orphan", orphanJoin, `NULL`, `NULL`},
+	{`coming_table_product`, "orphan", orphanJoin, `NULL`, `NULL`},
+	{`stock_movement`, "orphan", orphanJoin, `NULL`, `NULL`},
+}
+
+type consistencyRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewConsistencyRepo(db *pgxpool.Pool) *consistencyRepo {
+	return &consistencyRepo{
+		db: db,
+	}
+}
+
+// Check counts rows of remaining, coming lines and stock movements which drifted from their products
+// and returns up to req.Limit samples of every check, rows of barcodes unknown to catalog are reported as orphan
+func (r *consistencyRepo) Check(req *models.ConsistencyRequest) (*models.ConsistencyReport, error) {
+	resp := &models.ConsistencyReport{
+		Counts: make(map[string]int),
+		Issues: make([]*models.ConsistencyIssue, 0),
+	}
+
+	for _, check := range consistencyChecks {
+		filter := ` AND ` + check.stored + ` IS DISTINCT FROM ` + check.expected
+		if check.name == "orphan" {
+			filter = ` AND t."product_id" IS NULL AND p."id" IS NULL`
+		}
+
+		query := `
+			SELECT
+				COUNT(*) OVER(),
+				t."id"::text,
+				COALESCE(p."id"::text, ''),
+				COALESCE(t."barcode", ''),
+				COALESCE((` + check.stored + `)::text, ''),
+				COALESCE((` + check.expected + `)::text, '')
+			FROM "` + check.table + `" AS t
+			` + check.join + `
+			WHERE true ` + filter + `
+			ORDER BY t."id"
+			LIMIT $1
+		`
+
+		rows, err := r.db.Query(context.Background(), query, req.Limit)
+		if err != nil {
+			return nil, err
+		}
+
+		for rows.Next() {
+			var (
+				count int
+				issue = models.ConsistencyIssue{Check: check.name, Table: check.table}
+			)
+
+			err := rows.Scan(
+				&count,
+				&issue.Id,
+				&issue.ProductId,
+				&issue.Barcode,
+				&issue.Stored,
+				&issue.Expected,
+			)
+			if err != nil {
+				rows.Close()
+				return nil, err
+			}
+
+			resp.Counts[check.table+"."+check.name] = count
+			resp.Issues = append(resp.Issues, &issue)
+		}
+		rows.Close()
+
+		if rows.Err() != nil {
+			return nil, rows.Err()
+		}
+	}
+
+	return resp, nil
+}
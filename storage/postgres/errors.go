@@ -0,0 +1,27 @@
+package postgres
+
+import (
+	"errors"
+	"market/storage"
+
+	"github.com/jackc/pgconn"
+)
+
+const (
+	pgUniqueViolation = "23505"
+	pgCheckViolation  = "23514"
+)
+
+// constraintError converts unique and check violations of postgres to storage.ConstraintError, other errors are kept
+func constraintError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	if pgErr.Code != pgUniqueViolation && pgErr.Code != pgCheckViolation {
+		return err
+	}
+
+	return &storage.ConstraintError{Constraint: pgErr.ConstraintName, Detail: pgErr.Detail}
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"market/models"
+	"market/pkg/search"
+	"market/storage"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// listFilterOps are SQL of comparison filters, :value is the filter value cast to type of the column
+var listFilterOps = map[string]string{
+	models.FilterEq:  ` = :value`,
+	models.FilterNe:  ` IS DISTINCT FROM :value`,
+	models.FilterGt:  ` > :value`,
+	models.FilterGte: ` >= :value`,
+	models.FilterLt:  ` < :value`,
+	models.FilterLte: ` <= :value`,
+}
+
+// listWhere compiles filters and date range of list options into conditions over columns of spec,
+// values are added to params, so the result is appended to WHERE of the list or its export
+func listWhere(spec listSpec, opts models.ListOptions, params map[string]interface{}) (string, error) {
+	var where strings.Builder
+
+	for i, filter := range opts.Filters {
+		column, ok := spec.filters[filter.Field]
+		if !ok {
+			return "", &storage.ListError{Param: "filter", Value: filter.Field, Reason: "allowed are " + strings.Join(listFilterNames(spec), ", ")}
+		}
+
+		name := "filter_" + strconv.Itoa(i)
+		value := ":" + name + "::text::" + column.cast
+
+		switch filter.Op {
+		case models.FilterIn:
+			values := strings.Split(filter.Value, ",")
+			for _, v := range values {
+				if !validListValue(column.cast, v) {
+					return "", listValueError(filter, column)
+				}
+			}
+			params[name] = values
+			where.WriteString(` AND (` + column.expr + ` = ANY(ARRAY[:` + name + `]::text[]::` + column.cast + `[]))`)
+		case models.FilterNull:
+			null, err := strconv.ParseBool(filter.Value)
+			if err != nil {
+				return "", &storage.ListError{Param: filter.Field + "[" + filter.Op + "]", Value: filter.Value, Reason: "must be true or false"}
+			}
+			if null {
+				where.WriteString(` AND (` + column.expr + ` IS NULL)`)
+			} else {
+				where.WriteString(` AND (` + column.expr + ` IS NOT NULL)`)
+			}
+		case models.FilterLike:
+			if column.cast != "text" {
+				return "", &storage.ListError{Param: filter.Field + "[" + filter.Op + "]", Value: filter.Value, Reason: "like is allowed on text fields only"}
+			}
+			params[name] = search.LikeContains(filter.Value)
+			where.WriteString(` AND (` + column.expr + ` ILIKE :` + name + ` ESCAPE '\')`)
+		default:
+			op, ok := listFilterOps[filter.Op]
+			if !ok {
+				return "", &storage.ListError{Param: filter.Field + "[" + filter.Op + "]", Value: filter.Value, Reason: "unknown operator"}
+			}
+			if !validListValue(column.cast, filter.Value) {
+				return "", listValueError(filter, column)
+			}
+			params[name] = filter.Value
+			where.WriteString(` AND (` + column.expr + strings.Replace(op, ":value", value, 1) + `)`)
+		}
+	}
+
+	if opts.DateFrom.IsZero() && opts.DateTo.IsZero() {
+		return where.String(), nil
+	}
+
+	date, ok := spec.filters[spec.date]
+	if !ok {
+		return "", &storage.ListError{Param: "date_from", Value: opts.DateFrom.Format(time.DateTime), Reason: "list has no date"}
+	}
+
+	if !opts.DateFrom.IsZero() {
+		params["list_date_from"] = opts.DateFrom
+		where.WriteString(` AND (` + date.expr + ` >= :list_date_from)`)
+	}
+	if !opts.DateTo.IsZero() {
+		params["list_date_to"] = opts.DateTo
+		where.WriteString(` AND (` + date.expr + ` <= :list_date_to)`)
+	}
+
+	return where.String(), nil
+}
+
+func listFilterNames(spec listSpec) []string {
+	names := make([]string, 0, len(spec.filters))
+	for name := range spec.filters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func listValueError(filter models.ListFilter, column listColumn) error {
+	return &storage.ListError{Param: filter.Field + "[" + filter.Op + "]", Value: filter.Value, Reason: "must be " + column.cast}
+}
+
+// validListValue checks that value can be cast to type of the column, so a wrong filter is answered as bad
+// request instead of failing query
+func validListValue(cast, value string) bool {
+	var err error
+
+	switch cast {
+	case "numeric":
+		_, err = strconv.ParseFloat(value, 64)
+	case "int":
+		_, err = strconv.Atoi(value)
+	case "boolean":
+		_, err = strconv.ParseBool(value)
+	case "uuid":
+		_, err = uuid.Parse(value)
+	case "timestamp":
+		for _, layout := range []string{time.RFC3339, time.DateTime, time.DateOnly} {
+			if _, err = time.Parse(layout, value); err == nil {
+				break
+			}
+		}
+	}
+
+	return err == nil
+}
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"errors"
+	"market/models"
+	"market/storage"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var testList = listSpec{
+	key:      listColumn{`"id"`, "uuid"},
+	fallback: "-created_at",
+	sorts: map[string][]listColumn{
+		"created_at": {{`"created_at"`, "timestamp"}},
+		"name":       {{`"name"`, "text"}},
+	},
+	filters: map[string]listColumn{
+		"name":        {`"name"`, "text"},
+		"price":       {`"price"`, "numeric"},
+		"category_id": {`"category_id"`, "uuid"},
+		"created_at":  {`"created_at"`, "timestamp"},
+	},
+	date: "created_at",
+}
+
+func TestListWhere(t *testing.T) {
+	var (
+		from = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+		to   = time.Date(2024, 5, 31, 23, 59, 59, 0, time.UTC)
+	)
+
+	tests := []struct {
+		name    string
+		filters []models.ListFilter
+		from    time.Time
+		to      time.Time
+		where   string
+		params  map[string]interface{}
+	}{
+		{
+			name:   "nothing",
+			where:  "",
+			params: map[string]interface{}{},
+		},
+		{
+			name:    "comparison",
+			filters: []models.ListFilter{{Field: "price", Op: models.FilterGte, Value: "10.5"}},
+			where:   ` AND ("price" >= :filter_0::text::numeric)`,
+			params:  map[string]interface{}{"filter_0": "10.5"},
+		},
+		{
+			name:    "not equal",
+			filters: []models.ListFilter{{Field: "name", Op: models.FilterNe, Value: "milk"}},
+			where:   ` AND ("name" IS DISTINCT FROM :filter_0::text::text)`,
+			params:  map[string]interface{}{"filter_0": "milk"},
+		},
+		{
+			name:    "in",
+			filters: []models.ListFilter{{Field: "price", Op: models.FilterIn, Value: "1,2"}},
+			where:   ` AND ("price" = ANY(ARRAY[:filter_0]::text[]::numeric[]))`,
+			params:  map[string]interface{}{"filter_0": []string{"1", "2"}},
+		},
+		{
+			name:    "null",
+			filters: []models.ListFilter{{Field: "category_id", Op: models.FilterNull, Value: "true"}, {Field: "name", Op: models.FilterNull, Value: "false"}},
+			where:   ` AND ("category_id" IS NULL) AND ("name" IS NOT NULL)`,
+			params:  map[string]interface{}{},
+		},
+		{
+			name:    "like escapes wildcards",
+			filters: []models.ListFilter{{Field: "name", Op: models.FilterLike, Value: `50%_a\b`}},
+			where:   ` AND ("name" ILIKE :filter_0 ESCAPE '\')`,
+			params:  map[string]interface{}{"filter_0": `%50\%\_a\\b%`},
+		},
+		{
+			name:   "date range",
+			from:   from,
+			to:     to,
+			where:  ` AND ("created_at" >= :list_date_from) AND ("created_at" <= :list_date_to)`,
+			params: map[string]interface{}{"list_date_from": from, "list_date_to": to},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := make(map[string]interface{})
+
+			where, err := listWhere(testList, models.ListOptions{Filters: test.filters, DateFrom: test.from, DateTo: test.to}, params)
+			if err != nil {
+				t.Fatalf("listWhere: %v", err)
+			}
+			if where != test.where {
+				t.Errorf("where is %q, want %q", where, test.where)
+			}
+			if !reflect.DeepEqual(params, test.params) {
+				t.Errorf("params are %v, want %v", params, test.params)
+			}
+		})
+	}
+}
+
+func TestListWhereErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter models.ListFilter
+		param  string
+	}{
+		{"unknown field", models.ListFilter{Field: "secret", Op: models.FilterEq, Value: "1"}, "filter"},
+		{"unknown operator", models.ListFilter{Field: "price", Op: "between", Value: "1"}, "price[between]"},
+		{"not a number", models.ListFilter{Field: "price", Op: models.FilterEq, Value: "ten"}, "price[eq]"},
+		{"not a uuid in list", models.ListFilter{Field: "category_id", Op: models.FilterIn, Value: "1,2"}, "category_id[in]"},
+		{"not a date", models.ListFilter{Field: "created_at", Op: models.FilterLt, Value: "yesterday"}, "created_at[lt]"},
+		{"null is not bool", models.ListFilter{Field: "name", Op: models.FilterNull, Value: "maybe"}, "name[null]"},
+		{"like on number", models.ListFilter{Field: "price", Op: models.FilterLike, Value: "1"}, "price[like]"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := listWhere(testList, models.ListOptions{Filters: []models.ListFilter{test.filter}}, make(map[string]interface{}))
+
+			var listErr *storage.ListError
+			if !errors.As(err, &listErr) {
+				t.Fatalf("error is %v, want ListError", err)
+			}
+			if listErr.Param != test.param {
+				t.Errorf("error is about %q, want %q", listErr.Param, test.param)
+			}
+		})
+	}
+}
+
+func TestListWhereDateWithoutDate(t *testing.T) {
+	spec := testList
+	spec.date = ""
+
+	_, err := listWhere(spec, models.ListOptions{DateFrom: time.Now()}, make(map[string]interface{}))
+
+	var listErr *storage.ListError
+	if !errors.As(err, &listErr) || listErr.Param != "date_from" {
+		t.Errorf("error is %v, want ListError of date_from", err)
+	}
+}
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"market/models"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type idempotencyRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewIdempotencyRepo(db *pgxpool.Pool) *idempotencyRepo {
+	return &idempotencyRepo{
+		db: db,
+	}
+}
+
+// Begin claims req.Key for the request, created is false when the key is already claimed and not expired,
+// then the stored request and its response, if it is finished, are returned
+func (r *idempotencyRepo) Begin(req *models.IdempotencyKey) (*models.IdempotencyKey, bool, error) {
+	ctx := context.Background()
+
+	query := `
+		INSERT INTO "idempotency_key"(
+			"key",
+			"method",
+			"path",
+			"fingerprint",
+			"created_at",
+			"expires_at")
+		VALUES ($1, $2, $3, $4, NOW(), $5)
+		ON CONFLICT ("key") DO UPDATE
+		SET
+			"method" = EXCLUDED."method",
+			"path" = EXCLUDED."path",
+			"fingerprint" = EXCLUDED."fingerprint",
+			"status_code" = NULL,
+			"headers" = NULL,
+			"response" = NULL,
+			"created_at" = NOW(),
+			"expires_at" = EXCLUDED."expires_at"
+		WHERE "idempotency_key"."expires_at" < NOW()
+	`
+
+	result, err := r.db.Exec(ctx, query, req.Key, req.Method, req.Path, req.Fingerprint, req.ExpiresAt)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if result.RowsAffected() > 0 {
+		return req, true, nil
+	}
+
+	var (
+		stored     = models.IdempotencyKey{Key: req.Key}
+		statusCode sql.NullInt32
+	)
+
+	query = `
+		SELECT
+			"method",
+			"path",
+			"fingerprint",
+			"status_code",
+			COALESCE("headers", '{}'),
+			"response",
+			"expires_at"
+		FROM "idempotency_key"
+		WHERE "key" = $1
+	`
+
+	err = r.db.QueryRow(ctx, query, req.Key).Scan(
+		&stored.Method,
+		&stored.Path,
+		&stored.Fingerprint,
+		&statusCode,
+		&stored.Headers,
+		&stored.Response,
+		&stored.ExpiresAt,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, false, fmt.Errorf("idempotency key %s was released, retry the request", req.Key)
+		}
+		return nil, false, err
+	}
+	stored.StatusCode = int(statusCode.Int32)
+
+	return &stored, false, nil
+}
+
+// Finish stores response of the request which claimed req.Key
+func (r *idempotencyRepo) Finish(req *models.IdempotencyKey) error {
+	query := `
+		UPDATE
+			"idempotency_key"
+		SET
+			"status_code" = $2,
+			"headers" = $3,
+			"response" = $4
+		WHERE "key" = $1 AND "fingerprint" = $5
+	`
+
+	_, err := r.db.Exec(context.Background(), query, req.Key, req.StatusCode, req.Headers, req.Response, req.Fingerprint)
+	return err
+}
+
+// Release deletes unfinished key of failed request, so its retry is handled again
+func (r *idempotencyRepo) Release(req *models.IdempotencyKey) error {
+	_, err := r.db.Exec(context.Background(),
+		`DELETE FROM "idempotency_key" WHERE "key" = $1 AND "fingerprint" = $2 AND "status_code" IS NULL`,
+		req.Key, req.Fingerprint)
+	return err
+}
+
+// DeleteExpired removes keys whose TTL passed
+func (r *idempotencyRepo) DeleteExpired() (int64, error) {
+	result, err := r.db.Exec(context.Background(), `DELETE FROM "idempotency_key" WHERE "expires_at" < NOW()`)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
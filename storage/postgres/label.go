@@ -0,0 +1,191 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"market/models"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type labelRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewLabelRepo(db *pgxpool.Pool) *labelRepo {
+	return &labelRepo{
+		db: db,
+	}
+}
+
+// GetTemplate returns label template of branch or the default one
+func (r *labelRepo) GetTemplate(req *models.BranchPrimaryKey) (*models.LabelTemplate, error) {
+	if req.Id == "" {
+		return models.DefaultLabelTemplate(""), nil
+	}
+
+	var (
+		width       sql.NullFloat64
+		height      sql.NullFloat64
+		columns     sql.NullInt64
+		fontSize    sql.NullFloat64
+		barcodeType sql.NullString
+		dpi         sql.NullInt64
+		createdAt   sql.NullString
+		updatedAt   sql.NullString
+	)
+
+	query := `
+		SELECT
+			"width",
+			"height",
+			"columns",
+			"font_size",
+			"barcode_type",
+			"dpi",
+			"created_at",
+			"updated_at"
+		FROM "label_template"
+		WHERE "branch_id" = $1
+	`
+
+	err := r.db.QueryRow(context.Background(), query, req.Id).Scan(
+		&width,
+		&height,
+		&columns,
+		&fontSize,
+		&barcodeType,
+		&dpi,
+		&createdAt,
+		&updatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return models.DefaultLabelTemplate(req.Id), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.LabelTemplate{
+		BranchId:    req.Id,
+		Width:       width.Float64,
+		Height:      height.Float64,
+		Columns:     int(columns.Int64),
+		FontSize:    fontSize.Float64,
+		BarcodeType: barcodeType.String,
+		Dpi:         int(dpi.Int64),
+		CreatedAt:   createdAt.String,
+		UpdatedAt:   updatedAt.String,
+	}, nil
+}
+
+func (r *labelRepo) SaveTemplate(req *models.LabelTemplate) (string, error) {
+
+	query := `
+		INSERT INTO "label_template"(
+			"branch_id",
+			"width",
+			"height",
+			"columns",
+			"font_size",
+			"barcode_type",
+			"dpi",
+			"created_at")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT ("branch_id") DO UPDATE
+		SET
+			"width" = EXCLUDED."width",
+			"height" = EXCLUDED."height",
+			"columns" = EXCLUDED."columns",
+			"font_size" = EXCLUDED."font_size",
+			"barcode_type" = EXCLUDED."barcode_type",
+			"dpi" = EXCLUDED."dpi",
+			"updated_at" = NOW()
+	`
+
+	_, err := r.db.Exec(context.Background(), query,
+		req.BranchId,
+		req.Width,
+		req.Height,
+		req.Columns,
+		req.FontSize,
+		req.BarcodeType,
+		req.Dpi,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return req.BranchId, nil
+}
+
+// GetItems returns name, shelf price and number of labels of every product to print,
+// coming_table lines take name and price from their linked product of catalog
+func (r *labelRepo) GetItems(req *models.LabelRequest) ([]*models.LabelItem, error) {
+	var (
+		query string
+		arg   interface{}
+	)
+
+	if req.ComingTableId != "" {
+		query = `
+			SELECT
+				COALESCE(p."name", ctp."name"),
+				ctp."barcode",
+				COALESCE(p."price", ctp."price"),
+				ctp."count"
+			FROM "coming_table_product" AS ctp
+			LEFT JOIN "product" AS p ON p."id" = ctp."product_id"
+			WHERE ctp."coming_table_id" = $1
+			ORDER BY ctp."created_at"
+		`
+		arg = req.ComingTableId
+	} else {
+		query = `
+			SELECT
+				"name",
+				"barcode",
+				"price",
+				1
+			FROM "product"
+			WHERE "id" = ANY($1::uuid[])
+			ORDER BY array_position($1::uuid[], "id")
+		`
+		arg = req.ProductIds
+	}
+
+	rows, err := r.db.Query(context.Background(), query, arg)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*models.LabelItem, 0)
+	for rows.Next() {
+		var (
+			name    sql.NullString
+			barcode sql.NullString
+			price   sql.NullFloat64
+			count   sql.NullInt64
+		)
+
+		err := rows.Scan(&name, &barcode, &price, &count)
+		if err != nil {
+			return nil, err
+		}
+
+		item := &models.LabelItem{
+			Name:    name.String,
+			Barcode: barcode.String,
+			Price:   price.Float64,
+			Copies:  int(count.Int64),
+		}
+		if req.Copies > 0 {
+			item.Copies = req.Copies
+		}
+		items = append(items, item)
+	}
+
+	return items, rows.Err()
+}
@@ -0,0 +1,206 @@
+package postgres
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"market/models"
+	"market/storage"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// listColumn is a column of list query used for sorting or filtering, cast is the type values of cursor
+// and filters are converted to. Sort expression must not be NULL, otherwise rows after it can not be
+// compared with the cursor
+type listColumn struct {
+	expr string
+	cast string
+}
+
+// listSpec describes list of an entity: columns of each allowed sort by name, sort used when none is given,
+// unique key which breaks ties, so keyset pagination never skips or repeats rows, columns allowed in filters
+// and the one date_from and date_to apply to
+type listSpec struct {
+	key      listColumn
+	fallback string
+	sorts    map[string][]listColumn
+	filters  map[string]listColumn
+	date     string
+}
+
+// listCursor is encoded into opaque next_cursor: sort it was made for and values of last row of the page
+type listCursor struct {
+	Sort   string          `json:"s"`
+	Values json.RawMessage `json:"v"`
+}
+
+// listPage paginates list query either by page and limit or by keyset after the cursor
+type listPage struct {
+	sort    string
+	desc    bool
+	columns []listColumn
+	limit   int
+	offset  int
+	after   []string
+	count   bool
+
+	rows int
+	last string
+}
+
+func newListPage(spec listSpec, page, limit int, opts models.ListOptions) (*listPage, error) {
+	if limit < 1 || limit > models.MaxListLimit {
+		return nil, &storage.ListError{Param: "limit", Value: strconv.Itoa(limit), Reason: fmt.Sprintf("must be between 1 and %d", models.MaxListLimit)}
+	}
+	if page < 1 {
+		return nil, &storage.ListError{Param: "page", Value: strconv.Itoa(page), Reason: "must be positive"}
+	}
+
+	p := &listPage{sort: opts.Sort, limit: limit, offset: (page - 1) * limit, count: opts.WithCount}
+
+	if opts.Cursor != "" {
+		after, err := decodeListCursor(opts.Cursor)
+		if err != nil {
+			return nil, &storage.ListError{Param: "cursor", Value: opts.Cursor, Reason: "malformed"}
+		}
+		if p.sort == "" {
+			p.sort = after.Sort
+		}
+		if after.Sort != p.sort {
+			return nil, &storage.ListError{Param: "cursor", Value: opts.Cursor, Reason: fmt.Sprintf("made for sort %q, not %q", after.Sort, p.sort)}
+		}
+
+		err = json.Unmarshal(after.Values, &p.after)
+		if err != nil {
+			return nil, &storage.ListError{Param: "cursor", Value: opts.Cursor, Reason: "malformed"}
+		}
+		p.offset = 0
+	}
+
+	if p.sort == "" {
+		p.sort = spec.fallback
+	}
+
+	columns, ok := spec.sorts[strings.TrimPrefix(p.sort, "-")]
+	if !ok {
+		names := make([]string, 0, len(spec.sorts))
+		for name := range spec.sorts {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, &storage.ListError{Param: "sort", Value: p.sort, Reason: "allowed are " + strings.Join(names, ", ") + " with optional - prefix"}
+	}
+
+	p.desc = strings.HasPrefix(p.sort, "-")
+	p.columns = append(append(p.columns, columns...), spec.key)
+
+	if p.after != nil && len(p.after) != len(p.columns) {
+		return nil, &storage.ListError{Param: "cursor", Value: opts.Cursor, Reason: "malformed"}
+	}
+
+	return p, nil
+}
+
+func decodeListCursor(value string) (*listCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor listCursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cursor, nil
+}
+
+// countColumn is total count of filtered rows when it was requested, 0 otherwise. It is aliased
+// because lists of stock have their own "count" column
+func (p *listPage) countColumn() string {
+	if p.count {
+		return `COUNT(*) OVER() AS "list_count"`
+	}
+	return `0 AS "list_count"`
+}
+
+// query wraps filtered select of list into sorted page of it, first column of result is cursor of the row
+func (p *listPage) query(inner string, params map[string]interface{}) string {
+	var (
+		values = make([]string, 0, len(p.columns))
+		exprs  = make([]string, 0, len(p.columns))
+		after  = make([]string, 0, len(p.columns))
+		cmp    = " > "
+		where  string
+	)
+	if p.desc {
+		cmp = " < "
+	}
+
+	for i, column := range p.columns {
+		values = append(values, "("+column.expr+")::text")
+		exprs = append(exprs, column.expr)
+
+		if p.after != nil {
+			name := "cursor_" + strconv.Itoa(i)
+			params[name] = p.after[i]
+			after = append(after, ":"+name+"::text::"+column.cast)
+		}
+	}
+
+	if p.after != nil {
+		where = " WHERE (" + strings.Join(exprs, ", ") + ")" + cmp + "(" + strings.Join(after, ", ") + ")"
+	}
+
+	params["limit"] = p.limit
+	params["offset"] = p.offset
+
+	return `SELECT json_build_array(` + strings.Join(values, ", ") + `)::text, "list".* FROM (` + inner + `) AS "list"` +
+		where + ` ORDER BY ` + p.order() + ` LIMIT :limit OFFSET :offset `
+}
+
+// order is ORDER BY list of the sort with the unique key last
+func (p *listPage) order() string {
+	dir := " ASC"
+	if p.desc {
+		dir = " DESC"
+	}
+
+	order := make([]string, 0, len(p.columns))
+	for _, column := range p.columns {
+		order = append(order, column.expr+dir)
+	}
+	return strings.Join(order, ", ")
+}
+
+// listOrder is ORDER BY of the whole list in the sort its pages use, for exports which are not paginated
+func listOrder(spec listSpec, name string) (string, error) {
+	p, err := newListPage(spec, 1, 1, models.ListOptions{Sort: name})
+	if err != nil {
+		return "", err
+	}
+	return p.order(), nil
+}
+
+// dest returns scan destinations of a list row, cursor of the row goes first. It also counts scanned rows
+func (p *listPage) dest(columns ...interface{}) []interface{} {
+	p.rows++
+	return append([]interface{}{&p.last}, columns...)
+}
+
+// next is cursor of the page after scanned one, empty when the page was the last
+func (p *listPage) next() string {
+	if p.rows < p.limit {
+		return ""
+	}
+
+	data, err := json.Marshal(listCursor{Sort: p.sort, Values: json.RawMessage(p.last)})
+	if err != nil {
+		return ""
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
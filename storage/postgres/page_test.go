@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"errors"
+	"market/models"
+	"market/storage"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// nextCursor returns cursor made by page whose last row had values
+func nextCursor(t *testing.T, sort string, values string) string {
+	t.Helper()
+
+	p, err := newListPage(testList, 1, 2, models.ListOptions{Sort: sort})
+	if err != nil {
+		t.Fatalf("newListPage: %v", err)
+	}
+	p.dest()
+	p.dest()
+	p.last = values
+
+	cursor := p.next()
+	if cursor == "" {
+		t.Fatal("full page has no next cursor")
+	}
+	return cursor
+}
+
+func TestListCursorRoundTrip(t *testing.T) {
+	cursor := nextCursor(t, "-name", `["milk", "0b7f4a8e-6c1d-4f4e-9a43-5d2c0b1f7e11"]`)
+
+	p, err := newListPage(testList, 3, 2, models.ListOptions{Cursor: cursor})
+	if err != nil {
+		t.Fatalf("newListPage: %v", err)
+	}
+
+	if p.sort != "-name" || !p.desc {
+		t.Errorf("sort is %q desc %v, want -name taken from cursor", p.sort, p.desc)
+	}
+	if want := []string{"milk", "0b7f4a8e-6c1d-4f4e-9a43-5d2c0b1f7e11"}; !reflect.DeepEqual(p.after, want) {
+		t.Errorf("after is %v, want %v", p.after, want)
+	}
+	if p.offset != 0 {
+		t.Errorf("offset is %d, page must be ignored with cursor", p.offset)
+	}
+
+	params := make(map[string]interface{})
+	query := p.query(`SELECT * FROM "product"`, params)
+	if !strings.Contains(query, `WHERE ("name", "id") < (:cursor_0::text::text, :cursor_1::text::uuid)`) {
+		t.Errorf("query does not continue after the cursor: %s", query)
+	}
+	if !strings.Contains(query, `ORDER BY "name" DESC, "id" DESC`) {
+		t.Errorf("query is not ordered by sort and key: %s", query)
+	}
+	if params["cursor_0"] != "milk" || params["limit"] != 2 || params["offset"] != 0 {
+		t.Errorf("params are %v", params)
+	}
+}
+
+func TestListCursorLastPage(t *testing.T) {
+	p, err := newListPage(testList, 1, 2, models.ListOptions{})
+	if err != nil {
+		t.Fatalf("newListPage: %v", err)
+	}
+	p.dest()
+	p.last = `["2024-05-01 00:00:00", "0b7f4a8e-6c1d-4f4e-9a43-5d2c0b1f7e11"]`
+
+	if cursor := p.next(); cursor != "" {
+		t.Errorf("page shorter than limit has next cursor %q", cursor)
+	}
+}
+
+func TestListPageErrors(t *testing.T) {
+	nameCursor := nextCursor(t, "name", `["milk", "0b7f4a8e-6c1d-4f4e-9a43-5d2c0b1f7e11"]`)
+	shortCursor := nextCursor(t, "name", `["milk"]`)
+
+	tests := []struct {
+		name  string
+		page  int
+		limit int
+		opts  models.ListOptions
+		param string
+	}{
+		{"limit too small", 1, 0, models.ListOptions{}, "limit"},
+		{"limit too big", 1, models.MaxListLimit + 1, models.ListOptions{}, "limit"},
+		{"page not positive", 0, 10, models.ListOptions{}, "page"},
+		{"unknown sort", 1, 10, models.ListOptions{Sort: "price"}, "sort"},
+		{"cursor not base64", 1, 10, models.ListOptions{Cursor: "!!!"}, "cursor"},
+		{"cursor not json", 1, 10, models.ListOptions{Cursor: "bm90IGpzb24"}, "cursor"},
+		{"cursor of other sort", 1, 10, models.ListOptions{Cursor: nameCursor, Sort: "-name"}, "cursor"},
+		{"cursor of other columns", 1, 10, models.ListOptions{Cursor: shortCursor}, "cursor"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := newListPage(testList, test.page, test.limit, test.opts)
+
+			var listErr *storage.ListError
+			if !errors.As(err, &listErr) {
+				t.Fatalf("error is %v, want ListError", err)
+			}
+			if listErr.Param != test.param {
+				t.Errorf("error is about %q, want %q", listErr.Param, test.param)
+			}
+		})
+	}
+}
+
+func TestListOrder(t *testing.T) {
+	order, err := listOrder(testList, "")
+	if err != nil {
+		t.Fatalf("listOrder: %v", err)
+	}
+	if order != `"created_at" DESC, "id" DESC` {
+		t.Errorf("order is %q", order)
+	}
+}
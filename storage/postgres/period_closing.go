@@ -0,0 +1,338 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"market/models"
+	"market/storage"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// openPeriod matches when month of dateTime is not closed for branchId, both are SQL expressions
+func openPeriod(branchId, dateTime string) string {
+	return `NOT EXISTS (
+			SELECT 1 FROM "period_closing"
+			WHERE "branch_id" = ` + branchId + ` AND "month" = DATE_TRUNC('month', COALESCE(` + dateTime + `, NOW()))::date
+		)`
+}
+
+// lockPeriod takes advisory lock of branch and month of dateTime until the end of transaction, empty dateTime means now.
+// Writers share it while they check the month and write into it, Close takes it exclusively,
+// so the month is not closed under a document which is being written
+func lockPeriod(ctx context.Context, q querier, branchId, dateTime string, exclusive bool) error {
+	var locked int
+
+	lock := "pg_advisory_xact_lock_shared"
+	if exclusive {
+		lock = "pg_advisory_xact_lock"
+	}
+
+	query := `
+		SELECT 1
+		FROM ` + lock + `(
+			hashtext($1::text),
+			(EXTRACT(YEAR FROM m."date_time") * 12 + EXTRACT(MONTH FROM m."date_time"))::int
+		), (SELECT COALESCE(NULLIF($2, '')::timestamp, NOW()) AS "date_time") AS m
+	`
+
+	return q.QueryRow(ctx, query, branchId, dateTime).Scan(&locked)
+}
+
+// checkPeriod returns PeriodClosedError when document of branch dated dateTime falls into closed month,
+// empty dateTime means the document is dated now. The month is share locked until the end of transaction
+func checkPeriod(ctx context.Context, q querier, branchId, dateTime string) error {
+	var month sql.NullString
+
+	if branchId == "" {
+		return nil
+	}
+
+	err := lockPeriod(ctx, q, branchId, dateTime, false)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		SELECT
+			TO_CHAR("month", 'YYYY-MM')
+		FROM "period_closing"
+		WHERE "branch_id" = $1 AND "month" = DATE_TRUNC('month', COALESCE(NULLIF($2, '')::timestamp, NOW()))::date
+	`
+
+	err = q.QueryRow(ctx, query, branchId, dateTime).Scan(&month)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+
+	return &storage.PeriodClosedError{BranchId: branchId, Month: month.String}
+}
+
+// checkComingTablePeriod returns PeriodClosedError when coming_table is dated inside closed month of its branch
+func checkComingTablePeriod(ctx context.Context, q querier, comingTableId string) error {
+	var (
+		branchId sql.NullString
+		dateTime sql.NullString
+	)
+
+	query := `
+		SELECT
+			"branch_id",
+			"date_time"::text
+		FROM "coming_table"
+		WHERE "id" = $1
+	`
+
+	err := q.QueryRow(ctx, query, comingTableId).Scan(&branchId, &dateTime)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil
+		}
+		return err
+	}
+
+	return checkPeriod(ctx, q, branchId.String, dateTime.String)
+}
+
+type periodClosingRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPeriodClosingRepo(db *pgxpool.Pool) *periodClosingRepo {
+	return &periodClosingRepo{
+		db: db,
+	}
+}
+
+// Close forbids changes of stock documents of branch dated inside the month
+func (r *periodClosingRepo) Close(req *models.PeriodClosingRequest) error {
+	ctx := context.Background()
+
+	month, err := periodMonth(req.Month)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// documents of the month being written are waited for, the ones coming later see it closed
+	err = lockPeriod(ctx, tx, req.BranchId, month.Format(time.DateOnly), true)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO "period_closing"(
+			"branch_id",
+			"month",
+			"closed_by",
+			"closed_at")
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT ("branch_id", "month") DO NOTHING
+	`
+
+	result, err := tx.Exec(ctx, query, req.BranchId, month, req.User)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("period %s of branch %s is already closed", req.Month, req.BranchId)
+	}
+
+	err = r.log(ctx, tx, req, month, models.PeriodClose)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Reopen allows changes in closed month again, the reason is kept in the log
+func (r *periodClosingRepo) Reopen(req *models.PeriodClosingRequest) error {
+	ctx := context.Background()
+
+	month, err := periodMonth(req.Month)
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		DELETE FROM "period_closing"
+		WHERE "branch_id" = $1 AND "month" = $2
+	`
+
+	result, err := tx.Exec(ctx, query, req.BranchId, month)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("period %s of branch %s is not closed", req.Month, req.BranchId)
+	}
+
+	err = r.log(ctx, tx, req, month, models.PeriodReopen)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *periodClosingRepo) log(ctx context.Context, tx pgx.Tx, req *models.PeriodClosingRequest, month time.Time, action string) error {
+	query := `
+		INSERT INTO "period_closing_log"(
+			"id",
+			"branch_id",
+			"month",
+			"action",
+			"user_name",
+			"reason",
+			"created_at")
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), NOW())
+	`
+
+	_, err := tx.Exec(ctx, query,
+		uuid.NewString(),
+		req.BranchId,
+		month,
+		action,
+		req.User,
+		req.Reason,
+	)
+	return err
+}
+
+func (r *periodClosingRepo) GetList(req *models.PeriodClosingGetListRequest) (*models.PeriodClosingGetListResponse, error) {
+	resp := &models.PeriodClosingGetListResponse{}
+
+	query := `
+		SELECT
+			"branch_id",
+			TO_CHAR("month", 'YYYY-MM'),
+			"closed_by",
+			"closed_at"
+		FROM "period_closing"
+		WHERE NULLIF($1, '') IS NULL OR "branch_id" = NULLIF($1, '')::uuid
+		ORDER BY "month" DESC, "branch_id"
+	`
+
+	rows, err := r.db.Query(context.Background(), query, req.BranchId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			branch_id sql.NullString
+			month     sql.NullString
+			closed_by sql.NullString
+			closed_at sql.NullString
+		)
+
+		err := rows.Scan(
+			&branch_id,
+			&month,
+			&closed_by,
+			&closed_at,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Periods = append(resp.Periods, &models.PeriodClosing{
+			BranchId: branch_id.String,
+			Month:    month.String,
+			ClosedBy: closed_by.String,
+			ClosedAt: closed_at.String,
+		})
+	}
+	return resp, rows.Err()
+}
+
+func (r *periodClosingRepo) GetLog(req *models.PeriodClosingGetListRequest) (*models.PeriodClosingLogResponse, error) {
+	resp := &models.PeriodClosingLogResponse{}
+
+	query := `
+		SELECT
+			"id",
+			"branch_id",
+			TO_CHAR("month", 'YYYY-MM'),
+			"action",
+			"user_name",
+			"reason",
+			"created_at"
+		FROM "period_closing_log"
+		WHERE NULLIF($1, '') IS NULL OR "branch_id" = NULLIF($1, '')::uuid
+		ORDER BY "created_at" DESC
+	`
+
+	rows, err := r.db.Query(context.Background(), query, req.BranchId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id         sql.NullString
+			branch_id  sql.NullString
+			month      sql.NullString
+			action     sql.NullString
+			user_name  sql.NullString
+			reason     sql.NullString
+			created_at sql.NullString
+		)
+
+		err := rows.Scan(
+			&id,
+			&branch_id,
+			&month,
+			&action,
+			&user_name,
+			&reason,
+			&created_at,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Logs = append(resp.Logs, &models.PeriodClosingLog{
+			Id:        id.String,
+			BranchId:  branch_id.String,
+			Month:     month.String,
+			Action:    action.String,
+			User:      user_name.String,
+			Reason:    reason.String,
+			CreatedAt: created_at.String,
+		})
+	}
+	return resp, rows.Err()
+}
+
+// periodMonth parses month like 2023-09 to its first day
+func periodMonth(month string) (time.Time, error) {
+	date, err := time.Parse("2006-01", month)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid month %q, expected format is YYYY-MM", month)
+	}
+	return date, nil
+}
@@ -6,9 +6,15 @@ import (
 	"market/config"
 	"market/storage"
 
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
+// querier is satisfied by both pool and transaction
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
 type store struct {
 	db                 *pgxpool.Pool
 	branches           *branchRepo
@@ -17,6 +23,18 @@ type store struct {
 	comingTable        *comingTableRepo
 	comingTableProduct *comingTableProduct
 	remainings         *remainingRepo
+	suppliers          *supplierRepo
+	purchaseOrders     *purchaseOrderRepo
+	labels             *labelRepo
+	reports            *reportRepo
+	periodClosings     *periodClosingRepo
+	productPrices      *productPriceRepo
+	promotions         *promotionRepo
+	revaluations       *revaluationRepo
+	consistency        *consistencyRepo
+	idempotency        *idempotencyRepo
+	categoryAttributes *categoryAttributeRepo
+	productImages      *productImageRepo
 }
 
 func NewStorage(ctx context.Context, cfg config.Config) (storage.StorageI, error) {
@@ -86,6 +104,90 @@ func (s *store) Remaining() storage.RemainingRepoI {
 	return s.remainings
 }
 
+func (s *store) Supplier() storage.SupplierRepoI {
+	if s.suppliers == nil {
+		s.suppliers = NewSupplierRepo(s.db)
+	}
+	return s.suppliers
+}
+
+func (s *store) PurchaseOrder() storage.PurchaseOrderRepoI {
+	if s.purchaseOrders == nil {
+		s.purchaseOrders = NewPurchaseOrderRepo(s.db)
+	}
+	return s.purchaseOrders
+}
+
+func (s *store) Label() storage.LabelRepoI {
+	if s.labels == nil {
+		s.labels = NewLabelRepo(s.db)
+	}
+	return s.labels
+}
+
+func (s *store) Report() storage.ReportRepoI {
+	if s.reports == nil {
+		s.reports = NewReportRepo(s.db)
+	}
+	return s.reports
+}
+
+func (s *store) PeriodClosing() storage.PeriodClosingRepoI {
+	if s.periodClosings == nil {
+		s.periodClosings = NewPeriodClosingRepo(s.db)
+	}
+	return s.periodClosings
+}
+
+func (s *store) ProductPrice() storage.ProductPriceRepoI {
+	if s.productPrices == nil {
+		s.productPrices = NewProductPriceRepo(s.db)
+	}
+	return s.productPrices
+}
+
+func (s *store) Promotion() storage.PromotionRepoI {
+	if s.promotions == nil {
+		s.promotions = NewPromotionRepo(s.db)
+	}
+	return s.promotions
+}
+
+func (s *store) Revaluation() storage.RevaluationRepoI {
+	if s.revaluations == nil {
+		s.revaluations = NewRevaluationRepo(s.db)
+	}
+	return s.revaluations
+}
+
+func (s *store) Consistency() storage.ConsistencyRepoI {
+	if s.consistency == nil {
+		s.consistency = NewConsistencyRepo(s.db)
+	}
+	return s.consistency
+}
+
+func (s *store) Idempotency() storage.IdempotencyRepoI {
+	if s.idempotency == nil {
+		s.idempotency = NewIdempotencyRepo(s.db)
+	}
+	return s.idempotency
+}
+
+func (s *store) CategoryAttribute() storage.CategoryAttributeRepoI {
+	if s.categoryAttributes == nil {
+		s.categoryAttributes = NewCategoryAttributeRepo(s.db)
+	}
+	return s.categoryAttributes
+}
+
+func (s *store) ProductImage() storage.ProductImageRepoI {
+	if s.productImages == nil {
+		s.productImages = NewProductImageRepo(s.db)
+	}
+	return s.productImages
+}
+
 func (s *store) Close() {
 	s.db.Close()
 }
@@ -6,8 +6,13 @@ import (
 	"fmt"
 	"market/models"
 	"market/pkg/helper"
+	"market/pkg/search"
+	"market/storage"
+	"sort"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -23,118 +28,347 @@ func NewProductRepo(db *pgxpool.Pool) *productRepo {
 
 func (r *productRepo) Create(req *models.CreateProduct) (string, error) {
 	var (
-		id = uuid.NewString()
+		ctx = context.Background()
+		id  = uuid.NewString()
 	)
 
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	err = checkAttributes(ctx, tx, req.CategoryId, req.Attributes)
+	if err != nil {
+		return "", err
+	}
+
+	err = insertProduct(ctx, tx, id, req, models.ProductActive)
+	if err != nil {
+		return "", err
+	}
+
+	return id, tx.Commit(ctx)
+}
+
+// insertProduct adds product with the status, starts its price history and links stock rows of its barcode to it
+func insertProduct(ctx context.Context, tx pgx.Tx, id string, req *models.CreateProduct, status string) error {
 	query := `
-				INSERT INTO "product"(
-					"id",
-					"name",
-					"price",
-					"barcode",
-					"category_id",
-					"created_at")
-				VALUES ($1, $2, $3, $4, $5, NOW())`
+		INSERT INTO "product"(
+			"id",
+			"name",
+			"price",
+			"barcode",
+			"category_id",
+			"attributes",
+			"status",
+			"created_at")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+	`
 
-	_, err := r.db.Exec(context.Background(), query,
+	_, err := tx.Exec(ctx, query,
 		id,
 		req.Name,
 		req.Price,
 		req.Barcode,
-		req.CategoryId,
+		helper.NewNullString(req.CategoryId),
+		productAttributes(req.Attributes),
+		status,
 	)
+	if err != nil {
+		return constraintError(err)
+	}
 
+	err = recordPrice(ctx, tx, id)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	return id, nil
+	return linkProduct(ctx, tx, id)
 }
 
-func (r *productRepo) GetByID(req *models.ProductPrimaryKey) (*models.Product, error) {
-
+// CreateVariant adds variant to product which is not a variant itself, the variant gets category of the parent
+// and attributes must tell it apart from other variants
+func (r *productRepo) CreateVariant(req *models.CreateProductVariant) (string, error) {
 	var (
-		id          sql.NullString
-		name        sql.NullString
-		price       sql.NullFloat64
-		barcode     sql.NullString
+		ctx         = context.Background()
+		id          = uuid.NewString()
+		parentName  sql.NullString
+		parentPrice sql.NullFloat64
 		category_id sql.NullString
-		createdAt   sql.NullString
-		updatedAt   sql.NullString
+		parent_id   sql.NullString
+	)
+
+	if len(req.Attributes) == 0 {
+		return "", fmt.Errorf("variant must have attributes")
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		SELECT
+			"name",
+			"price",
+			"category_id",
+			"parent_id"
+		FROM "product"
+		WHERE "id" = $1
+		FOR UPDATE
+	`
+
+	err = tx.QueryRow(ctx, query, req.ParentId).Scan(&parentName, &parentPrice, &category_id, &parent_id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", fmt.Errorf("product with ID %s not found", req.ParentId)
+		}
+		return "", err
+	}
+	if parent_id.Valid {
+		return "", fmt.Errorf("product with ID %s is a variant and can not have variants", req.ParentId)
+	}
+
+	err = checkAttributes(ctx, tx, category_id.String, req.Attributes)
+	if err != nil {
+		return "", err
+	}
+
+	if req.Name == "" {
+		req.Name = variantName(parentName.String, req.Attributes)
+	}
+	if req.Price == 0 {
+		req.Price = parentPrice.Float64
+	}
+
+	query = `
+		INSERT INTO "product"(
+			"id",
+			"parent_id",
+			"name",
+			"price",
+			"barcode",
+			"category_id",
+			"attributes",
+			"created_at")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+	`
+
+	_, err = tx.Exec(ctx, query,
+		id,
+		req.ParentId,
+		req.Name,
+		req.Price,
+		req.Barcode,
+		category_id,
+		req.Attributes,
 	)
+	if err != nil {
+		return "", constraintError(err)
+	}
+
+	err = recordPrice(ctx, tx, id)
+	if err != nil {
+		return "", err
+	}
+
+	err = linkProduct(ctx, tx, id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, tx.Commit(ctx)
+}
+
+// variantName is name of parent followed by attribute values in order of attribute names, like "Shirt 42 red"
+func variantName(name string, attributes map[string]string) string {
+	names := make([]string, 0, len(attributes))
+	for attribute := range attributes {
+		names = append(names, attribute)
+	}
+	sort.Strings(names)
+
+	for _, attribute := range names {
+		name += " " + attributes[attribute]
+	}
+	return name
+}
+
+// productAttributes is attributes stored for product, nil map is stored as empty object
+func productAttributes(attributes map[string]string) map[string]string {
+	if attributes == nil {
+		return map[string]string{}
+	}
+	return attributes
+}
+
+// GetByID returns product with its variants and images
+func (r *productRepo) GetByID(req *models.ProductPrimaryKey) (*models.Product, error) {
+	ctx := context.Background()
 
 	query := `
 		SELECT
 			"id",
+			"parent_id",
 			"name",
 			"price",		
 			"barcode",
 			"category_id",
+			"attributes",
+			"status",
 			"created_at",
 			"updated_at" 
 		FROM "product"
 		WHERE id = $1
 	`
 
-	err := r.db.QueryRow(context.Background(), query, req.Id).Scan(
+	product, err := scanProduct(r.db.QueryRow(ctx, query, req.Id))
+	if err != nil {
+		return nil, err
+	}
+
+	query = `
+		SELECT
+			"id",
+			"parent_id",
+			"name",
+			"price",
+			"barcode",
+			"category_id",
+			"attributes",
+			"status",
+			"created_at",
+			"updated_at"
+		FROM "product"
+		WHERE "parent_id" = $1
+		ORDER BY "name", "barcode"
+	`
+
+	rows, err := r.db.Query(ctx, query, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		variant, err := scanProduct(rows)
+		if err != nil {
+			return nil, err
+		}
+		product.Variants = append(product.Variants, variant)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	product.Images, err = productImages(ctx, r.db, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return product, nil
+}
+
+func scanProduct(row pgx.Row) (*models.Product, error) {
+	var (
+		id          sql.NullString
+		parent_id   sql.NullString
+		name        sql.NullString
+		price       sql.NullFloat64
+		barcode     sql.NullString
+		category_id sql.NullString
+		attributes  map[string]string
+		status      sql.NullString
+		createdAt   sql.NullString
+		updatedAt   sql.NullString
+	)
+
+	err := row.Scan(
 		&id,
+		&parent_id,
 		&name,
 		&price,
 		&barcode,
 		&category_id,
+		&attributes,
+		&status,
 		&createdAt,
 		&updatedAt,
 	)
-
 	if err != nil {
 		return nil, err
 	}
 
 	return &models.Product{
 		Id:         id.String,
+		ParentId:   parent_id.String,
 		Name:       name.String,
 		Price:      price.Float64,
 		Barcode:    barcode.String,
 		CategoryId: category_id.String,
+		Attributes: attributes,
+		Status:     status.String,
 		CreatedAt:  createdAt.String,
 		UpdatedAt:  updatedAt.String,
 	}, nil
 }
 
+// productList are sorts and filters of product list, sorts by created_at, name, price or barcode
+var productList = listSpec{
+	key:      listColumn{`"id"`, "uuid"},
+	fallback: "-created_at",
+	sorts: map[string][]listColumn{
+		"created_at": {{`"created_at"`, "timestamp"}},
+		"name":       {{`"name"`, "text"}},
+		"price":      {{`"price"`, "numeric"}},
+		"barcode":    {{`"barcode"`, "text"}},
+	},
+	filters: map[string]listColumn{
+		"name":        {`"name"`, "text"},
+		"barcode":     {`"barcode"`, "text"},
+		"price":       {`"price"`, "numeric"},
+		"status":      {`"status"::text`, "text"},
+		"category_id": {`"category_id"`, "uuid"},
+		"parent_id":   {`"parent_id"`, "uuid"},
+		"created_at":  {`"created_at"`, "timestamp"},
+		"updated_at":  {`"updated_at"`, "timestamp"},
+	},
+	date: "created_at",
+}
+
 func (r *productRepo) GetList(req *models.ProductGetListRequest) (*models.ProductGetListResponse, error) {
-	params := make(map[string]interface{})
+	page, err := newListPage(productList, req.Page, req.Limit, req.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
 	var resp = &models.ProductGetListResponse{}
 
 	resp.Products = make([]*models.Product, 0)
 
-	filter := " WHERE true "
+	filter, params, err := productFilter(req)
+	if err != nil {
+		return nil, err
+	}
 	query := `
 		SELECT
-			COUNT(*) OVER(),
+			` + page.countColumn() + `,
 			"id",
+			"parent_id",
 			"name",
 			"price",		
 			"barcode",
 			"category_id",
+			"attributes",
+			"status",
 			"created_at",
 			"updated_at" 
 		FROM "product"
 	`
-	if req.Name != "" {
-		filter += ` AND "name" ILIKE '%' || :name || '%' `
-		params["name"] = req.Name
-	}
-
-	if req.Barcode != "" {
-		filter += ` AND ("barcode" = :barcode) `
-		params["barcode"] = req.Barcode
-	}
 
-	offset := (req.Page - 1) * req.Limit
-	params["limit"] = req.Limit
-	params["offset"] = offset
-
-	query = query + filter + " ORDER BY created_at DESC OFFSET :offset LIMIT :limit "
+	query = page.query(query+filter, params)
 	rquery, pArr := helper.ReplaceQueryParams(query, params)
 
 	rows, err := r.db.Query(context.Background(), rquery, pArr...)
@@ -146,46 +380,86 @@ func (r *productRepo) GetList(req *models.ProductGetListRequest) (*models.Produc
 	for rows.Next() {
 		var (
 			id          sql.NullString
+			parent_id   sql.NullString
 			name        sql.NullString
 			price       sql.NullFloat64
 			barcode     sql.NullString
 			category_id sql.NullString
+			attributes  map[string]string
+			status      sql.NullString
 			createdAt   sql.NullString
 			updatedAt   sql.NullString
 		)
-		err := rows.Scan(
+		err := rows.Scan(page.dest(
 			&resp.Count,
 			&id,
+			&parent_id,
 			&name,
 			&price,
 			&barcode,
 			&category_id,
+			&attributes,
+			&status,
 			&createdAt,
 			&updatedAt,
-		)
+		)...)
 		if err != nil {
 			return nil, err
 		}
 		resp.Products = append(resp.Products, &models.Product{
 			Id:         id.String,
+			ParentId:   parent_id.String,
 			Name:       name.String,
 			Price:      price.Float64,
 			Barcode:    barcode.String,
 			CategoryId: category_id.String,
+			Attributes: attributes,
+			Status:     status.String,
 			CreatedAt:  createdAt.String,
 			UpdatedAt:  updatedAt.String,
 		})
 	}
+	resp.NextCursor = page.next()
 	return resp, nil
 }
 
+// Update changes product and revalues its remaining when price changes. Variant keeps category of its parent,
+// new category of parent is given to its variants and attributes of all of them are checked against it
 func (r *productRepo) Update(req *models.UpdateProduct) (string, error) {
+	ctx := context.Background()
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
 	var (
-		query  string
-		params map[string]interface{}
+		oldPrice  float64
+		parent_id sql.NullString
 	)
+	err = tx.QueryRow(ctx, `SELECT "price", "parent_id" FROM "product" WHERE "id" = $1 FOR UPDATE`, req.Id).Scan(&oldPrice, &parent_id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", fmt.Errorf("product with ID %s not found", req.Id)
+		}
+		return "", err
+	}
 
-	query = `
+	if parent_id.Valid {
+		var category_id sql.NullString
+		err = tx.QueryRow(ctx, `SELECT "category_id" FROM "product" WHERE "id" = $1`, parent_id.String).Scan(&category_id)
+		if err != nil {
+			return "", err
+		}
+		req.CategoryId = category_id.String
+	}
+
+	err = checkAttributes(ctx, tx, req.CategoryId, req.Attributes)
+	if err != nil {
+		return "", err
+	}
+
+	query := `
 		UPDATE
 			"product"
 		SET
@@ -193,37 +467,119 @@ func (r *productRepo) Update(req *models.UpdateProduct) (string, error) {
 			"price" = :price,
 			"barcode" = :barcode,
 			"category_id" = :category_id,
+			"attributes" = :attributes,
 			"updated_at" = NOW()
+		WHERE id = :id
 	`
 
-	params = map[string]interface{}{
+	params := map[string]interface{}{
 		"id":          req.Id,
 		"name":        req.Name,
 		"price":       req.Price,
 		"barcode":     req.Barcode,
-		"category_id": req.CategoryId,
+		"category_id": helper.NewNullString(req.CategoryId),
+		"attributes":  productAttributes(req.Attributes),
 	}
 
-	query += `
-		WHERE id = :id
-	`
 	query, args := helper.ReplaceQueryParams(query, params)
 
-	result, err := r.db.Exec(context.Background(), query, args...)
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return "", constraintError(err)
+	}
+
+	err = recordPrice(ctx, tx, req.Id)
 	if err != nil {
 		return "", err
 	}
 
-	if result.RowsAffected() == 0 {
-		return "", fmt.Errorf("product with ID %s not found", req.Id)
+	err = linkProduct(ctx, tx, req.Id)
+	if err != nil {
+		return "", err
+	}
+
+	err = revalue(ctx, tx, req.Id, oldPrice, models.RevaluationProductUpdate, "")
+	if err != nil {
+		return "", err
+	}
+
+	err = syncVariants(ctx, tx, req.Id, req.CategoryId)
+	if err != nil {
+		return "", err
+	}
+
+	return req.Id, tx.Commit(ctx)
+}
+
+// syncVariants gives category of parent product to its variants which have another one
+func syncVariants(ctx context.Context, tx pgx.Tx, parentId, categoryId string) error {
+	query := `
+		SELECT
+			"id",
+			"attributes"
+		FROM "product"
+		WHERE "parent_id" = $1 AND "category_id" IS DISTINCT FROM NULLIF($2, '')::uuid
+		ORDER BY "id"
+		FOR UPDATE
+	`
+
+	rows, err := tx.Query(ctx, query, parentId, categoryId)
+	if err != nil {
+		return err
+	}
+
+	variants := make(map[string]map[string]string)
+	for rows.Next() {
+		var (
+			id         string
+			attributes map[string]string
+		)
+
+		err := rows.Scan(&id, &attributes)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		variants[id] = attributes
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, attributes := range variants {
+		err = checkAttributes(ctx, tx, categoryId, attributes)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE "product" SET "category_id" = NULLIF($2, '')::uuid, "updated_at" = NOW() WHERE "id" = $1`, id, categoryId)
+		if err != nil {
+			return err
+		}
+
+		err = linkProduct(ctx, tx, id)
+		if err != nil {
+			return err
+		}
 	}
 
-	return req.Id, nil
+	return nil
 }
 
+// Delete deletes product with its images, product with variants can be deleted only after them
 func (r *productRepo) Delete(req *models.ProductPrimaryKey) error {
 	ctx := context.Background()
 
+	var variants bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM "product" WHERE "parent_id" = $1)`, req.Id).Scan(&variants)
+	if err != nil {
+		return err
+	}
+	if variants {
+		return &storage.ConstraintError{Constraint: "product_parent_id_fkey", Detail: "product has variants, they must be deleted first"}
+	}
+
 	result, err := r.db.Exec(ctx, "DELETE FROM product WHERE id = $1", req.Id)
 	if err != nil {
 		return err
@@ -271,3 +627,676 @@ func (r *productRepo) GetByBarcode(req *models.ProductBarcodeRequest) (*models.P
 		CategoryId: category_id.String,
 	}, nil
 }
+
+// Search finds products by barcode prefix, prefixes of name words, typo tolerant similarity of name and similar
+// category name, names are compared transliterated. Exact barcode goes first, then barcode prefix, then by text rank
+func (r *productRepo) Search(req *models.ProductSearchRequest) (*models.ProductSearchResponse, error) {
+	var (
+		resp   = &models.ProductSearchResponse{Products: make([]*models.ProductSearchResult, 0)}
+		params = map[string]interface{}{
+			"query":  req.Query,
+			"prefix": search.LikePrefix(req.Query),
+			"text":   search.Latin(req.Query),
+			"limit":  req.Limit,
+		}
+		candidates = []string{
+			`SELECT "id" FROM "product" WHERE "barcode" LIKE :prefix ESCAPE '\'`,
+			`SELECT "id" FROM "product" WHERE :text <% "search_name"`,
+			`SELECT p."id" FROM "product" AS p JOIN "category_match" AS cm ON cm."id" = p."category_id"`,
+		}
+		rank   = `word_similarity(:text, p."search_name") + COALESCE(cm."similarity", 0) * 0.5`
+		filter string
+	)
+
+	if ts := search.PrefixQuery(req.Query); ts != "" {
+		params["ts"] = ts
+		candidates = append(candidates, `SELECT "id" FROM "product" WHERE "search_vector" @@ to_tsquery('simple', :ts)`)
+		rank += ` + ts_rank(p."search_vector", to_tsquery('simple', :ts)) * 2`
+	}
+
+	if req.CategoryId != "" {
+		filter += ` AND p."category_id" = :category_id`
+		params["category_id"] = req.CategoryId
+	}
+
+	if req.Status != "" {
+		filter += ` AND p."status" = :status`
+		params["status"] = req.Status
+	}
+
+	query := `
+		WITH "category_match" AS (
+			SELECT
+				"id",
+				word_similarity(:text, product_search_text("name")) AS "similarity"
+			FROM "category"
+			WHERE :text <% product_search_text("name")
+		),
+		"candidate" AS (
+			` + strings.Join(candidates, "\n\t\t\tUNION\n\t\t\t") + `
+		)
+		SELECT
+			p."id",
+			p."name",
+			p."price",
+			p."barcode",
+			p."category_id",
+			c."name",
+			p."attributes",
+			p."status",
+			p."created_at",
+			p."updated_at",
+			(CASE WHEN p."barcode" = :query THEN 4 WHEN p."barcode" LIKE :prefix ESCAPE '\' THEN 2 ELSE 0 END
+				+ ` + rank + `)::float8 AS "rank"
+		FROM "candidate"
+		JOIN "product" AS p ON p."id" = "candidate"."id"
+		LEFT JOIN "category" AS c ON c."id" = p."category_id"
+		LEFT JOIN "category_match" AS cm ON cm."id" = p."category_id"
+		WHERE true` + filter + `
+		ORDER BY "rank" DESC, p."name"
+		LIMIT :limit
+	`
+
+	query, args := helper.ReplaceQueryParams(query, params)
+
+	rows, err := r.db.Query(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			result       models.ProductSearchResult
+			category_id  sql.NullString
+			categoryName sql.NullString
+			createdAt    sql.NullString
+			updatedAt    sql.NullString
+		)
+		err := rows.Scan(
+			&result.Id,
+			&result.Name,
+			&result.Price,
+			&result.Barcode,
+			&category_id,
+			&categoryName,
+			&result.Attributes,
+			&result.Status,
+			&createdAt,
+			&updatedAt,
+			&result.Rank,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		result.CategoryId = category_id.String
+		result.CategoryName = categoryName.String
+		result.CreatedAt = createdAt.String
+		result.UpdatedAt = updatedAt.String
+		result.Highlight = search.Highlight(result.Name, req.Query)
+
+		resp.Products = append(resp.Products, &result)
+	}
+
+	return resp, rows.Err()
+}
+
+// Approve makes pending product created while receiving a regular one
+func (r *productRepo) Approve(req *models.ProductPrimaryKey) error {
+	query := `
+		UPDATE
+			"product"
+		SET
+			"status" = 'active',
+			"updated_at" = NOW()
+		WHERE "id" = $1 AND "status" = 'pending_review'
+	`
+
+	result, err := r.db.Exec(context.Background(), query, req.Id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("pending product with ID %s not found", req.Id)
+	}
+
+	return nil
+}
+
+// Merge replaces pending product by target product in coming tables, remaining, stock movements and snapshots
+// and deletes the pending product. Its movements and snapshots are added to target like its remaining is,
+// so stock rebuilt from them still agrees with remaining without adjustment movement.
+// Merge is rejected when any of these rows is dated in closed month
+func (r *productRepo) Merge(req *models.MergeProduct) error {
+	var (
+		ctx            = context.Background()
+		pendingBarcode sql.NullString
+		barcode        sql.NullString
+		name           sql.NullString
+		categoryId     sql.NullString
+	)
+
+	if req.Id == req.TargetId {
+		return fmt.Errorf("product can not be merged into itself")
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		SELECT
+			"barcode"
+		FROM "product"
+		WHERE "id" = $1 AND "status" = 'pending_review'
+		FOR UPDATE
+	`
+
+	err = tx.QueryRow(ctx, query, req.Id).Scan(&pendingBarcode)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("pending product with ID %s not found", req.Id)
+		}
+		return err
+	}
+
+	query = `
+		SELECT
+			"barcode",
+			"name",
+			"category_id"
+		FROM "product"
+		WHERE "id" = $1
+	`
+
+	err = tx.QueryRow(ctx, query, req.TargetId).Scan(&barcode, &name, &categoryId)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return fmt.Errorf("product with ID %s not found", req.TargetId)
+		}
+		return err
+	}
+
+	err = checkMergePeriods(ctx, tx, pendingBarcode.String)
+	if err != nil {
+		return err
+	}
+
+	// rows of both barcodes are locked in one order before counts are read and added
+	lockQueries := []string{
+		`SELECT 1 FROM "coming_table_product" WHERE "barcode" IN ($1, $2) ORDER BY "id" FOR UPDATE`,
+		`SELECT 1 FROM "remaining" WHERE "barcode" IN ($1, $2) ORDER BY "id" FOR UPDATE`,
+	}
+
+	for _, query := range lockQueries {
+		_, err = tx.Exec(ctx, query, pendingBarcode.String, barcode.String)
+		if err != nil {
+			return err
+		}
+	}
+
+	// coming tables and branches which already have target product get pending counts added to it
+	mergeQueries := []string{
+		`UPDATE "coming_table_product" AS t
+		SET "count" = t."count" + p."count", "total_price" = t."total_price" + p."total_price", "updated_at" = NOW()
+		FROM "coming_table_product" AS p
+		WHERE p."barcode" = $1 AND t."barcode" = $2 AND t."coming_table_id" = p."coming_table_id"`,
+		`DELETE FROM "coming_table_product" AS p
+		WHERE p."barcode" = $1 AND EXISTS (
+			SELECT 1 FROM "coming_table_product" AS t
+			WHERE t."barcode" = $2 AND t."coming_table_id" = p."coming_table_id"
+		)`,
+		`UPDATE "remaining" AS t
+		SET "count" = t."count" + p."count", "total_price" = t."total_price" + p."total_price", "updated_at" = NOW()
+		FROM "remaining" AS p
+		WHERE p."barcode" = $1 AND t."barcode" = $2 AND t."branch_id" = p."branch_id"`,
+		`DELETE FROM "remaining" AS p
+		WHERE p."barcode" = $1 AND EXISTS (
+			SELECT 1 FROM "remaining" AS t
+			WHERE t."barcode" = $2 AND t."branch_id" = p."branch_id"
+		)`,
+		`UPDATE "stock_snapshot" AS t
+		SET "count" = t."count" + p."count", "total_price" = t."total_price" + p."total_price"
+		FROM "stock_snapshot" AS p
+		WHERE p."barcode" = $1 AND t."barcode" = $2 AND t."branch_id" = p."branch_id" AND t."period_end" = p."period_end"`,
+		`DELETE FROM "stock_snapshot" AS p
+		WHERE p."barcode" = $1 AND EXISTS (
+			SELECT 1 FROM "stock_snapshot" AS t
+			WHERE t."barcode" = $2 AND t."branch_id" = p."branch_id" AND t."period_end" = p."period_end"
+		)`,
+	}
+
+	for _, query := range mergeQueries {
+		_, err = tx.Exec(ctx, query, pendingBarcode.String, barcode.String)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the rest of pending rows are renamed to target product
+	renameQueries := []string{
+		`UPDATE "coming_table_product"
+		SET "barcode" = $2, "name" = $3, "category_id" = $4, "product_id" = ` + productIdByBarcode("$2") + `, "updated_at" = NOW()
+		WHERE "barcode" = $1`,
+		`UPDATE "remaining"
+		SET "barcode" = $2, "name" = $3, "category_id" = $4, "product_id" = ` + productIdByBarcode("$2") + `, "updated_at" = NOW()
+		WHERE "barcode" = $1`,
+		`UPDATE "purchase_order_product"
+		SET "barcode" = $2, "name" = $3, "category_id" = $4, "updated_at" = NOW()
+		WHERE "barcode" = $1`,
+		`UPDATE "stock_movement"
+		SET "barcode" = $2, "name" = $3, "category_id" = $4, "product_id" = ` + productIdByBarcode("$2") + `
+		WHERE "barcode" = $1`,
+		`UPDATE "stock_snapshot"
+		SET "barcode" = $2, "name" = $3, "category_id" = $4
+		WHERE "barcode" = $1`,
+	}
+
+	for _, query := range renameQueries {
+		_, err = tx.Exec(ctx, query, pendingBarcode.String, barcode.String, name.String, categoryId)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM "product" WHERE "id" = $1`, req.Id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// checkMergePeriods returns PeriodClosedError when merge would change rows of barcode dated in closed month:
+// lines of coming tables, movements, snapshots closing the month and remaining which is dated now.
+// Months are share locked, so they are not closed until the merge is committed
+func checkMergePeriods(ctx context.Context, tx pgx.Tx, barcode string) error {
+	type period struct {
+		branchId string
+		month    string
+	}
+
+	query := `
+		SELECT DISTINCT
+			a."branch_id"::text,
+			DATE_TRUNC('month', a."date_time")::text
+		FROM (
+			SELECT ct."branch_id", COALESCE(ct."date_time", NOW()) AS "date_time"
+			FROM "coming_table_product" AS ctp
+			JOIN "coming_table" AS ct ON ct."id" = ctp."coming_table_id"
+			WHERE ctp."barcode" = $1
+			UNION ALL
+			SELECT "branch_id", "date_time" FROM "stock_movement" WHERE "barcode" = $1
+			UNION ALL
+			SELECT "branch_id", "period_end" - INTERVAL '1 day' FROM "stock_snapshot" WHERE "barcode" = $1
+			UNION ALL
+			SELECT "branch_id", NOW() FROM "remaining" WHERE "barcode" = $1
+		) AS a
+		WHERE a."branch_id" IS NOT NULL
+		ORDER BY 1, 2
+	`
+
+	rows, err := tx.Query(ctx, query, barcode)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var periods []period
+	for rows.Next() {
+		var p period
+		err := rows.Scan(&p.branchId, &p.month)
+		if err != nil {
+			return err
+		}
+		periods = append(periods, p)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, p := range periods {
+		err = checkPeriod(ctx, tx, p.branchId, p.month)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Import upserts products by barcode and creates missing categories of their paths,
+// on dry run everything is rolled back but counted the same way
+func (r *productRepo) Import(req *models.ProductImportRequest) (*models.ProductImportResponse, error) {
+	var (
+		ctx        = context.Background()
+		resp       = &models.ProductImportResponse{DryRun: req.DryRun, Total: len(req.Rows), Errors: make([]*models.ImportRowError, 0)}
+		categories = make(map[string]string)
+	)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	for _, row := range req.Rows {
+		// every row has its own savepoint so failed row does not abort the whole import
+		rowTx, err := tx.Begin(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		// categories found or created by the row are cached only when its savepoint is committed,
+		// otherwise later rows would get ids of categories rolled back with it
+		found := make(map[string]string)
+
+		inserted, created, err := r.importRow(ctx, rowTx, row, categories, found)
+		if err != nil {
+			rowTx.Rollback(ctx)
+			resp.Errors = append(resp.Errors, &models.ImportRowError{Row: row.Row, Barcode: row.Barcode, Error: err.Error()})
+			continue
+		}
+
+		err = rowTx.Commit(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for key, id := range found {
+			categories[key] = id
+		}
+
+		if inserted {
+			resp.Created++
+		} else {
+			resp.Updated++
+		}
+		resp.CategoriesCreated += created
+	}
+
+	if req.DryRun {
+		return resp, nil
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// importRow upserts product of the row like Update does: variant keeps category of its parent, new category
+// of parent is given to its variants and attributes are checked against the category
+func (r *productRepo) importRow(ctx context.Context, tx pgx.Tx, row *models.ProductImportRow, categories, found map[string]string) (bool, int, error) {
+	var (
+		inserted    bool
+		created     int
+		id          string
+		oldPrice    sql.NullFloat64
+		parent_id   sql.NullString
+		category_id sql.NullString
+		attributes  map[string]string
+		categoryId  string
+	)
+
+	query := `
+		SELECT
+			"price",
+			"parent_id",
+			"category_id",
+			"attributes"
+		FROM "product"
+		WHERE "barcode" = $1
+		FOR UPDATE
+	`
+
+	err := tx.QueryRow(ctx, query, row.Barcode).Scan(&oldPrice, &parent_id, &category_id, &attributes)
+	if err != nil && err != pgx.ErrNoRows {
+		return false, 0, err
+	}
+
+	if parent_id.Valid {
+		var parentCategory sql.NullString
+		err = tx.QueryRow(ctx, `SELECT "category_id" FROM "product" WHERE "id" = $1`, parent_id.String).Scan(&parentCategory)
+		if err != nil {
+			return false, 0, err
+		}
+		categoryId = parentCategory.String
+	} else {
+		categoryId, created, err = r.categoryByPath(ctx, tx, row.CategoryPath, categories, found)
+		if err != nil {
+			return false, 0, err
+		}
+		// empty category path keeps category of existing product
+		if categoryId == "" {
+			categoryId = category_id.String
+		}
+	}
+
+	err = checkAttributes(ctx, tx, categoryId, attributes)
+	if err != nil {
+		return false, 0, err
+	}
+
+	query = `
+		INSERT INTO "product"(
+			"id",
+			"name",
+			"price",
+			"barcode",
+			"category_id",
+			"created_at")
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT ("barcode") DO UPDATE
+		SET
+			"name" = EXCLUDED."name",
+			"price" = EXCLUDED."price",
+			"category_id" = EXCLUDED."category_id",
+			"updated_at" = NOW()
+		RETURNING (xmax = 0), "id"
+	`
+
+	err = tx.QueryRow(ctx, query,
+		uuid.NewString(),
+		row.Name,
+		row.Price,
+		row.Barcode,
+		helper.NewNullString(categoryId),
+	).Scan(&inserted, &id)
+	if err != nil {
+		return false, 0, err
+	}
+
+	err = recordPrice(ctx, tx, id)
+	if err != nil {
+		return false, 0, err
+	}
+
+	err = linkProduct(ctx, tx, id)
+	if err != nil {
+		return false, 0, err
+	}
+
+	if oldPrice.Valid {
+		err = revalue(ctx, tx, id, oldPrice.Float64, models.RevaluationImport, "")
+		if err != nil {
+			return false, 0, err
+		}
+	}
+
+	if !parent_id.Valid {
+		err = syncVariants(ctx, tx, id, categoryId)
+		if err != nil {
+			return false, 0, err
+		}
+	}
+
+	return inserted, created, nil
+}
+
+// categoryByPath finds nested categories by names and creates the missing ones, returns id of the last one.
+// Ids are looked up in categories cached by committed rows and in found, which gets ids found by this row
+func (r *productRepo) categoryByPath(ctx context.Context, tx pgx.Tx, path []string, categories, found map[string]string) (string, int, error) {
+	var (
+		parentId string
+		key      string
+		created  int
+	)
+
+	for _, name := range path {
+		key += "/" + strings.ToLower(name)
+		if id, ok := categories[key]; ok {
+			parentId = id
+			continue
+		}
+		if id, ok := found[key]; ok {
+			parentId = id
+			continue
+		}
+
+		var id sql.NullString
+		query := `
+			SELECT
+				"id"
+			FROM "category"
+			WHERE LOWER("name") = LOWER($1) AND "parent_id" IS NOT DISTINCT FROM $2
+			LIMIT 1
+		`
+
+		err := tx.QueryRow(ctx, query, name, helper.NewNullString(parentId)).Scan(&id)
+		if err != nil && err != pgx.ErrNoRows {
+			return "", 0, err
+		}
+
+		if !id.Valid {
+			id.String = uuid.NewString()
+			query = `
+				INSERT INTO "category"(
+					"id",
+					"name",
+					"parent_id",
+					"created_at")
+				VALUES ($1, $2, $3, NOW())
+			`
+
+			_, err = tx.Exec(ctx, query, id.String, name, helper.NewNullString(parentId))
+			if err != nil {
+				return "", 0, err
+			}
+			created++
+		}
+
+		found[key] = id.String
+		parentId = id.String
+	}
+
+	return parentId, created, nil
+}
+
+// productFilter builds WHERE clause of list filters, it is shared by GetList and Export
+func productFilter(req *models.ProductGetListRequest) (string, map[string]interface{}, error) {
+	params := make(map[string]interface{})
+	filter := " WHERE true "
+
+	if req.Name != "" {
+		filter += ` AND "name" ILIKE '%' || :name || '%' `
+		params["name"] = req.Name
+	}
+
+	if req.Barcode != "" {
+		filter += ` AND ("barcode" = :barcode) `
+		params["barcode"] = req.Barcode
+	}
+
+	if req.Status != "" {
+		filter += ` AND ("status" = :status) `
+		params["status"] = req.Status
+	}
+
+	where, err := listWhere(productList, req.ListOptions, params)
+	return filter + where, params, err
+}
+
+// Export streams all products matching list filters with category names to fn
+func (r *productRepo) Export(req *models.ProductGetListRequest, fn func(*models.Product, *models.ExportNames) error) error {
+	filter, params, err := productFilter(req)
+	if err != nil {
+		return err
+	}
+	order, err := listOrder(productList, req.Sort)
+	if err != nil {
+		return err
+	}
+	query := `
+		SELECT
+			p."id",
+			p."name",
+			p."price",
+			p."barcode",
+			p."category_id",
+			c."name",
+			p."status",
+			p."created_at",
+			p."updated_at"
+		FROM (SELECT *, ROW_NUMBER() OVER (ORDER BY ` + order + `) AS "list_row" FROM "product" ` + filter + `) AS p
+		LEFT JOIN "category" AS c ON c."id" = p."category_id"
+		ORDER BY p."list_row"
+	`
+	rquery, pArr := helper.ReplaceQueryParams(query, params)
+
+	rows, err := r.db.Query(context.Background(), rquery, pArr...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id           sql.NullString
+			name         sql.NullString
+			price        sql.NullFloat64
+			barcode      sql.NullString
+			category_id  sql.NullString
+			categoryName sql.NullString
+			status       sql.NullString
+			createdAt    sql.NullString
+			updatedAt    sql.NullString
+		)
+		err := rows.Scan(
+			&id,
+			&name,
+			&price,
+			&barcode,
+			&category_id,
+			&categoryName,
+			&status,
+			&createdAt,
+			&updatedAt,
+		)
+		if err != nil {
+			return err
+		}
+
+		err = fn(&models.Product{
+			Id:         id.String,
+			Name:       name.String,
+			Price:      price.Float64,
+			Barcode:    barcode.String,
+			CategoryId: category_id.String,
+			Status:     status.String,
+			CreatedAt:  createdAt.String,
+			UpdatedAt:  updatedAt.String,
+		}, &models.ExportNames{CategoryName: categoryName.String})
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
@@ -0,0 +1,185 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"market/models"
+	"market/storage"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type productImageRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewProductImageRepo(db *pgxpool.Pool) *productImageRepo {
+	return &productImageRepo{
+		db: db,
+	}
+}
+
+// Create adds image after the last one of product, product is locked so concurrent uploads get distinct positions
+func (r *productImageRepo) Create(req *models.CreateProductImage) (string, error) {
+	var (
+		ctx   = context.Background()
+		id    = uuid.NewString()
+		count int
+	)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `SELECT 1 FROM "product" WHERE "id" = $1 FOR UPDATE`, req.ProductId).Scan(&count)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", fmt.Errorf("product with ID %s not found", req.ProductId)
+		}
+		return "", err
+	}
+
+	err = tx.QueryRow(ctx, `SELECT COUNT(*) FROM "product_image" WHERE "product_id" = $1`, req.ProductId).Scan(&count)
+	if err != nil {
+		return "", err
+	}
+	if count >= models.MaxProductImages {
+		return "", &storage.ConstraintError{Constraint: "product_image", Detail: fmt.Sprintf("product can have at most %d images", models.MaxProductImages)}
+	}
+
+	query := `
+		INSERT INTO "product_image"(
+			"id",
+			"product_id",
+			"key",
+			"content_type",
+			"size",
+			"position",
+			"created_at")
+		SELECT $1, $2, $3, $4, $5, COALESCE(MAX("position") + 1, 0), NOW()
+		FROM "product_image"
+		WHERE "product_id" = $2
+	`
+
+	_, err = tx.Exec(ctx, query,
+		id,
+		req.ProductId,
+		req.Key,
+		req.ContentType,
+		req.Size,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return id, tx.Commit(ctx)
+}
+
+func (r *productImageRepo) GetByID(req *models.ProductImagePrimaryKey) (*models.ProductImage, error) {
+	query := `
+		SELECT
+			"id",
+			"product_id",
+			"key",
+			"content_type",
+			"size",
+			"position",
+			"created_at"
+		FROM "product_image"
+		WHERE "id" = $1
+	`
+
+	image, err := scanProductImage(r.db.QueryRow(context.Background(), query, req.Id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("product image with ID %s not found", req.Id)
+		}
+		return nil, err
+	}
+
+	return image, nil
+}
+
+func (r *productImageRepo) GetList(req *models.ProductPrimaryKey) (*models.ProductImageGetListResponse, error) {
+	images, err := productImages(context.Background(), r.db, req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.ProductImageGetListResponse{Images: images}, nil
+}
+
+func (r *productImageRepo) Delete(req *models.ProductImagePrimaryKey) error {
+	result, err := r.db.Exec(context.Background(), `DELETE FROM "product_image" WHERE "id" = $1`, req.Id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("product image with ID %s not found", req.Id)
+	}
+
+	return nil
+}
+
+// productImages returns images of product in their order, Url is left for the handler to fill from the file store
+func productImages(ctx context.Context, db *pgxpool.Pool, productId string) ([]*models.ProductImage, error) {
+	query := `
+		SELECT
+			"id",
+			"product_id",
+			"key",
+			"content_type",
+			"size",
+			"position",
+			"created_at"
+		FROM "product_image"
+		WHERE "product_id" = $1
+		ORDER BY "position", "created_at"
+	`
+
+	rows, err := db.Query(ctx, query, productId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	images := make([]*models.ProductImage, 0)
+	for rows.Next() {
+		image, err := scanProductImage(rows)
+		if err != nil {
+			return nil, err
+		}
+		images = append(images, image)
+	}
+
+	return images, rows.Err()
+}
+
+func scanProductImage(row pgx.Row) (*models.ProductImage, error) {
+	var (
+		image      models.ProductImage
+		created_at sql.NullString
+	)
+
+	err := row.Scan(
+		&image.Id,
+		&image.ProductId,
+		&image.Key,
+		&image.ContentType,
+		&image.Size,
+		&image.Position,
+		&created_at,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	image.CreatedAt = created_at.String
+	return &image, nil
+}
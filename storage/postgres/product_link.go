@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v4"
+)
+
+// productIdByBarcode is id of product with barcode, rows written by barcode are linked to product with it
+func productIdByBarcode(barcode string) string {
+	return `(SELECT "id" FROM "product" WHERE "barcode" = ` + barcode + `)`
+}
+
+// remainingResolved is remaining rows of source with name and category of the linked product,
+// price, count and total_price are kept as stored because they are the stock valuation
+func remainingResolved(source string) string {
+	return `(
+		SELECT
+			s."id",
+			s."branch_id",
+			COALESCE(p."category_id", s."category_id") AS "category_id",
+			COALESCE(p."name", s."name") AS "name",
+			s."price",
+			s."barcode",
+			s."count",
+			s."total_price",
+			s."created_at",
+			s."updated_at",
+			s."product_id",
+			s."version"
+		FROM ` + source + ` AS s
+		LEFT JOIN "product" AS p ON p."id" = s."product_id"
+	)`
+}
+
+// comingLinesResolved is coming_table_product rows with name and category of the linked product,
+// price, count and total_price are kept as stored because they are what the document received
+const comingLinesResolved = `(
+		SELECT
+			l."id",
+			COALESCE(p."category_id", l."category_id") AS "category_id",
+			COALESCE(p."name", l."name") AS "name",
+			l."price",
+			l."barcode",
+			l."count",
+			l."total_price",
+			l."coming_table_id",
+			l."created_at",
+			l."updated_at",
+			l."product_id",
+			l."version"
+		FROM "coming_table_product" AS l
+		LEFT JOIN "product" AS p ON p."id" = l."product_id"
+	)`
+
+// linkProduct keeps rows written by barcode linked to product after it is created or changed:
+// unlinked rows of its barcode get linked and linked rows get its current barcode,
+// remaining is current stock so it gets current name and category too, other tables keep them as history
+func linkProduct(ctx context.Context, tx pgx.Tx, productId string) error {
+	// extra columns to set and condition under which they differ
+	tables := map[string][2]string{
+		`"remaining"`: {
+			`, "name" = p."name", "category_id" = p."category_id", "updated_at" = NOW()`,
+			` OR t."name" <> p."name" OR t."category_id" IS DISTINCT FROM p."category_id"`,
+		},
+		`"coming_table_product"`: {},
+		`"stock_movement"`:       {},
+	}
+
+	for table, extra := range tables {
+		query := `
+			UPDATE
+				` + table + ` AS t
+			SET
+				"product_id" = p."id",
+				"barcode" = p."barcode"` + extra[0] + `
+			FROM "product" AS p
+			WHERE p."id" = $1
+				AND (t."product_id" = p."id" OR (t."product_id" IS NULL AND t."barcode" = p."barcode"))
+				AND (t."product_id" IS NULL OR t."barcode" <> p."barcode"` + extra[1] + `)
+		`
+
+		_, err := tx.Exec(ctx, query, productId)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,351 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"market/models"
+	"market/pkg/helper"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// applyDuePrices copies latest base price which became effective to product, marks pending changes applied
+// and revalues remaining of changed products, empty productId applies due changes of all products
+func applyDuePrices(ctx context.Context, tx pgx.Tx, productId string) (int64, error) {
+	type duePrice struct {
+		productId string
+		price     float64
+		user      string
+	}
+
+	query := `
+		WITH "due" AS (
+			UPDATE "product_price"
+			SET "applied_at" = NOW()
+			WHERE "branch_id" IS NULL AND "applied_at" IS NULL AND "effective_from" <= NOW()
+				AND (NULLIF($1, '') IS NULL OR "product_id" = NULLIF($1, '')::uuid)
+			RETURNING "product_id"
+		)
+		SELECT DISTINCT ON ("product_id")
+			"product_id",
+			"price",
+			"created_by"
+		FROM "product_price"
+		WHERE "product_id" IN (SELECT "product_id" FROM "due")
+			AND "branch_id" IS NULL AND "effective_from" <= NOW()
+		ORDER BY "product_id", "effective_from" DESC
+	`
+
+	rows, err := tx.Query(ctx, query, productId)
+	if err != nil {
+		return 0, err
+	}
+
+	var prices []*duePrice
+	for rows.Next() {
+		var (
+			product_id sql.NullString
+			price      sql.NullFloat64
+			created_by sql.NullString
+		)
+
+		err := rows.Scan(&product_id, &price, &created_by)
+		if err != nil {
+			rows.Close()
+			return 0, err
+		}
+		prices = append(prices, &duePrice{productId: product_id.String, price: price.Float64, user: created_by.String})
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	query = `
+		UPDATE
+			"product" AS p
+		SET
+			"price" = $2,
+			"updated_at" = NOW()
+		FROM (SELECT "id", "price" FROM "product" WHERE "id" = $1 FOR UPDATE) AS old
+		WHERE p."id" = old."id" AND old."price" <> $2
+		RETURNING old."price"
+	`
+
+	var changed int64
+	for _, due := range prices {
+		var oldPrice float64
+
+		err := tx.QueryRow(ctx, query, due.productId, due.price).Scan(&oldPrice)
+		if err != nil {
+			if err == pgx.ErrNoRows {
+				continue
+			}
+			return 0, err
+		}
+
+		err = revalue(ctx, tx, due.productId, oldPrice, models.RevaluationScheduledPrice, due.user)
+		if err != nil {
+			return 0, err
+		}
+		changed++
+	}
+
+	return changed, nil
+}
+
+// recordPrice adds current price of product to its history when it differs from the latest effective base price
+func recordPrice(ctx context.Context, tx pgx.Tx, productId string) error {
+	query := `
+		INSERT INTO "product_price"(
+			"id",
+			"product_id",
+			"price",
+			"effective_from",
+			"applied_at",
+			"created_at")
+		SELECT gen_random_uuid(), p."id", p."price", NOW(), NOW(), NOW()
+		FROM "product" AS p
+		WHERE p."id" = $1 AND p."price" IS DISTINCT FROM (
+			SELECT "price" FROM "product_price"
+			WHERE "product_id" = p."id" AND "branch_id" IS NULL AND "effective_from" <= NOW()
+			ORDER BY "effective_from" DESC
+			LIMIT 1
+		)
+	`
+
+	_, err := tx.Exec(ctx, query, productId)
+	return err
+}
+
+type productPriceRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewProductPriceRepo(db *pgxpool.Pool) *productPriceRepo {
+	return &productPriceRepo{
+		db: db,
+	}
+}
+
+// Create schedules price change, base price which is already effective is copied to product at once
+func (r *productPriceRepo) Create(req *models.CreateProductPrice) (string, error) {
+	var (
+		ctx = context.Background()
+		id  = uuid.NewString()
+	)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO "product_price"(
+			"id",
+			"product_id",
+			"branch_id",
+			"price",
+			"effective_from",
+			"created_by",
+			"created_at")
+		VALUES ($1, $2, $3, $4, COALESCE(NULLIF($5, '')::timestamp, NOW()), $6, NOW())
+	`
+
+	_, err = tx.Exec(ctx, query,
+		id,
+		req.ProductId,
+		helper.NewNullString(req.BranchId),
+		req.Price,
+		req.EffectiveFrom,
+		helper.NewNullString(req.CreatedBy),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	if req.BranchId == "" {
+		_, err = applyDuePrices(ctx, tx, req.ProductId)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return id, tx.Commit(ctx)
+}
+
+func (r *productPriceRepo) GetList(req *models.ProductPriceGetListRequest) (*models.ProductPriceGetListResponse, error) {
+	resp := &models.ProductPriceGetListResponse{}
+
+	query := `
+		SELECT
+			"id",
+			"product_id",
+			"branch_id",
+			"price",
+			"effective_from",
+			"applied_at",
+			"created_by",
+			"created_at"
+		FROM "product_price"
+		WHERE "product_id" = $1 AND (NULLIF($2, '') IS NULL OR "branch_id" = NULLIF($2, '')::uuid)
+		ORDER BY "effective_from" DESC
+	`
+
+	rows, err := r.db.Query(context.Background(), query, req.ProductId, req.BranchId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id             sql.NullString
+			product_id     sql.NullString
+			branch_id      sql.NullString
+			price          sql.NullFloat64
+			effective_from sql.NullString
+			applied_at     sql.NullString
+			created_by     sql.NullString
+			created_at     sql.NullString
+		)
+
+		err := rows.Scan(
+			&id,
+			&product_id,
+			&branch_id,
+			&price,
+			&effective_from,
+			&applied_at,
+			&created_by,
+			&created_at,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Prices = append(resp.Prices, &models.ProductPrice{
+			Id:            id.String,
+			ProductId:     product_id.String,
+			BranchId:      branch_id.String,
+			Price:         price.Float64,
+			EffectiveFrom: effective_from.String,
+			AppliedAt:     applied_at.String,
+			CreatedBy:     created_by.String,
+			CreatedAt:     created_at.String,
+		})
+	}
+	return resp, rows.Err()
+}
+
+// Delete cancels price change which is not effective yet, history is never changed
+func (r *productPriceRepo) Delete(req *models.ProductPricePrimaryKey) error {
+	query := `
+		DELETE FROM "product_price"
+		WHERE "id" = $1 AND "effective_from" > NOW()
+	`
+
+	result, err := r.db.Exec(context.Background(), query, req.Id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("scheduled price with ID %s not found or already effective", req.Id)
+	}
+
+	return nil
+}
+
+// Effective returns price of barcode at the moment, override of the branch wins over base price
+// and product price is used when there is no history yet
+func (r *productPriceRepo) Effective(req *models.EffectivePriceRequest) (*models.EffectivePrice, error) {
+	var (
+		product_id     sql.NullString
+		barcode        sql.NullString
+		name           sql.NullString
+		price          sql.NullFloat64
+		effective_from sql.NullString
+		source         sql.NullString
+	)
+
+	query := `
+		SELECT
+			p."id",
+			p."barcode",
+			p."name",
+			COALESCE(b."price", g."price", p."price"),
+			COALESCE(b."effective_from", g."effective_from"),
+			CASE
+				WHEN b."price" IS NOT NULL THEN 'branch'
+				WHEN g."price" IS NOT NULL THEN 'base'
+				ELSE 'product'
+			END
+		FROM "product" AS p
+		LEFT JOIN LATERAL (
+			SELECT "price", "effective_from" FROM "product_price"
+			WHERE "product_id" = p."id" AND "branch_id" = NULLIF($2, '')::uuid AND "effective_from" <= COALESCE($3::timestamp, NOW())
+			ORDER BY "effective_from" DESC
+			LIMIT 1
+		) AS b ON true
+		LEFT JOIN LATERAL (
+			SELECT "price", "effective_from" FROM "product_price"
+			WHERE "product_id" = p."id" AND "branch_id" IS NULL AND "effective_from" <= COALESCE($3::timestamp, NOW())
+			ORDER BY "effective_from" DESC
+			LIMIT 1
+		) AS g ON true
+		WHERE p."barcode" = $1
+	`
+
+	err := r.db.QueryRow(context.Background(), query,
+		req.Barcode,
+		req.BranchId,
+		sql.NullTime{Time: req.At, Valid: !req.At.IsZero()},
+	).Scan(
+		&product_id,
+		&barcode,
+		&name,
+		&price,
+		&effective_from,
+		&source,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("product with barcode %s not found", req.Barcode)
+		}
+		return nil, err
+	}
+
+	return &models.EffectivePrice{
+		ProductId:     product_id.String,
+		Barcode:       barcode.String,
+		Name:          name.String,
+		BranchId:      req.BranchId,
+		Price:         price.Float64,
+		EffectiveFrom: effective_from.String,
+		Source:        source.String,
+	}, nil
+}
+
+// ApplyDue copies base prices which became effective to products and revalues their remaining,
+// returns count of changed products
+func (r *productPriceRepo) ApplyDue() (int64, error) {
+	ctx := context.Background()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	changed, err := applyDuePrices(ctx, tx, "")
+	if err != nil {
+		return 0, err
+	}
+
+	return changed, tx.Commit(ctx)
+}
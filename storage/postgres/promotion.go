@@ -0,0 +1,499 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"market/models"
+	"market/pkg/helper"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type promotionRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPromotionRepo(db *pgxpool.Pool) *promotionRepo {
+	return &promotionRepo{
+		db: db,
+	}
+}
+
+const promotionColumns = `
+			"id",
+			"name",
+			"type",
+			"percent",
+			"buy_count",
+			"free_count",
+			"bundle_count",
+			"bundle_price",
+			"product_ids",
+			"category_ids",
+			"branch_id",
+			"starts_at",
+			"ends_at",
+			"priority",
+			"active",
+			"created_at",
+			"updated_at"`
+
+func (r *promotionRepo) Create(req *models.CreatePromotion) (string, error) {
+	var (
+		id = uuid.NewString()
+	)
+
+	query := `
+		INSERT INTO "promotion"(
+			"id",
+			"name",
+			"type",
+			"percent",
+			"buy_count",
+			"free_count",
+			"bundle_count",
+			"bundle_price",
+			"product_ids",
+			"category_ids",
+			"branch_id",
+			"starts_at",
+			"ends_at",
+			"priority",
+			"active",
+			"created_at")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9::uuid[], '{}'), COALESCE($10::uuid[], '{}'), $11,
+			COALESCE(NULLIF($12, '')::timestamp, NOW()), NULLIF($13, '')::timestamp, $14, $15, NOW())
+	`
+
+	_, err := r.db.Exec(context.Background(), query,
+		id,
+		req.Name,
+		req.Type,
+		req.Percent,
+		req.BuyCount,
+		req.FreeCount,
+		req.BundleCount,
+		req.BundlePrice,
+		req.ProductIds,
+		req.CategoryIds,
+		helper.NewNullString(req.BranchId),
+		req.StartsAt,
+		req.EndsAt,
+		req.Priority,
+		req.Active,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (r *promotionRepo) GetByID(req *models.PromotionPrimaryKey) (*models.Promotion, error) {
+	query := `
+		SELECT` + promotionColumns + `
+		FROM "promotion"
+		WHERE "id" = $1
+	`
+
+	promotion, err := scanPromotion(r.db.QueryRow(context.Background(), query, req.Id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("promotion with ID %s not found", req.Id)
+		}
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// promotionList are sorts and filters of promotion list,
+// sorts by priority (then created_at), created_at, name or starts_at
+var promotionList = listSpec{
+	key:      listColumn{`"id"`, "uuid"},
+	fallback: "-priority",
+	sorts: map[string][]listColumn{
+		"priority":   {{`"priority"`, "int"}, {`"created_at"`, "timestamp"}},
+		"created_at": {{`"created_at"`, "timestamp"}},
+		"name":       {{`"name"`, "text"}},
+		"starts_at":  {{`"starts_at"`, "timestamp"}},
+	},
+	filters: map[string]listColumn{
+		"name":       {`"name"`, "text"},
+		"type":       {`"type"::text`, "text"},
+		"percent":    {`"percent"`, "numeric"},
+		"branch_id":  {`"branch_id"`, "uuid"},
+		"priority":   {`"priority"`, "int"},
+		"active":     {`"active"`, "boolean"},
+		"starts_at":  {`"starts_at"`, "timestamp"},
+		"ends_at":    {`"ends_at"`, "timestamp"},
+		"created_at": {`"created_at"`, "timestamp"},
+		"updated_at": {`"updated_at"`, "timestamp"},
+	},
+	date: "created_at",
+}
+
+func (r *promotionRepo) GetList(req *models.PromotionGetListRequest) (*models.PromotionGetListResponse, error) {
+	page, err := newListPage(promotionList, req.Page, req.Limit, req.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		resp   = &models.PromotionGetListResponse{Promotions: make([]*models.Promotion, 0)}
+		params = make(map[string]interface{})
+		filter = " WHERE true "
+		count  int
+	)
+
+	if req.Search != "" {
+		filter += ` AND "name" ILIKE '%' || :search || '%' `
+		params["search"] = req.Search
+	}
+
+	if req.BranchId != "" {
+		filter += ` AND ("branch_id" IS NULL OR "branch_id" = :branch_id) `
+		params["branch_id"] = req.BranchId
+	}
+
+	where, err := listWhere(promotionList, req.ListOptions, params)
+	if err != nil {
+		return nil, err
+	}
+	filter += where
+
+	query := `
+		SELECT
+			` + page.countColumn() + `,` + promotionColumns + `
+		FROM "promotion"
+	` + filter
+
+	query, args := helper.ReplaceQueryParams(page.query(query, params), params)
+
+	rows, err := r.db.Query(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		promotion, err := scanPromotion(rows, page.dest(&count)...)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Count = count
+		resp.Promotions = append(resp.Promotions, promotion)
+	}
+
+	resp.NextCursor = page.next()
+	return resp, rows.Err()
+}
+
+func (r *promotionRepo) Update(req *models.UpdatePromotion) (string, error) {
+	query := `
+		UPDATE
+			"promotion"
+		SET
+			"name" = $2,
+			"type" = $3,
+			"percent" = $4,
+			"buy_count" = $5,
+			"free_count" = $6,
+			"bundle_count" = $7,
+			"bundle_price" = $8,
+			"product_ids" = COALESCE($9::uuid[], '{}'),
+			"category_ids" = COALESCE($10::uuid[], '{}'),
+			"branch_id" = $11,
+			"starts_at" = COALESCE(NULLIF($12, '')::timestamp, "starts_at"),
+			"ends_at" = NULLIF($13, '')::timestamp,
+			"priority" = $14,
+			"active" = $15,
+			"updated_at" = NOW()
+		WHERE "id" = $1
+	`
+
+	result, err := r.db.Exec(context.Background(), query,
+		req.Id,
+		req.Name,
+		req.Type,
+		req.Percent,
+		req.BuyCount,
+		req.FreeCount,
+		req.BundleCount,
+		req.BundlePrice,
+		req.ProductIds,
+		req.CategoryIds,
+		helper.NewNullString(req.BranchId),
+		req.StartsAt,
+		req.EndsAt,
+		req.Priority,
+		req.Active,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	if result.RowsAffected() == 0 {
+		return "", fmt.Errorf("promotion with ID %s not found", req.Id)
+	}
+
+	return req.Id, nil
+}
+
+// Delete removes promotion which has not granted discounts yet, used ones are kept for reports
+func (r *promotionRepo) Delete(req *models.PromotionPrimaryKey) error {
+	query := `
+		DELETE FROM "promotion"
+		WHERE "id" = $1 AND NOT EXISTS (SELECT 1 FROM "promotion_usage" WHERE "promotion_id" = $1)
+	`
+
+	result, err := r.db.Exec(context.Background(), query, req.Id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("promotion with ID %s not found or already granted discounts, deactivate it instead", req.Id)
+	}
+
+	return nil
+}
+
+// Active returns promotions running in the branch at the moment by priority
+func (r *promotionRepo) Active(req *models.ActivePromotionRequest) ([]*models.Promotion, error) {
+	promotions := make([]*models.Promotion, 0)
+
+	query := `
+		SELECT` + promotionColumns + `
+		FROM "promotion"
+		WHERE "active"
+			AND "starts_at" <= COALESCE(NULLIF($2, '')::timestamp, NOW())
+			AND ("ends_at" IS NULL OR "ends_at" > COALESCE(NULLIF($2, '')::timestamp, NOW()))
+			AND ("branch_id" IS NULL OR "branch_id" = NULLIF($1, '')::uuid)
+		ORDER BY "priority" DESC, "created_at"
+	`
+
+	rows, err := r.db.Query(context.Background(), query, req.BranchId, req.At)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			return nil, err
+		}
+		promotions = append(promotions, promotion)
+	}
+
+	return promotions, rows.Err()
+}
+
+// Basket returns lines of basket with effective prices of the branch at the moment and categories of products
+// with all their parents, counts of repeated barcodes are summed
+func (r *promotionRepo) Basket(req *models.BasketRequest) ([]*models.BasketLine, error) {
+	var (
+		barcodes = make([]string, 0, len(req.Lines))
+		lines    = make(map[string]*models.BasketLine)
+		basket   = make([]*models.BasketLine, 0, len(req.Lines))
+	)
+
+	for _, line := range req.Lines {
+		if existing, ok := lines[line.Barcode]; ok {
+			existing.Count += line.Count
+			continue
+		}
+		lines[line.Barcode] = &models.BasketLine{Barcode: line.Barcode, Count: line.Count}
+		basket = append(basket, lines[line.Barcode])
+		barcodes = append(barcodes, line.Barcode)
+	}
+
+	query := `
+		WITH RECURSIVE "tree" AS (
+			SELECT p."id" AS "product_id", p."category_id"
+			FROM "product" AS p
+			WHERE p."barcode" = ANY($1) AND p."category_id" IS NOT NULL
+			UNION
+			SELECT t."product_id", c."parent_id"
+			FROM "tree" AS t
+			JOIN "category" AS c ON c."id" = t."category_id"
+			WHERE c."parent_id" IS NOT NULL
+		)
+		SELECT
+			p."id",
+			p."barcode",
+			p."name",
+			COALESCE(
+				(SELECT "price" FROM "product_price"
+				WHERE "product_id" = p."id" AND "branch_id" = NULLIF($2, '')::uuid
+					AND "effective_from" <= COALESCE(NULLIF($3, '')::timestamp, NOW())
+				ORDER BY "effective_from" DESC LIMIT 1),
+				(SELECT "price" FROM "product_price"
+				WHERE "product_id" = p."id" AND "branch_id" IS NULL
+					AND "effective_from" <= COALESCE(NULLIF($3, '')::timestamp, NOW())
+				ORDER BY "effective_from" DESC LIMIT 1),
+				p."price"
+			),
+			ARRAY(SELECT t."category_id"::text FROM "tree" AS t WHERE t."product_id" = p."id")
+		FROM "product" AS p
+		WHERE p."barcode" = ANY($1)
+	`
+
+	rows, err := r.db.Query(context.Background(), query, barcodes, req.BranchId, req.At)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id           sql.NullString
+			barcode      sql.NullString
+			name         sql.NullString
+			price        sql.NullFloat64
+			category_ids []string
+		)
+
+		err := rows.Scan(
+			&id,
+			&barcode,
+			&name,
+			&price,
+			&category_ids,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		line := lines[barcode.String]
+		line.ProductId = id.String
+		line.Name = name.String
+		line.Price = price.Float64
+		line.CategoryIds = category_ids
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, line := range basket {
+		if line.ProductId == "" {
+			return nil, fmt.Errorf("product with barcode %s not found", line.Barcode)
+		}
+	}
+
+	return basket, nil
+}
+
+// SaveUsage keeps discounts granted to the basket for promotion reports
+func (r *promotionRepo) SaveUsage(req *models.BasketRequest, basket *models.BasketResponse) error {
+	ctx := context.Background()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO "promotion_usage"(
+			"id",
+			"promotion_id",
+			"branch_id",
+			"document_id",
+			"barcode",
+			"count",
+			"discount",
+			"created_at")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, COALESCE(NULLIF($8, '')::timestamp, NOW()))
+	`
+
+	for _, line := range basket.Lines {
+		for _, applied := range line.Promotions {
+			_, err = tx.Exec(ctx, query,
+				uuid.NewString(),
+				applied.PromotionId,
+				helper.NewNullString(req.BranchId),
+				helper.NewNullString(req.DocumentId),
+				line.Barcode,
+				applied.Count,
+				applied.Discount,
+				req.At,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func scanPromotion(row pgx.Row, before ...interface{}) (*models.Promotion, error) {
+	var (
+		id           sql.NullString
+		name         sql.NullString
+		kind         sql.NullString
+		percent      sql.NullFloat64
+		buy_count    sql.NullInt64
+		free_count   sql.NullInt64
+		bundle_count sql.NullInt64
+		bundle_price sql.NullFloat64
+		product_ids  []string
+		category_ids []string
+		branch_id    sql.NullString
+		starts_at    sql.NullString
+		ends_at      sql.NullString
+		priority     sql.NullInt64
+		active       sql.NullBool
+		created_at   sql.NullString
+		updated_at   sql.NullString
+	)
+
+	err := row.Scan(append(before,
+		&id,
+		&name,
+		&kind,
+		&percent,
+		&buy_count,
+		&free_count,
+		&bundle_count,
+		&bundle_price,
+		&product_ids,
+		&category_ids,
+		&branch_id,
+		&starts_at,
+		&ends_at,
+		&priority,
+		&active,
+		&created_at,
+		&updated_at,
+	)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Promotion{
+		Id:          id.String,
+		Name:        name.String,
+		Type:        kind.String,
+		Percent:     percent.Float64,
+		BuyCount:    int(buy_count.Int64),
+		FreeCount:   int(free_count.Int64),
+		BundleCount: int(bundle_count.Int64),
+		BundlePrice: bundle_price.Float64,
+		ProductIds:  product_ids,
+		CategoryIds: category_ids,
+		BranchId:    branch_id.String,
+		StartsAt:    starts_at.String,
+		EndsAt:      ends_at.String,
+		Priority:    int(priority.Int64),
+		Active:      active.Bool,
+		CreatedAt:   created_at.String,
+		UpdatedAt:   updated_at.String,
+	}, nil
+}
@@ -0,0 +1,575 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"market/models"
+	"market/pkg/helper"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type purchaseOrderRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewPurchaseOrderRepo(db *pgxpool.Pool) *purchaseOrderRepo {
+	return &purchaseOrderRepo{
+		db: db,
+	}
+}
+
+func (r *purchaseOrderRepo) Create(req *models.CreatePurchaseOrder) (string, error) {
+	var (
+		ctx = context.Background()
+		id  = uuid.NewString()
+	)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+				INSERT INTO "purchase_order"(
+					"id",
+					"order_number",
+					"supplier_id",
+					"branch_id",
+					"expected_date",
+					"created_at")
+				VALUES ($1, $2, $3, $4, $5, NOW())`
+
+	_, err = tx.Exec(ctx, query,
+		id,
+		req.OrderNumber,
+		req.SupplierId,
+		req.BranchId,
+		helper.NewNullString(req.ExpectedDate),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	for _, product := range req.Products {
+		err = r.addProduct(ctx, tx, id, product)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// addProduct copies name and category of the ordered barcode from product table
+func (r *purchaseOrderRepo) addProduct(ctx context.Context, tx pgx.Tx, purchaseOrderId string, req *models.CreatePurchaseOrderProduct) error {
+	query := `
+		INSERT INTO "purchase_order_product"(
+			"id",
+			"purchase_order_id",
+			"category_id",
+			"name",
+			"barcode",
+			"count",
+			"price",
+			"total_price",
+			"created_at")
+		SELECT $1::uuid, $2::uuid, "category_id", "name", "barcode", $3::numeric, $4::numeric, $5::numeric, NOW()
+		FROM "product"
+		WHERE "barcode" = $6
+	`
+
+	result, err := tx.Exec(ctx, query,
+		uuid.NewString(),
+		purchaseOrderId,
+		req.Count,
+		req.Price,
+		req.Price*float64(req.Count),
+		req.Barcode,
+	)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("product with barcode %s not found", req.Barcode)
+	}
+
+	return nil
+}
+
+func (r *purchaseOrderRepo) GetByID(req *models.PurchaseOrderPrimaryKey) (*models.PurchaseOrder, error) {
+
+	var (
+		id            sql.NullString
+		order_number  sql.NullString
+		supplier_id   sql.NullString
+		branch_id     sql.NullString
+		expected_date sql.NullTime
+		status        sql.NullString
+		created_at    sql.NullString
+		updated_at    sql.NullString
+	)
+
+	query := `
+		SELECT
+			"id",
+			"order_number",
+			"supplier_id",
+			"branch_id",
+			"expected_date",
+			"status",
+			"created_at",
+			"updated_at"
+		FROM "purchase_order"
+		WHERE id = $1
+	`
+
+	err := r.db.QueryRow(context.Background(), query, req.Id).Scan(
+		&id,
+		&order_number,
+		&supplier_id,
+		&branch_id,
+		&expected_date,
+		&status,
+		&created_at,
+		&updated_at,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	products, err := r.getProducts(id.String)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.PurchaseOrder{
+		Id:           id.String,
+		OrderNumber:  order_number.String,
+		SupplierId:   supplier_id.String,
+		BranchId:     branch_id.String,
+		ExpectedDate: expected_date.Time.Format(time.DateTime),
+		Status:       status.String,
+		CreatedAt:    created_at.String,
+		UpdatedAt:    updated_at.String,
+		Products:     products,
+	}, nil
+}
+
+func (r *purchaseOrderRepo) getProducts(purchaseOrderId string) ([]*models.PurchaseOrderProduct, error) {
+	products := make([]*models.PurchaseOrderProduct, 0)
+
+	query := `
+		SELECT
+			"id",
+			"purchase_order_id",
+			"category_id",
+			"name",
+			"barcode",
+			"count",
+			"price",
+			"total_price",
+			"received_count",
+			"created_at",
+			"updated_at"
+		FROM "purchase_order_product"
+		WHERE "purchase_order_id" = $1
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(context.Background(), query, purchaseOrderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id                sql.NullString
+			purchase_order_id sql.NullString
+			category_id       sql.NullString
+			name              sql.NullString
+			barcode           sql.NullString
+			count             sql.NullInt64
+			price             sql.NullFloat64
+			total_price       sql.NullFloat64
+			received_count    sql.NullInt64
+			created_at        sql.NullString
+			updated_at        sql.NullString
+		)
+
+		err := rows.Scan(
+			&id,
+			&purchase_order_id,
+			&category_id,
+			&name,
+			&barcode,
+			&count,
+			&price,
+			&total_price,
+			&received_count,
+			&created_at,
+			&updated_at,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		products = append(products, &models.PurchaseOrderProduct{
+			Id:              id.String,
+			PurchaseOrderId: purchase_order_id.String,
+			CategoryId:      category_id.String,
+			Name:            name.String,
+			Barcode:         barcode.String,
+			Count:           int(count.Int64),
+			Price:           price.Float64,
+			TotalPrice:      total_price.Float64,
+			ReceivedCount:   int(received_count.Int64),
+			CreatedAt:       created_at.String,
+			UpdatedAt:       updated_at.String,
+		})
+	}
+
+	return products, rows.Err()
+}
+
+// purchaseOrderList are sorts and filters of purchase order list, sorts by created_at, order_number
+// or expected_date, ones without expected_date are sorted by created_at
+var purchaseOrderList = listSpec{
+	key:      listColumn{`"id"`, "uuid"},
+	fallback: "-created_at",
+	sorts: map[string][]listColumn{
+		"created_at":    {{`"created_at"`, "timestamp"}},
+		"order_number":  {{`"order_number"`, "text"}},
+		"expected_date": {{`COALESCE("expected_date", "created_at")`, "timestamp"}},
+	},
+	filters: map[string]listColumn{
+		"order_number":  {`"order_number"`, "text"},
+		"supplier_id":   {`"supplier_id"`, "uuid"},
+		"branch_id":     {`"branch_id"`, "uuid"},
+		"status":        {`"status"::text`, "text"},
+		"expected_date": {`"expected_date"`, "timestamp"},
+		"created_at":    {`"created_at"`, "timestamp"},
+		"updated_at":    {`"updated_at"`, "timestamp"},
+	},
+	date: "created_at",
+}
+
+func (r *purchaseOrderRepo) GetList(req *models.PurchaseOrderGetListRequest) (*models.PurchaseOrderGetListResponse, error) {
+	page, err := newListPage(purchaseOrderList, req.Page, req.Limit, req.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]interface{})
+	var resp = &models.PurchaseOrderGetListResponse{}
+
+	resp.PurchaseOrders = make([]*models.PurchaseOrder, 0)
+
+	filter := " WHERE true "
+	query := `
+			SELECT
+				` + page.countColumn() + `,
+				"id",
+				"order_number",
+				"supplier_id",
+				"branch_id",
+				"expected_date",
+				"status",
+				"created_at",
+				"updated_at"
+			FROM "purchase_order"
+		`
+	if req.SupplierId != "" {
+		filter += ` AND ("supplier_id" = :supplier_id)`
+		params["supplier_id"] = req.SupplierId
+	}
+
+	if req.BranchId != "" {
+		filter += ` AND ("branch_id" = :branch_id)`
+		params["branch_id"] = req.BranchId
+	}
+
+	if req.Status != "" {
+		filter += ` AND ("status" = :status)`
+		params["status"] = req.Status
+	}
+
+	where, err := listWhere(purchaseOrderList, req.ListOptions, params)
+	if err != nil {
+		return nil, err
+	}
+	filter += where
+
+	query = page.query(query+filter, params)
+	rquery, pArr := helper.ReplaceQueryParams(query, params)
+
+	rows, err := r.db.Query(context.Background(), rquery, pArr...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id            sql.NullString
+			order_number  sql.NullString
+			supplier_id   sql.NullString
+			branch_id     sql.NullString
+			expected_date sql.NullTime
+			status        sql.NullString
+			created_at    sql.NullString
+			updated_at    sql.NullString
+		)
+		err := rows.Scan(page.dest(
+			&resp.Count,
+			&id,
+			&order_number,
+			&supplier_id,
+			&branch_id,
+			&expected_date,
+			&status,
+			&created_at,
+			&updated_at,
+		)...)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.PurchaseOrders = append(resp.PurchaseOrders, &models.PurchaseOrder{
+			Id:           id.String,
+			OrderNumber:  order_number.String,
+			SupplierId:   supplier_id.String,
+			BranchId:     branch_id.String,
+			ExpectedDate: expected_date.Time.Format(time.DateTime),
+			Status:       status.String,
+			CreatedAt:    created_at.String,
+			UpdatedAt:    updated_at.String,
+		})
+	}
+	resp.NextCursor = page.next()
+	return resp, nil
+}
+
+func (r *purchaseOrderRepo) Update(req *models.UpdatePurchaseOrder) (string, error) {
+
+	query := `
+		UPDATE
+			"purchase_order"
+		SET
+			"order_number" = $1,
+			"supplier_id" = $2,
+			"branch_id" = $3,
+			"expected_date" = $4,
+			"updated_at" = NOW()
+		WHERE id = $5
+	`
+
+	result, err := r.db.Exec(context.Background(), query,
+		req.OrderNumber,
+		req.SupplierId,
+		req.BranchId,
+		helper.NewNullString(req.ExpectedDate),
+		req.Id,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	if result.RowsAffected() == 0 {
+		return "", fmt.Errorf("purchase_order with ID %s not found", req.Id)
+	}
+
+	return req.Id, nil
+}
+
+func (r *purchaseOrderRepo) Delete(req *models.PurchaseOrderPrimaryKey) error {
+	ctx := context.Background()
+
+	result, err := r.db.Exec(ctx, "DELETE FROM purchase_order WHERE id = $1", req.Id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("purchase_order with ID %s not found", req.Id)
+
+	}
+
+	return nil
+}
+
+// CreateComingTable opens a new coming_table for the branch and supplier of the purchase order
+func (r *purchaseOrderRepo) CreateComingTable(req *models.CreateComingTableFromOrder) (string, error) {
+	var (
+		ctx = context.Background()
+		id  = uuid.NewString()
+	)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO "coming_table"(
+			"id",
+			"coming_id",
+			"branch_id",
+			"supplier_id",
+			"purchase_order_id",
+			"date_time",
+			"created_at")
+		SELECT $1::uuid, $2, "branch_id", "supplier_id", "id", COALESCE($3::timestamp, NOW()), NOW()
+		FROM "purchase_order"
+		WHERE "id" = $4 AND "status" <> 'received'
+	`
+
+	result, err := tx.Exec(ctx, query,
+		id,
+		req.ComingId,
+		helper.NewNullString(req.DateTime),
+		req.PurchaseOrderId,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	if result.RowsAffected() == 0 {
+		return "", fmt.Errorf("purchase_order with ID %s not found or already received", req.PurchaseOrderId)
+	}
+
+	err = checkComingTablePeriod(ctx, tx, id)
+	if err != nil {
+		return "", err
+	}
+
+	return id, tx.Commit(ctx)
+}
+
+// GetProgress reports ordered, already received and currently scanned quantity of a barcode
+func (r *purchaseOrderRepo) GetProgress(req *models.PurchaseOrderProgressRequest) (*models.PurchaseOrderProgress, error) {
+	var (
+		ctx      = context.Background()
+		progress = &models.PurchaseOrderProgress{}
+		ordered  sql.NullInt64
+		received sql.NullInt64
+		scanned  sql.NullInt64
+	)
+
+	query := `
+		SELECT
+			SUM("count"),
+			SUM("received_count")
+		FROM "purchase_order_product"
+		WHERE "purchase_order_id" = $1 AND "barcode" = $2
+	`
+
+	err := r.db.QueryRow(ctx, query, req.PurchaseOrderId, req.Barcode).Scan(
+		&ordered,
+		&received,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `
+		SELECT
+			SUM("count")
+		FROM "coming_table_product"
+		WHERE "coming_table_id" = $1 AND "barcode" = $2
+	`
+
+	err = r.db.QueryRow(ctx, query, req.ComingTableId, req.Barcode).Scan(&scanned)
+	if err != nil {
+		return nil, err
+	}
+
+	progress.OrderedCount = int(ordered.Int64)
+	progress.ReceivedCount = int(received.Int64)
+	progress.ScannedCount = int(scanned.Int64)
+	progress.Unknown = !ordered.Valid
+	progress.OverDelivery = progress.ReceivedCount+progress.ScannedCount > progress.OrderedCount
+
+	return progress, nil
+}
+
+// refreshPurchaseOrder recalculates received quantities of purchase order of coming_table from posted coming tables
+// and moves the order status, it runs in transaction which posts or reverses the coming_table
+func refreshPurchaseOrder(ctx context.Context, tx pgx.Tx, comingTableId string) error {
+	var purchaseOrderId sql.NullString
+
+	// order is locked so coming tables of it posted at once do not miss each other
+	query := `
+		SELECT
+			po."id"
+		FROM "coming_table" AS ct
+		JOIN "purchase_order" AS po ON po."id" = ct."purchase_order_id"
+		WHERE ct."id" = $1
+		FOR UPDATE OF po
+	`
+
+	err := tx.QueryRow(ctx, query, comingTableId).Scan(&purchaseOrderId)
+	if err == pgx.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE
+			"purchase_order_product" AS pop
+		SET
+			"received_count" = COALESCE((
+				SELECT SUM(ctp."count")
+				FROM "coming_table_product" AS ctp
+				JOIN "coming_table" AS ct ON ct."id" = ctp."coming_table_id"
+				WHERE ct."purchase_order_id" = pop."purchase_order_id"
+					AND ct."status" = 'posted'
+					AND ctp."barcode" = pop."barcode"
+			), 0),
+			"updated_at" = NOW()
+		WHERE pop."purchase_order_id" = $1
+	`
+
+	_, err = tx.Exec(ctx, query, purchaseOrderId.String)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE
+			"purchase_order"
+		SET
+			"status" = (
+				SELECT
+					CASE
+						WHEN bool_and("received_count" >= "count") THEN 'received'
+						WHEN bool_or("received_count" > 0) THEN 'partially_received'
+						ELSE 'new'
+					END::purchase_order_status
+				FROM "purchase_order_product"
+				WHERE "purchase_order_id" = $1
+			),
+			"updated_at" = NOW()
+		WHERE "id" = $1 AND EXISTS (
+			SELECT 1 FROM "purchase_order_product" WHERE "purchase_order_id" = $1
+		)
+	`
+
+	_, err = tx.Exec(ctx, query, purchaseOrderId.String)
+	return err
+}
@@ -8,6 +8,7 @@ import (
 	"market/pkg/helper"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -21,12 +22,20 @@ func NewRemainingRepo(db *pgxpool.Pool) *remainingRepo {
 	}
 }
 
+// Create adds remaining with adjustment movement of its count and total_price
 func (r *remainingRepo) Create(req *models.CreateRemaining) (string, error) {
 	var (
+		ctx   = context.Background()
 		id    = uuid.NewString()
 		query string
 	)
 
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
+
 	query = `
 		INSERT INTO "remaining"(
 			"id", 
@@ -37,13 +46,14 @@ func (r *remainingRepo) Create(req *models.CreateRemaining) (string, error) {
 			"barcode",
 			"count",
 			"total_price",
+			"product_id",
 			"created_at" )
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NOW())`
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, ` + productIdByBarcode("$6") + `, NOW())`
 
-	_, err := r.db.Exec(context.Background(), query,
+	_, err = tx.Exec(ctx, query,
 		id,
 		req.BranchId,
-		req.CategoryId,
+		helper.NewNullString(req.CategoryId),
 		req.Name,
 		req.Price,
 		req.Barcode,
@@ -52,20 +62,26 @@ func (r *remainingRepo) Create(req *models.CreateRemaining) (string, error) {
 	)
 
 	if err != nil {
-		return "", err
+		return "", constraintError(err)
 	}
 
-	return id, nil
+	err = adjustStock(ctx, tx, id, float64(req.Count), req.TotalPrice)
+	if err != nil {
+		return "", err
+	}
 
+	return id, tx.Commit(ctx)
 }
 
 func (r *remainingRepo) GetByID(req *models.RemainingPrimaryKey) (*models.Remaining, error) {
 	var updatedAt sql.NullString
 	var createdAt sql.NullString
+	var productId sql.NullString
 
 	query := `
 		SELECT
 			"id", 
+			"product_id",
 			"branch_id",
 			"category_id",
 			"name",
@@ -73,14 +89,16 @@ func (r *remainingRepo) GetByID(req *models.RemainingPrimaryKey) (*models.Remain
 			"barcode",
 			"count",
 			"total_price",
+			"version",
 			"created_at",
 			"updated_at" 
-		FROM "remaining"
+		FROM ` + remainingResolved(`"remaining"`) + ` AS "remaining"
 		WHERE id = $1
 	`
 	remaining := models.Remaining{}
 	err := r.db.QueryRow(context.Background(), query, req.Id).Scan(
 		&remaining.Id,
+		&productId,
 		&remaining.BranchId,
 		&remaining.CategoryId,
 		&remaining.Name,
@@ -88,9 +106,11 @@ func (r *remainingRepo) GetByID(req *models.RemainingPrimaryKey) (*models.Remain
 		&remaining.Barcode,
 		&remaining.Count,
 		&remaining.TotalPrice,
+		&remaining.Version,
 		&createdAt,
 		&updatedAt,
 	)
+	remaining.ProductId = productId.String
 	remaining.CreatedAt = createdAt.String
 	remaining.UpdatedAt = updatedAt.String
 	if err != nil {
@@ -100,16 +120,53 @@ func (r *remainingRepo) GetByID(req *models.RemainingPrimaryKey) (*models.Remain
 	return &remaining, nil
 }
 
+// remainingList are sorts and filters of remaining list, sorts by created_at, name, price, count,
+// total_price or barcode.
+// Remaining rebuilt as_of a moment may have no id, so its branch and barcode are the key
+var remainingList = listSpec{
+	key:      listColumn{`COALESCE("branch_id"::text, '') || '/' || COALESCE("barcode", '')`, "text"},
+	fallback: "-created_at",
+	sorts: map[string][]listColumn{
+		"created_at":  {{`COALESCE("created_at", '-infinity')`, "timestamp"}},
+		"name":        {{`"name"`, "text"}},
+		"price":       {{`"price"`, "numeric"}},
+		"count":       {{`"count"`, "numeric"}},
+		"total_price": {{`COALESCE("total_price", 0)`, "numeric"}},
+		"barcode":     {{`"barcode"`, "text"}},
+	},
+	filters: map[string]listColumn{
+		"name":        {`"name"`, "text"},
+		"barcode":     {`"barcode"`, "text"},
+		"price":       {`"price"`, "numeric"},
+		"count":       {`"count"`, "numeric"},
+		"total_price": {`"total_price"`, "numeric"},
+		"category_id": {`"category_id"`, "uuid"},
+		"product_id":  {`"product_id"`, "uuid"},
+		"branch_id":   {`"branch_id"`, "uuid"},
+		"created_at":  {`"created_at"`, "timestamp"},
+		"updated_at":  {`"updated_at"`, "timestamp"},
+	},
+	date: "created_at",
+}
+
 func (r *remainingRepo) GetList(req *models.RemainingGetListRequest) (*models.RemainingGetListResponse, error) {
-	params := make(map[string]interface{})
+	page, err := newListPage(remainingList, req.Page, req.Limit, req.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
 	var resp = &models.RemainingGetListResponse{}
 	resp.Remainings = make([]*models.Remaining, 0)
 
-	filter := " WHERE true "
+	filter, params, err := remainingFilter(req)
+	if err != nil {
+		return nil, err
+	}
 	query := `
 		SELECT
-			COUNT(*) OVER(),
+			` + page.countColumn() + `,
 			"id", 
+			"product_id",
 			"branch_id",
 			"category_id",
 			"name",
@@ -117,30 +174,13 @@ func (r *remainingRepo) GetList(req *models.RemainingGetListRequest) (*models.Re
 			"barcode",
 			"count",
 			"total_price",
+			"version",
 			"created_at",
 			"updated_at" 
-		FROM "remaining"
+		FROM ` + remainingSource(req, params) + `
 		`
-	if req.BranchId != "" {
-		filter += ` AND ("branch_id" = :branch_id) `
-		params["branch_id"] = req.BranchId
-	}
-
-	if req.Barcode != "" {
-		filter += ` AND ("barcode" = :barcode) `
-		params["barcode"] = req.Barcode
-	}
-
-	if req.CategoryId != "" {
-		filter += ` AND ("category_id" = :category_id) `
-		params["category_id"] = req.CategoryId
-	}
-
-	offset := (req.Page - 1) * req.Limit
-	params["limit"] = req.Limit
-	params["offset"] = offset
 
-	query = query + filter + " ORDER BY created_at DESC OFFSET :offset LIMIT :limit "
+	query = page.query(query+filter, params)
 	rquery, pArr := helper.ReplaceQueryParams(query, params)
 
 	rows, err := r.db.Query(context.Background(), rquery, pArr...)
@@ -152,11 +192,13 @@ func (r *remainingRepo) GetList(req *models.RemainingGetListRequest) (*models.Re
 	for rows.Next() {
 		var updatedAt sql.NullString
 		var createdAt sql.NullString
+		var productId sql.NullString
 
 		var remaining models.Remaining
-		err := rows.Scan(
+		err := rows.Scan(page.dest(
 			&resp.Count,
 			&remaining.Id,
+			&productId,
 			&remaining.BranchId,
 			&remaining.CategoryId,
 			&remaining.Name,
@@ -164,23 +206,69 @@ func (r *remainingRepo) GetList(req *models.RemainingGetListRequest) (*models.Re
 			&remaining.Barcode,
 			&remaining.Count,
 			&remaining.TotalPrice,
+			&remaining.Version,
 			&createdAt,
 			&updatedAt,
-		)
+		)...)
 		if err != nil {
 			return nil, err
 		}
+		remaining.ProductId = productId.String
 		remaining.CreatedAt = createdAt.String
 		remaining.UpdatedAt = updatedAt.String
 
 		resp.Remainings = append(resp.Remainings, &remaining)
 	}
+	resp.NextCursor = page.next()
 	return resp, nil
 }
 
+// Update replaces remaining, with req.Version set it is applied only to that version of the row.
+// Changed count and total_price are written as adjustment movements, moved row is taken out of
+// its old branch and barcode and added to the new ones
 func (r *remainingRepo) Update(req *models.UpdateRemaining) (string, error) {
+	var (
+		ctx        = context.Background()
+		branchId   sql.NullString
+		barcode    sql.NullString
+		count      float64
+		totalPrice sql.NullFloat64
+	)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
 
 	query := `
+		SELECT
+			"branch_id",
+			"barcode",
+			"count",
+			"total_price"
+		FROM "remaining"
+		WHERE "id" = $1
+		FOR UPDATE
+	`
+
+	err = tx.QueryRow(ctx, query, req.Id).Scan(&branchId, &barcode, &count, &totalPrice)
+	if err == pgx.ErrNoRows {
+		return "", fmt.Errorf("remaining with ID %s not found", req.Id)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	moved := branchId.String != req.BranchId || barcode.String != req.Barcode
+	if moved {
+		err = adjustStock(ctx, tx, req.Id, -count, -totalPrice.Float64)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	query = `
 		UPDATE
 			"remaining"
 		SET
@@ -191,109 +279,243 @@ func (r *remainingRepo) Update(req *models.UpdateRemaining) (string, error) {
 			"barcode" =$5,
 			"count" = $6,
 			"total_price" =$7,
+			"product_id" = ` + productIdByBarcode("$5") + `,
 			"updated_at" = NOW()
-		WHERE id = $8
+		WHERE id = $8 AND ($9 = 0 OR "version" = $9)
+		RETURNING "version"
 	`
 
-	result, err := r.db.Exec(context.Background(), query,
+	err = tx.QueryRow(ctx, query,
 		req.BranchId,
-		req.CategoryId,
+		helper.NewNullString(req.CategoryId),
 		req.Name,
 		req.Price,
 		req.Barcode,
 		req.Count,
 		req.TotalPrice,
 		req.Id,
-	)
-	if err != nil {
+		req.Version,
+	).Scan(&req.Version)
+	if err == pgx.ErrNoRows {
+		err = versionError(ctx, r.db, "remaining", req.Id, req.Version)
+		if err == nil {
+			err = fmt.Errorf("remaining with ID %s not found", req.Id)
+		}
 		return "", err
 	}
+	if err != nil {
+		return "", constraintError(err)
+	}
 
-	if result.RowsAffected() == 0 {
-		return "", fmt.Errorf("remaining with ID %s not found", req.Id)
+	if moved {
+		err = adjustStock(ctx, tx, req.Id, float64(req.Count), req.TotalPrice)
+	} else {
+		err = adjustStock(ctx, tx, req.Id, float64(req.Count)-count, req.TotalPrice-totalPrice.Float64)
+	}
+	if err != nil {
+		return "", err
 	}
 
-	return req.Id, nil
+	return req.Id, tx.Commit(ctx)
 }
 
+// Delete removes remaining and writes adjustment movement taking its count and total_price out of stock
 func (r *remainingRepo) Delete(req *models.RemainingPrimaryKey) error {
-	ctx := context.Background()
+	var (
+		ctx        = context.Background()
+		count      float64
+		totalPrice sql.NullFloat64
+	)
 
-	result, err := r.db.Exec(ctx, "DELETE FROM remaining WHERE id = $1", req.Id)
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return err
 	}
+	defer tx.Rollback(ctx)
 
-	if result.RowsAffected() == 0 {
+	err = tx.QueryRow(ctx, `SELECT "count", "total_price" FROM "remaining" WHERE "id" = $1 FOR UPDATE`, req.Id).Scan(&count, &totalPrice)
+	if err == pgx.ErrNoRows {
 		return fmt.Errorf("remaining with ID %s not found", req.Id)
-
+	}
+	if err != nil {
+		return err
 	}
 
-	return nil
-}
-
-// check raming by branch id and barcode
-func (r *remainingRepo) CheckRemaing(req *models.CheckingRemaining) (string, error) {
-	var in sql.NullString
-	var params map[string]interface{}
-
-	query := `
-		SELECT
-			"id"
-		FROM "remaining"
-		WHERE "branch_id" = :branch_id AND "barcode" = :barcode
-	`
-
-	params = map[string]interface{}{
-		"branch_id": req.BranchId,
-		"barcode":   req.Barcode,
+	err = adjustStock(ctx, tx, req.Id, -count, -totalPrice.Float64)
+	if err != nil {
+		return err
 	}
-	queryN, args := helper.ReplaceQueryParams(query, params)
 
-	err := r.db.QueryRow(context.Background(), queryN, args...).Scan(
-		&in,
-	)
+	_, err = tx.Exec(ctx, "DELETE FROM remaining WHERE id = $1", req.Id)
 	if err != nil {
-		return in.String, err
+		return err
 	}
 
-	return in.String, nil
+	return tx.Commit(ctx)
 }
 
-func (r *remainingRepo) UpdateExists(req *models.UpdateRemaining) (string, error) {
+// Upsert adds remaining of the branch and barcode or adds count and total_price to the existing one in one statement,
+// what is added is written as adjustment movement
+func (r *remainingRepo) Upsert(req *models.CreateRemaining) (string, error) {
+	var (
+		ctx = context.Background()
+		id  string
+	)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer tx.Rollback(ctx)
 
 	query := `
-		UPDATE
-			"remaining"
+		INSERT INTO "remaining"(
+			"id",
+			"branch_id",
+			"category_id",
+			"name",
+			"price",
+			"barcode",
+			"count",
+			"total_price",
+			"product_id",
+			"created_at")
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, ` + productIdByBarcode("$6") + `, NOW())
+		ON CONFLICT ("branch_id", "barcode") DO UPDATE
 		SET
-			"branch_id" = $1,
-			"category_id" = $2,
-			"name" = $3,
-			"price" = $4,
-			"barcode" =$5,
-			"count" = "count" + $6,
-			"total_price" = "total_price" + $7,
+			"category_id" = EXCLUDED."category_id",
+			"name" = EXCLUDED."name",
+			"price" = EXCLUDED."price",
+			"count" = "remaining"."count" + EXCLUDED."count",
+			"total_price" = "remaining"."total_price" + EXCLUDED."total_price",
+			"product_id" = EXCLUDED."product_id",
 			"updated_at" = NOW()
-		WHERE id = $8
+		RETURNING "id"
 	`
 
-	result, err := r.db.Exec(context.Background(), query,
+	err = tx.QueryRow(ctx, query,
+		uuid.NewString(),
 		req.BranchId,
-		req.CategoryId,
+		helper.NewNullString(req.CategoryId),
 		req.Name,
 		req.Price,
 		req.Barcode,
 		req.Count,
 		req.TotalPrice,
-		req.Id,
-	)
+	).Scan(&id)
+	if err != nil {
+		return "", constraintError(err)
+	}
+
+	err = adjustStock(ctx, tx, id, float64(req.Count), req.TotalPrice)
 	if err != nil {
 		return "", err
 	}
 
-	if result.RowsAffected() == 0 {
-		return "", fmt.Errorf("remaining with ID %s not found", req.Id)
+	return id, tx.Commit(ctx)
+}
+
+// remainingFilter builds WHERE clause of list filters, it is shared by GetList and Export
+func remainingFilter(req *models.RemainingGetListRequest) (string, map[string]interface{}, error) {
+	params := make(map[string]interface{})
+	filter := " WHERE true "
+
+	if req.BranchId != "" {
+		filter += ` AND ("branch_id" = :branch_id) `
+		params["branch_id"] = req.BranchId
+	}
+
+	if req.Barcode != "" {
+		filter += ` AND ("barcode" = :barcode) `
+		params["barcode"] = req.Barcode
+	}
+
+	if req.CategoryId != "" {
+		filter += ` AND ("category_id" = :category_id) `
+		params["category_id"] = req.CategoryId
+	}
+
+	where, err := listWhere(remainingList, req.ListOptions, params)
+	return filter + where, params, err
+}
+
+// Export streams all remainings matching list filters, current or as of req.AsOf, with branch and category names to fn
+func (r *remainingRepo) Export(req *models.RemainingGetListRequest, fn func(*models.Remaining, *models.ExportNames) error) error {
+	filter, params, err := remainingFilter(req)
+	if err != nil {
+		return err
+	}
+	order, err := listOrder(remainingList, req.Sort)
+	if err != nil {
+		return err
+	}
+	query := `
+		SELECT
+			r."id",
+			r."product_id",
+			r."branch_id",
+			b."name",
+			r."category_id",
+			c."name",
+			r."name",
+			r."price",
+			r."barcode",
+			r."count",
+			r."total_price",
+			r."created_at",
+			r."updated_at"
+		FROM (SELECT *, ROW_NUMBER() OVER (ORDER BY ` + order + `) AS "list_row" FROM ` + remainingSource(req, params) + filter + `) AS r
+		LEFT JOIN "branch" AS b ON b."id" = r."branch_id"
+		LEFT JOIN "category" AS c ON c."id" = r."category_id"
+		ORDER BY r."list_row"
+	`
+	rquery, pArr := helper.ReplaceQueryParams(query, params)
+
+	rows, err := r.db.Query(context.Background(), rquery, pArr...)
+	if err != nil {
+		return fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			remaining    models.Remaining
+			productId    sql.NullString
+			branchName   sql.NullString
+			categoryId   sql.NullString
+			categoryName sql.NullString
+			createdAt    sql.NullString
+			updatedAt    sql.NullString
+		)
+
+		err := rows.Scan(
+			&remaining.Id,
+			&productId,
+			&remaining.BranchId,
+			&branchName,
+			&categoryId,
+			&categoryName,
+			&remaining.Name,
+			&remaining.Price,
+			&remaining.Barcode,
+			&remaining.Count,
+			&remaining.TotalPrice,
+			&createdAt,
+			&updatedAt,
+		)
+		if err != nil {
+			return err
+		}
+		remaining.ProductId = productId.String
+		remaining.CategoryId = categoryId.String
+		remaining.CreatedAt = createdAt.String
+		remaining.UpdatedAt = updatedAt.String
+
+		err = fn(&remaining, &models.ExportNames{BranchName: branchName.String, CategoryName: categoryName.String})
+		if err != nil {
+			return err
+		}
 	}
 
-	return req.Id, nil
+	return rows.Err()
 }
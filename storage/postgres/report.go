@@ -0,0 +1,285 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"market/models"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type reportRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewReportRepo(db *pgxpool.Pool) *reportRepo {
+	return &reportRepo{
+		db: db,
+	}
+}
+
+// incomeGroups are id and name expressions of income report groups
+var incomeGroups = map[string][2]string{
+	models.ReportByBranch:   {`ct."branch_id"`, `b."name"`},
+	models.ReportBySupplier: {`ct."supplier_id"`, `s."name"`},
+	models.ReportByCategory: {`ctp."category_id"`, `c."name"`},
+}
+
+// Income sums lines of posted coming tables by period and branch, supplier or category
+func (r *reportRepo) Income(req *models.ReportRequest) (*models.IncomeReportResponse, error) {
+	resp := &models.IncomeReportResponse{Rows: make([]*models.IncomeReportRow, 0)}
+
+	group, ok := incomeGroups[req.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("unknown group_by %q", req.GroupBy)
+	}
+
+	query := `
+		SELECT
+			TO_CHAR(DATE_TRUNC($1, ct."date_time"), 'YYYY-MM-DD'),
+			` + group[0] + `,
+			COALESCE(` + group[1] + `, ''),
+			COUNT(DISTINCT ct."id"),
+			COALESCE(SUM(ctp."count"), 0),
+			COALESCE(SUM(ctp."total_price"), 0)
+		FROM "coming_table" AS ct
+		JOIN ` + comingLinesResolved + ` AS ctp ON ctp."coming_table_id" = ct."id"
+		LEFT JOIN "branch" AS b ON b."id" = ct."branch_id"
+		LEFT JOIN "supplier" AS s ON s."id" = ct."supplier_id"
+		LEFT JOIN "category" AS c ON c."id" = ctp."category_id"
+		WHERE ct."status" = 'posted'
+			AND ct."date_time" >= $2 AND ct."date_time" < $3::date + 1
+			AND ($4 = '' OR ct."branch_id"::text = $4)
+		GROUP BY 1, 2, 3
+		ORDER BY 1, 3
+	`
+
+	rows, err := r.db.Query(context.Background(), query, req.Period, req.From, req.To, req.BranchId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			row models.IncomeReportRow
+			id  sql.NullString
+		)
+
+		err := rows.Scan(
+			&row.Period,
+			&id,
+			&row.Name,
+			&row.ComingTables,
+			&row.Count,
+			&row.TotalPrice,
+		)
+		if err != nil {
+			return nil, err
+		}
+		row.Id = id.String
+
+		resp.Count += row.Count
+		resp.TotalPrice += row.TotalPrice
+		resp.Rows = append(resp.Rows, &row)
+	}
+
+	return resp, rows.Err()
+}
+
+// Stock sums current remaining of every branch
+func (r *reportRepo) Stock(req *models.ReportRequest) (*models.StockReportResponse, error) {
+	resp := &models.StockReportResponse{Rows: make([]*models.StockReportRow, 0)}
+
+	query := `
+		SELECT
+			b."id",
+			b."name",
+			COUNT(r."id") FILTER (WHERE r."count" <> 0),
+			COALESCE(SUM(r."count"), 0),
+			COALESCE(SUM(r."total_price"), 0)
+		FROM "branch" AS b
+		LEFT JOIN "remaining" AS r ON r."branch_id" = b."id"
+		WHERE ($1 = '' OR b."id"::text = $1)
+		GROUP BY b."id", b."name"
+		ORDER BY b."name"
+	`
+
+	rows, err := r.db.Query(context.Background(), query, req.BranchId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.StockReportRow
+
+		err := rows.Scan(
+			&row.BranchId,
+			&row.BranchName,
+			&row.Products,
+			&row.Count,
+			&row.TotalPrice,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Count += row.Count
+		resp.TotalPrice += row.TotalPrice
+		resp.Rows = append(resp.Rows, &row)
+	}
+
+	return resp, rows.Err()
+}
+
+// TopProducts returns products received the most by count in posted coming tables of the date range
+func (r *reportRepo) TopProducts(req *models.ReportRequest) (*models.TopProductsResponse, error) {
+	resp := &models.TopProductsResponse{Products: make([]*models.TopProductRow, 0)}
+
+	query := `
+		SELECT
+			ctp."barcode",
+			MAX(ctp."name"),
+			COALESCE(MAX(c."name"), ''),
+			SUM(ctp."count"),
+			COALESCE(SUM(ctp."total_price"), 0)
+		FROM "coming_table" AS ct
+		JOIN ` + comingLinesResolved + ` AS ctp ON ctp."coming_table_id" = ct."id"
+		LEFT JOIN "category" AS c ON c."id" = ctp."category_id"
+		WHERE ct."status" = 'posted'
+			AND ct."date_time" >= $1 AND ct."date_time" < $2::date + 1
+			AND ($3 = '' OR ct."branch_id"::text = $3)
+		GROUP BY ctp."barcode"
+		ORDER BY 4 DESC, 5 DESC
+		LIMIT $4
+	`
+
+	rows, err := r.db.Query(context.Background(), query, req.From, req.To, req.BranchId, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.TopProductRow
+
+		err := rows.Scan(
+			&row.Barcode,
+			&row.Name,
+			&row.CategoryName,
+			&row.Count,
+			&row.TotalPrice,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Products = append(resp.Products, &row)
+	}
+
+	return resp, rows.Err()
+}
+
+// Movements sums stock movements of every barcode before and inside the date range,
+// closing balance is opening balance plus income minus outcome, value-only revaluation is income or outcome by its sign
+func (r *reportRepo) Movements(req *models.ReportRequest) (*models.MovementReportResponse, error) {
+	resp := &models.MovementReportResponse{Rows: make([]*models.MovementReportRow, 0)}
+
+	query := `
+		SELECT
+			"barcode",
+			MAX("name"),
+			COALESCE(SUM("count") FILTER (WHERE "date_time" < $1), 0),
+			COALESCE(SUM("total_price") FILTER (WHERE "date_time" < $1), 0),
+			COALESCE(SUM("count") FILTER (WHERE "date_time" >= $1 AND "count" > 0), 0),
+			COALESCE(SUM("total_price") FILTER (WHERE "date_time" >= $1 AND ("count" > 0 OR "count" = 0 AND "total_price" > 0)), 0),
+			COALESCE(-SUM("count") FILTER (WHERE "date_time" >= $1 AND "count" < 0), 0),
+			COALESCE(-SUM("total_price") FILTER (WHERE "date_time" >= $1 AND ("count" < 0 OR "count" = 0 AND "total_price" < 0)), 0)
+		FROM "stock_movement"
+		WHERE "date_time" < $2::date + 1
+			AND ($3 = '' OR "branch_id"::text = $3)
+			AND ($4 = '' OR "barcode" = $4)
+		GROUP BY "barcode"
+		ORDER BY 2
+	`
+
+	rows, err := r.db.Query(context.Background(), query, req.From, req.To, req.BranchId, req.Barcode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.MovementReportRow
+
+		err := rows.Scan(
+			&row.Barcode,
+			&row.Name,
+			&row.OpeningCount,
+			&row.OpeningPrice,
+			&row.InCount,
+			&row.InPrice,
+			&row.OutCount,
+			&row.OutPrice,
+		)
+		if err != nil {
+			return nil, err
+		}
+		row.ClosingCount = row.OpeningCount + row.InCount - row.OutCount
+		row.ClosingPrice = row.OpeningPrice + row.InPrice - row.OutPrice
+
+		resp.Rows = append(resp.Rows, &row)
+	}
+
+	return resp, rows.Err()
+}
+
+// Promotions sums discounts granted by every promotion in the date range
+func (r *reportRepo) Promotions(req *models.ReportRequest) (*models.PromotionReportResponse, error) {
+	resp := &models.PromotionReportResponse{Rows: make([]*models.PromotionReportRow, 0)}
+
+	query := `
+		SELECT
+			p."id",
+			p."name",
+			p."type",
+			COUNT(DISTINCT pu."document_id"),
+			SUM(pu."count"),
+			COALESCE(SUM(pu."discount"), 0)
+		FROM "promotion_usage" AS pu
+		JOIN "promotion" AS p ON p."id" = pu."promotion_id"
+		WHERE pu."created_at" >= $1 AND pu."created_at" < $2::date + 1
+			AND ($3 = '' OR pu."branch_id"::text = $3)
+		GROUP BY p."id", p."name", p."type"
+		ORDER BY 6 DESC
+	`
+
+	rows, err := r.db.Query(context.Background(), query, req.From, req.To, req.BranchId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row models.PromotionReportRow
+
+		err := rows.Scan(
+			&row.PromotionId,
+			&row.Name,
+			&row.Type,
+			&row.Documents,
+			&row.Count,
+			&row.Discount,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Discount += row.Discount
+		resp.Rows = append(resp.Rows, &row)
+	}
+
+	return resp, rows.Err()
+}
@@ -0,0 +1,400 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"market/models"
+	"market/pkg/helper"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// revalue writes revaluation of product when its price differs from oldPrice
+// and reprices remaining of the product in every branch, difference of every branch is written
+// as revaluation movement without count
+func revalue(ctx context.Context, tx pgx.Tx, productId string, oldPrice float64, source, user string) error {
+	id := uuid.NewString()
+
+	query := `
+		INSERT INTO "revaluation"(
+			"id",
+			"product_id",
+			"barcode",
+			"name",
+			"old_price",
+			"new_price",
+			"source",
+			"created_by",
+			"created_at")
+		SELECT $1, "id", "barcode", "name", $3, "price", $4, NULLIF($5, ''), NOW()
+		FROM "product"
+		WHERE "id" = $2 AND "price" <> $3
+	`
+
+	result, err := tx.Exec(ctx, query, id, productId, oldPrice, source, user)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return nil
+	}
+
+	// remaining is locked before its lines are read, so income posted meanwhile is not lost between read and repricing
+	_, err = tx.Exec(ctx, `SELECT 1 FROM "remaining" WHERE "product_id" = $1 ORDER BY "id" FOR UPDATE`, productId)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO "revaluation_line"(
+			"id",
+			"revaluation_id",
+			"branch_id",
+			"count",
+			"old_price",
+			"new_price",
+			"old_total",
+			"new_total",
+			"difference")
+		SELECT
+			gen_random_uuid(),
+			rv."id",
+			r."branch_id",
+			r."count",
+			r."price",
+			rv."new_price",
+			COALESCE(r."total_price", 0),
+			r."count" * rv."new_price",
+			r."count" * rv."new_price" - COALESCE(r."total_price", 0)
+		FROM "revaluation" AS rv
+		JOIN "remaining" AS r ON r."product_id" = rv."product_id"
+		WHERE rv."id" = $1
+	`
+
+	_, err = tx.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		INSERT INTO "stock_movement"(
+			"id",
+			"branch_id",
+			"category_id",
+			"name",
+			"price",
+			"barcode",
+			"count",
+			"total_price",
+			"product_id",
+			"type",
+			"document_id",
+			"date_time",
+			"created_at")
+		SELECT
+			gen_random_uuid(),
+			r."branch_id",
+			r."category_id",
+			r."name",
+			rv."new_price",
+			r."barcode",
+			0,
+			r."count" * rv."new_price" - COALESCE(r."total_price", 0),
+			r."product_id",
+			$2,
+			rv."id",
+			NOW(),
+			NOW()
+		FROM "revaluation" AS rv
+		JOIN "remaining" AS r ON r."product_id" = rv."product_id"
+		WHERE rv."id" = $1 AND r."count" * rv."new_price" <> COALESCE(r."total_price", 0)
+	`
+
+	_, err = tx.Exec(ctx, query, id, movementRevaluation)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE
+			"remaining" AS r
+		SET
+			"price" = rv."new_price",
+			"total_price" = r."count" * rv."new_price",
+			"updated_at" = NOW()
+		FROM "revaluation" AS rv
+		WHERE rv."id" = $1 AND r."product_id" = rv."product_id"
+	`
+
+	_, err = tx.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE
+			"revaluation"
+		SET
+			"difference" = (SELECT COALESCE(SUM("difference"), 0) FROM "revaluation_line" WHERE "revaluation_id" = $1)
+		WHERE "id" = $1
+	`
+
+	_, err = tx.Exec(ctx, query, id)
+	return err
+}
+
+type revaluationRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewRevaluationRepo(db *pgxpool.Pool) *revaluationRepo {
+	return &revaluationRepo{
+		db: db,
+	}
+}
+
+func (r *revaluationRepo) GetByID(req *models.RevaluationPrimaryKey) (*models.Revaluation, error) {
+	query := `
+		SELECT
+			"id",
+			"product_id",
+			"barcode",
+			"name",
+			"old_price",
+			"new_price",
+			"difference",
+			"source",
+			"created_by",
+			"created_at"
+		FROM "revaluation"
+		WHERE "id" = $1
+	`
+
+	revaluation, err := scanRevaluation(r.db.QueryRow(context.Background(), query, req.Id))
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, fmt.Errorf("revaluation with ID %s not found", req.Id)
+		}
+		return nil, err
+	}
+
+	return revaluation, nil
+}
+
+// revaluationList are sorts and filters of revaluation list, sorts by created_at, barcode or difference
+var revaluationList = listSpec{
+	key:      listColumn{`"id"`, "uuid"},
+	fallback: "-created_at",
+	sorts: map[string][]listColumn{
+		"created_at": {{`"created_at"`, "timestamp"}},
+		"barcode":    {{`"barcode"`, "text"}},
+		"difference": {{`"difference"`, "numeric"}},
+	},
+	filters: map[string]listColumn{
+		"product_id": {`"product_id"`, "uuid"},
+		"barcode":    {`"barcode"`, "text"},
+		"name":       {`"name"`, "text"},
+		"old_price":  {`"old_price"`, "numeric"},
+		"new_price":  {`"new_price"`, "numeric"},
+		"difference": {`"difference"`, "numeric"},
+		"source":     {`"source"`, "text"},
+		"created_by": {`"created_by"`, "text"},
+		"created_at": {`"created_at"`, "timestamp"},
+	},
+	date: "created_at",
+}
+
+func (r *revaluationRepo) GetList(req *models.RevaluationGetListRequest) (*models.RevaluationGetListResponse, error) {
+	page, err := newListPage(revaluationList, req.Page, req.Limit, req.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		resp   = &models.RevaluationGetListResponse{Revaluations: make([]*models.Revaluation, 0)}
+		params = make(map[string]interface{})
+		filter = " WHERE true "
+		count  int
+	)
+
+	if req.Barcode != "" {
+		filter += ` AND "barcode" = :barcode `
+		params["barcode"] = req.Barcode
+	}
+
+	if req.ProductId != "" {
+		filter += ` AND "product_id" = :product_id `
+		params["product_id"] = req.ProductId
+	}
+
+	where, err := listWhere(revaluationList, req.ListOptions, params)
+	if err != nil {
+		return nil, err
+	}
+	filter += where
+
+	query := `
+		SELECT
+			` + page.countColumn() + `,
+			"id",
+			"product_id",
+			"barcode",
+			"name",
+			"old_price",
+			"new_price",
+			"difference",
+			"source",
+			"created_by",
+			"created_at"
+		FROM "revaluation"
+	` + filter
+
+	query, args := helper.ReplaceQueryParams(page.query(query, params), params)
+
+	rows, err := r.db.Query(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		revaluation, err := scanRevaluation(rows, page.dest(&count)...)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Count = count
+		resp.Revaluations = append(resp.Revaluations, revaluation)
+	}
+
+	resp.NextCursor = page.next()
+	return resp, rows.Err()
+}
+
+// GetFull returns revaluation with remaining of every branch before and after it
+func (r *revaluationRepo) GetFull(req *models.RevaluationPrimaryKey) (*models.RevaluationFull, error) {
+	revaluation, err := r.GetByID(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &models.RevaluationFull{
+		Revaluation: revaluation,
+		Lines:       make([]*models.RevaluationLine, 0),
+	}
+
+	query := `
+		SELECT
+			rl."id",
+			rl."branch_id",
+			b."name",
+			rl."count",
+			rl."old_price",
+			rl."new_price",
+			rl."old_total",
+			rl."new_total",
+			rl."difference"
+		FROM "revaluation_line" AS rl
+		LEFT JOIN "branch" AS b ON b."id" = rl."branch_id"
+		WHERE rl."revaluation_id" = $1
+		ORDER BY b."name"
+	`
+
+	rows, err := r.db.Query(context.Background(), query, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id          sql.NullString
+			branch_id   sql.NullString
+			branch_name sql.NullString
+			count       int
+			old_price   sql.NullFloat64
+			new_price   sql.NullFloat64
+			old_total   sql.NullFloat64
+			new_total   sql.NullFloat64
+			difference  sql.NullFloat64
+		)
+
+		err := rows.Scan(
+			&id,
+			&branch_id,
+			&branch_name,
+			&count,
+			&old_price,
+			&new_price,
+			&old_total,
+			&new_total,
+			&difference,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		resp.Count += count
+		resp.Lines = append(resp.Lines, &models.RevaluationLine{
+			Id:         id.String,
+			BranchId:   branch_id.String,
+			BranchName: branch_name.String,
+			Count:      count,
+			OldPrice:   old_price.Float64,
+			NewPrice:   new_price.Float64,
+			OldTotal:   old_total.Float64,
+			NewTotal:   new_total.Float64,
+			Difference: difference.Float64,
+		})
+	}
+
+	return resp, rows.Err()
+}
+
+func scanRevaluation(row pgx.Row, before ...interface{}) (*models.Revaluation, error) {
+	var (
+		id         sql.NullString
+		product_id sql.NullString
+		barcode    sql.NullString
+		name       sql.NullString
+		old_price  sql.NullFloat64
+		new_price  sql.NullFloat64
+		difference sql.NullFloat64
+		source     sql.NullString
+		created_by sql.NullString
+		created_at sql.NullString
+	)
+
+	err := row.Scan(append(before,
+		&id,
+		&product_id,
+		&barcode,
+		&name,
+		&old_price,
+		&new_price,
+		&difference,
+		&source,
+		&created_by,
+		&created_at,
+	)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Revaluation{
+		Id:         id.String,
+		ProductId:  product_id.String,
+		Barcode:    barcode.String,
+		Name:       name.String,
+		OldPrice:   old_price.Float64,
+		NewPrice:   new_price.Float64,
+		Difference: difference.Float64,
+		Source:     source.String,
+		CreatedBy:  created_by.String,
+		CreatedAt:  created_at.String,
+	}, nil
+}
@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jackc/pgx/v4"
+)
+
+const (
+	movementIncome         = "income"
+	movementIncomeReversal = "income_reversal"
+	movementAdjustment     = "adjustment"
+	movementRevaluation    = "revaluation"
+)
+
+// applyStockMovements adds count and total_price of document movements to remaining of the branch,
+// remaining is inserted or updated by one statement so concurrent documents can not duplicate it.
+// Movements dated back are added to snapshots of the branch taken after them too
+func applyStockMovements(ctx context.Context, tx pgx.Tx, documentId, movementType string) error {
+	query := `
+		INSERT INTO "remaining"(
+			"id",
+			"branch_id",
+			"category_id",
+			"name",
+			"price",
+			"barcode",
+			"count",
+			"total_price",
+			"product_id",
+			"created_at")
+		SELECT
+			gen_random_uuid(),
+			m."branch_id",
+			m."category_id",
+			m."name",
+			m."price",
+			m."barcode",
+			m."count",
+			m."total_price",
+			m."product_id",
+			NOW()
+		FROM "stock_movement" AS m
+		WHERE m."document_id" = $1 AND m."type" = $2
+		ON CONFLICT ("branch_id", "barcode") DO UPDATE
+		SET
+			"count" = "remaining"."count" + EXCLUDED."count",
+			"total_price" = "remaining"."total_price" + EXCLUDED."total_price",
+			"product_id" = COALESCE(EXCLUDED."product_id", "remaining"."product_id"),
+			"updated_at" = NOW()
+	`
+
+	_, err := tx.Exec(ctx, query, documentId, movementType)
+	if err != nil {
+		return constraintError(err)
+	}
+
+	query = `
+		INSERT INTO "stock_snapshot"(
+			"id",
+			"branch_id",
+			"category_id",
+			"name",
+			"price",
+			"barcode",
+			"count",
+			"total_price",
+			"period_end",
+			"created_at")
+		SELECT
+			gen_random_uuid(),
+			m."branch_id",
+			m."category_id",
+			m."name",
+			m."price",
+			m."barcode",
+			m."count",
+			COALESCE(m."total_price", 0),
+			s."period_end",
+			NOW()
+		FROM "stock_movement" AS m
+		JOIN (
+			SELECT DISTINCT "branch_id", "period_end" FROM "stock_snapshot"
+		) AS s ON s."branch_id" = m."branch_id" AND s."period_end" > m."date_time"
+		WHERE m."document_id" = $1 AND m."type" = $2
+		ON CONFLICT ("period_end", "branch_id", "barcode") DO UPDATE
+		SET
+			"count" = "stock_snapshot"."count" + EXCLUDED."count",
+			"total_price" = COALESCE("stock_snapshot"."total_price", 0) + EXCLUDED."total_price"
+	`
+
+	_, err = tx.Exec(ctx, query, documentId, movementType)
+	return err
+}
+
+// adjustStock writes adjustment movement of count and total_price added to remaining row by hand,
+// so stock rebuilt from movements agrees with remaining. Nothing is written when nothing is added,
+// current month of the branch must be open
+func adjustStock(ctx context.Context, tx pgx.Tx, remainingId string, count, totalPrice float64) error {
+	var branchId sql.NullString
+
+	if count == 0 && totalPrice == 0 {
+		return nil
+	}
+
+	err := tx.QueryRow(ctx, `SELECT "branch_id" FROM "remaining" WHERE "id" = $1`, remainingId).Scan(&branchId)
+	if err != nil {
+		return err
+	}
+
+	// adjustment is dated now, so it can not be written into closed month
+	err = checkPeriod(ctx, tx, branchId.String, "")
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO "stock_movement"(
+			"id",
+			"branch_id",
+			"category_id",
+			"name",
+			"price",
+			"barcode",
+			"count",
+			"total_price",
+			"product_id",
+			"type",
+			"document_id",
+			"date_time",
+			"created_at")
+		SELECT
+			gen_random_uuid(),
+			"branch_id",
+			"category_id",
+			"name",
+			"price",
+			"barcode",
+			$2,
+			$3,
+			"product_id",
+			$4,
+			"id",
+			NOW(),
+			NOW()
+		FROM "remaining"
+		WHERE "id" = $1
+	`
+
+	_, err = tx.Exec(ctx, query, remainingId, count, totalPrice, movementAdjustment)
+	return err
+}
@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"market/models"
+	"time"
+)
+
+// stockAsOf builds rows shaped like "remaining" from the latest stock snapshot of every branch taken not later than
+// the bound plus stock movements after that snapshot and before the bound, bound is named param of the query
+func stockAsOf(bound string) string {
+	return `(
+		WITH "last_snapshot" AS (
+			SELECT
+				"branch_id",
+				MAX("period_end") AS "period_end"
+			FROM "stock_snapshot"
+			WHERE "period_end" <= ` + bound + `
+			GROUP BY "branch_id"
+		),
+		"balance" AS (
+			SELECT
+				s."branch_id",
+				s."barcode",
+				s."category_id",
+				s."name",
+				s."price",
+				s."count",
+				s."total_price",
+				s."period_end" AS "date_time"
+			FROM "stock_snapshot" AS s
+			JOIN "last_snapshot" AS ls ON ls."branch_id" = s."branch_id" AND ls."period_end" = s."period_end"
+			UNION ALL
+			SELECT
+				m."branch_id",
+				m."barcode",
+				m."category_id",
+				m."name",
+				m."price",
+				m."count",
+				m."total_price",
+				m."date_time"
+			FROM "stock_movement" AS m
+			LEFT JOIN "last_snapshot" AS ls ON ls."branch_id" = m."branch_id"
+			WHERE m."date_time" < ` + bound + ` AND (ls."period_end" IS NULL OR m."date_time" >= ls."period_end")
+		),
+		"total" AS (
+			SELECT
+				"branch_id",
+				"barcode",
+				(ARRAY_AGG("category_id" ORDER BY "date_time" DESC))[1] AS "category_id",
+				(ARRAY_AGG("name" ORDER BY "date_time" DESC))[1] AS "name",
+				(ARRAY_AGG("price" ORDER BY "date_time" DESC))[1] AS "price",
+				SUM("count") AS "count",
+				COALESCE(SUM("total_price"), 0) AS "total_price"
+			FROM "balance"
+			GROUP BY "branch_id", "barcode"
+			HAVING SUM("count") <> 0 OR COALESCE(SUM("total_price"), 0) <> 0
+		)
+		SELECT
+			COALESCE(cur."id"::text, '') AS "id",
+			t."branch_id",
+			t."category_id",
+			t."name",
+			t."price",
+			t."barcode",
+			t."count",
+			t."total_price",
+			cur."created_at",
+			cur."updated_at",
+			COALESCE(cur."product_id", ` + productIdByBarcode(`t."barcode"`) + `) AS "product_id",
+			COALESCE(cur."version", 0) AS "version"
+		FROM "total" AS t
+		LEFT JOIN "remaining" AS cur ON cur."branch_id" = t."branch_id" AND cur."barcode" = t."barcode"
+	)`
+}
+
+// remainingSource is "remaining" table or its reconstruction at req.AsOf, both have the same columns
+// and both have name and category resolved from linked product
+func remainingSource(req *models.RemainingGetListRequest, params map[string]interface{}) string {
+	if req.AsOf.IsZero() {
+		return remainingResolved(`"remaining"`) + ` AS "remaining"`
+	}
+
+	// timestamps of postgres have microsecond precision, so as_of itself is included
+	params["as_of"] = req.AsOf.Add(time.Microsecond)
+	return remainingResolved(stockAsOf(":as_of")) + ` AS "remaining"`
+}
+
+// Snapshot replaces closing balances of all branches before period end, recalculating it is safe
+func (r *remainingRepo) Snapshot(req *models.StockSnapshotRequest) (int64, error) {
+	ctx := context.Background()
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	// posting adds movements dated back to snapshots, so it waits for the snapshot to be replaced or the other way round
+	_, err = tx.Exec(ctx, `LOCK TABLE "stock_snapshot" IN SHARE ROW EXCLUSIVE MODE`)
+	if err != nil {
+		return 0, err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM "stock_snapshot" WHERE "period_end" = $1`, req.PeriodEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	query := `
+		INSERT INTO "stock_snapshot"(
+			"id",
+			"branch_id",
+			"category_id",
+			"name",
+			"price",
+			"barcode",
+			"count",
+			"total_price",
+			"period_end",
+			"created_at")
+		SELECT
+			gen_random_uuid(),
+			a."branch_id",
+			a."category_id",
+			a."name",
+			a."price",
+			a."barcode",
+			a."count",
+			a."total_price",
+			$1,
+			NOW()
+		FROM ` + stockAsOf("$1") + ` AS a
+		WHERE a."branch_id" IS NOT NULL
+	`
+
+	result, err := tx.Exec(ctx, query, req.PeriodEnd)
+	if err != nil {
+		return 0, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), nil
+}
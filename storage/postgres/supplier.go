@@ -0,0 +1,335 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"market/models"
+	"market/pkg/helper"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type supplierRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewSupplierRepo(db *pgxpool.Pool) *supplierRepo {
+	return &supplierRepo{
+		db: db,
+	}
+}
+
+func (r *supplierRepo) Create(req *models.CreateSupplier) (string, error) {
+
+	var (
+		id    = uuid.NewString()
+		query string
+	)
+
+	query = `
+		INSERT INTO "supplier"(
+			"id", 
+			"name",
+			"address",
+			"phone_number",
+			"created_at" )
+		VALUES ($1, $2, $3, $4, NOW())`
+
+	_, err := r.db.Exec(context.Background(), query,
+		id,
+		req.Name,
+		req.Address,
+		req.PhoneNumber,
+	)
+
+	if err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+func (r *supplierRepo) GetByID(req *models.SupplierPrimaryKey) (*models.Supplier, error) {
+
+	var (
+		id          sql.NullString
+		name        sql.NullString
+		address     sql.NullString
+		phoneNumber sql.NullString
+		createdAt   sql.NullString
+		updatedAt   sql.NullString
+	)
+
+	query := `
+		SELECT
+			"id", 
+			"name",
+			"address",
+			"phone_number",
+			"created_at",
+			"updated_at" 
+		FROM "supplier"
+		WHERE id = $1
+	`
+
+	err := r.db.QueryRow(context.Background(), query, req.Id).Scan(
+		&id,
+		&name,
+		&address,
+		&phoneNumber,
+		&createdAt,
+		&updatedAt,
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Supplier{
+		Id:          id.String,
+		Name:        name.String,
+		Address:     address.String,
+		PhoneNumber: phoneNumber.String,
+		CreatedAt:   createdAt.String,
+		UpdatedAt:   updatedAt.String,
+	}, nil
+}
+
+// supplierList are sorts and filters of supplier list, sorts by created_at or name
+var supplierList = listSpec{
+	key:      listColumn{`"id"`, "uuid"},
+	fallback: "-created_at",
+	sorts: map[string][]listColumn{
+		"created_at": {{`"created_at"`, "timestamp"}},
+		"name":       {{`"name"`, "text"}},
+	},
+	filters: map[string]listColumn{
+		"name":         {`"name"`, "text"},
+		"address":      {`"address"`, "text"},
+		"phone_number": {`"phone_number"`, "text"},
+		"created_at":   {`"created_at"`, "timestamp"},
+		"updated_at":   {`"updated_at"`, "timestamp"},
+	},
+	date: "created_at",
+}
+
+func (r *supplierRepo) GetList(req *models.SupplierGetListRequest) (*models.SupplierGetListResponse, error) {
+	page, err := newListPage(supplierList, req.Page, req.Limit, req.ListOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]interface{})
+	var resp = &models.SupplierGetListResponse{}
+
+	resp.Suppliers = make([]*models.Supplier, 0)
+
+	filter := " WHERE true "
+	query := `
+			SELECT
+				` + page.countColumn() + `,
+				"id", 
+				"name",
+				"address",
+				"phone_number",
+				"created_at",
+				"updated_at" 
+			FROM "supplier"
+		`
+	if req.Search != "" {
+		filter += ` AND "name" ILIKE '%' || :search || '%' `
+		params["search"] = req.Search
+	}
+
+	where, err := listWhere(supplierList, req.ListOptions, params)
+	if err != nil {
+		return nil, err
+	}
+	filter += where
+
+	query = page.query(query+filter, params)
+	rquery, pArr := helper.ReplaceQueryParams(query, params)
+
+	rows, err := r.db.Query(context.Background(), rquery, pArr...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			id          sql.NullString
+			name        sql.NullString
+			address     sql.NullString
+			phoneNumber sql.NullString
+			createdAt   sql.NullString
+			updatedAt   sql.NullString
+		)
+		err := rows.Scan(page.dest(
+			&resp.Count,
+			&id,
+			&name,
+			&address,
+			&phoneNumber,
+			&createdAt,
+			&updatedAt,
+		)...)
+		if err != nil {
+			return nil, err
+		}
+		resp.Suppliers = append(resp.Suppliers, &models.Supplier{
+			Id:          id.String,
+			Name:        name.String,
+			Address:     address.String,
+			PhoneNumber: phoneNumber.String,
+			CreatedAt:   createdAt.String,
+			UpdatedAt:   updatedAt.String,
+		})
+	}
+	resp.NextCursor = page.next()
+	return resp, nil
+
+}
+
+func (r *supplierRepo) Update(req *models.UpdateSupplier) (string, error) {
+
+	var (
+		query  string
+		params map[string]interface{}
+	)
+
+	query = `
+		UPDATE
+			"supplier"
+		SET
+			"name" = :name,
+			"address" = :address,
+			"phone_number" = :phone_number,
+			"updated_at" = NOW()
+		WHERE id = :id
+	`
+
+	params = map[string]interface{}{
+		"id":           req.Id,
+		"name":         req.Name,
+		"address":      req.Address,
+		"phone_number": req.PhoneNumber,
+	}
+
+	query, args := helper.ReplaceQueryParams(query, params)
+
+	result, err := r.db.Exec(context.Background(), query, args...)
+	if err != nil {
+		return "", err
+	}
+
+	if result.RowsAffected() == 0 {
+		return "", fmt.Errorf("supplier with ID %s not found", req.Id)
+	}
+
+	return req.Id, nil
+}
+
+func (r *supplierRepo) Delete(req *models.SupplierPrimaryKey) error {
+	ctx := context.Background()
+
+	result, err := r.db.Exec(ctx, "DELETE FROM supplier WHERE id = $1", req.Id)
+	if err != nil {
+		return err
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("supplier with ID %s not found", req.Id)
+
+	}
+
+	return nil
+}
+
+// GetImportTemplate returns saved delivery note template of supplier,
+// suppliers without one get default barcode, count and price columns
+func (r *supplierRepo) GetImportTemplate(req *models.SupplierPrimaryKey) (*models.SupplierImportTemplate, error) {
+
+	var (
+		barcodeColumn sql.NullString
+		countColumn   sql.NullString
+		priceColumn   sql.NullString
+		headerRow     sql.NullInt64
+		createdAt     sql.NullString
+		updatedAt     sql.NullString
+	)
+
+	query := `
+		SELECT
+			"barcode_column",
+			"count_column",
+			"price_column",
+			"header_row",
+			"created_at",
+			"updated_at"
+		FROM "supplier_import_template"
+		WHERE "supplier_id" = $1
+	`
+
+	err := r.db.QueryRow(context.Background(), query, req.Id).Scan(
+		&barcodeColumn,
+		&countColumn,
+		&priceColumn,
+		&headerRow,
+		&createdAt,
+		&updatedAt,
+	)
+	if err == pgx.ErrNoRows {
+		return models.DefaultSupplierImportTemplate(req.Id), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SupplierImportTemplate{
+		SupplierId:    req.Id,
+		BarcodeColumn: barcodeColumn.String,
+		CountColumn:   countColumn.String,
+		PriceColumn:   priceColumn.String,
+		HeaderRow:     int(headerRow.Int64),
+		CreatedAt:     createdAt.String,
+		UpdatedAt:     updatedAt.String,
+	}, nil
+}
+
+func (r *supplierRepo) SaveImportTemplate(req *models.SupplierImportTemplate) (string, error) {
+
+	query := `
+		INSERT INTO "supplier_import_template"(
+			"supplier_id",
+			"barcode_column",
+			"count_column",
+			"price_column",
+			"header_row",
+			"created_at")
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		ON CONFLICT ("supplier_id") DO UPDATE
+		SET
+			"barcode_column" = EXCLUDED."barcode_column",
+			"count_column" = EXCLUDED."count_column",
+			"price_column" = EXCLUDED."price_column",
+			"header_row" = EXCLUDED."header_row",
+			"updated_at" = NOW()
+	`
+
+	_, err := r.db.Exec(context.Background(), query,
+		req.SupplierId,
+		req.BarcodeColumn,
+		req.CountColumn,
+		helper.NewNullString(req.PriceColumn),
+		req.HeaderRow,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return req.SupplierId, nil
+}
@@ -0,0 +1,24 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"market/storage"
+)
+
+// versionError explains why versioned update of row of table matched nothing: it is missing or its version
+// is not expected one, nil means the row is there with expected version or expected is 0
+func versionError(ctx context.Context, q querier, table, id string, expected int) error {
+	var version int
+
+	err := q.QueryRow(ctx, `SELECT "version" FROM "`+table+`" WHERE "id" = $1`, id).Scan(&version)
+	if err != nil {
+		return fmt.Errorf("%s with ID %s not found", table, id)
+	}
+
+	if expected != 0 && version != expected {
+		return &storage.VersionError{Table: table, Id: id, Version: version, Expected: expected}
+	}
+
+	return nil
+}
@@ -10,6 +10,18 @@ type StorageI interface {
 	ComingTable() ComingTableRepoI
 	ComingTableProduct() ComingTableProductRepoI
 	Remaining() RemainingRepoI
+	Supplier() SupplierRepoI
+	PurchaseOrder() PurchaseOrderRepoI
+	Label() LabelRepoI
+	Report() ReportRepoI
+	PeriodClosing() PeriodClosingRepoI
+	ProductPrice() ProductPriceRepoI
+	Promotion() PromotionRepoI
+	Revaluation() RevaluationRepoI
+	Consistency() ConsistencyRepoI
+	Idempotency() IdempotencyRepoI
+	CategoryAttribute() CategoryAttributeRepoI
+	ProductImage() ProductImageRepoI
 }
 
 type BranchRepoI interface {
@@ -28,14 +40,34 @@ type CategoryRepoI interface {
 	Delete(*models.CategoryPrimaryKey) error
 }
 
+type CategoryAttributeRepoI interface {
+	Create(*models.CreateCategoryAttribute) (string, error)
+	GetList(*models.CategoryAttributeGetListRequest) (*models.CategoryAttributeGetListResponse, error)
+	Update(*models.UpdateCategoryAttribute) (string, error)
+	Delete(*models.CategoryAttributePrimaryKey) error
+}
+
 type ProductRepoI interface {
 	Create(*models.CreateProduct) (string, error)
 	GetByID(*models.ProductPrimaryKey) (*models.Product, error)
 	GetList(*models.ProductGetListRequest) (*models.ProductGetListResponse, error)
 	Update(*models.UpdateProduct) (string, error)
 	Delete(*models.ProductPrimaryKey) error
+	Export(*models.ProductGetListRequest, func(*models.Product, *models.ExportNames) error) error
 
 	GetByBarcode(req *models.ProductBarcodeRequest) (*models.ProductBarcodeResponse, error)
+	Approve(*models.ProductPrimaryKey) error
+	Merge(*models.MergeProduct) error
+	Import(*models.ProductImportRequest) (*models.ProductImportResponse, error)
+	Search(*models.ProductSearchRequest) (*models.ProductSearchResponse, error)
+	CreateVariant(*models.CreateProductVariant) (string, error)
+}
+
+type ProductImageRepoI interface {
+	Create(*models.CreateProductImage) (string, error)
+	GetByID(*models.ProductImagePrimaryKey) (*models.ProductImage, error)
+	GetList(*models.ProductPrimaryKey) (*models.ProductImageGetListResponse, error)
+	Delete(*models.ProductImagePrimaryKey) error
 }
 
 type ComingTableRepoI interface {
@@ -43,10 +75,15 @@ type ComingTableRepoI interface {
 	GetByID(*models.ComingTablePrimaryKey) (*models.ComingTable, error)
 	GetList(*models.ComingTableGetListRequest) (*models.ComingTableGetListResponse, error)
 	Update(*models.UpdateComingTable) (string, error)
-	UpdateStatus(*models.ComingTablePrimaryKey) (string, error)
+	UpdateStatus(*models.UpdateComingTableStatus) (string, error)
 	Delete(*models.ComingTablePrimaryKey) error
+	Export(*models.ComingTableGetListRequest, func(*models.ComingTable, *models.ExportNames) error) error
 
+	GetFull(*models.ComingTablePrimaryKey) (*models.ComingTableFull, error)
 	GetStatus(*models.ComingTablePrimaryKey) (string, error)
+	StartReceiving(*models.ComingTablePrimaryKey) error
+	Post(*models.ComingTablePrimaryKey) error
+	Reverse(*models.ComingTablePrimaryKey) error
 }
 
 type ComingTableProductRepoI interface {
@@ -55,9 +92,11 @@ type ComingTableProductRepoI interface {
 	GetList(*models.ComingTableProductGetListRequest) (*models.ComingTableProductGetListResponse, error)
 	Update(*models.UpdateComingTableProduct) (string, error)
 	Delete(*models.ComingTableProductPrimaryKey) error
+	Export(*models.ComingTableProductGetListRequest, func(*models.ComingTableProduct, *models.ExportNames) error) error
 
-	CheckExistProduct(*models.ComingTableProductBarcode) (string, error)
-	UpdateIdExists(req *models.UpdateComingTableProduct) (string, error)
+	CreateBulk(*models.CreateComingTableProductBulk) (*models.ComingTableProductBulkResponse, error)
+	CreateWithProduct(*models.CreateComingTableProduct) (string, error)
+	Upsert(*models.CreateComingTableProduct) (string, bool, error)
 	GetByComingTableId(req *models.ComingTableProductPrimaryKey) (*models.ComingTableProduct, error)
 }
 
@@ -67,7 +106,88 @@ type RemainingRepoI interface {
 	GetList(*models.RemainingGetListRequest) (*models.RemainingGetListResponse, error)
 	Update(*models.UpdateRemaining) (string, error)
 	Delete(*models.RemainingPrimaryKey) error
+	Export(*models.RemainingGetListRequest, func(*models.Remaining, *models.ExportNames) error) error
+
+	Upsert(*models.CreateRemaining) (string, error)
+	Snapshot(*models.StockSnapshotRequest) (int64, error)
+}
+
+type SupplierRepoI interface {
+	Create(*models.CreateSupplier) (string, error)
+	GetByID(*models.SupplierPrimaryKey) (*models.Supplier, error)
+	GetList(*models.SupplierGetListRequest) (*models.SupplierGetListResponse, error)
+	Update(*models.UpdateSupplier) (string, error)
+	Delete(*models.SupplierPrimaryKey) error
+
+	GetImportTemplate(*models.SupplierPrimaryKey) (*models.SupplierImportTemplate, error)
+	SaveImportTemplate(*models.SupplierImportTemplate) (string, error)
+}
+
+type PurchaseOrderRepoI interface {
+	Create(*models.CreatePurchaseOrder) (string, error)
+	GetByID(*models.PurchaseOrderPrimaryKey) (*models.PurchaseOrder, error)
+	GetList(*models.PurchaseOrderGetListRequest) (*models.PurchaseOrderGetListResponse, error)
+	Update(*models.UpdatePurchaseOrder) (string, error)
+	Delete(*models.PurchaseOrderPrimaryKey) error
+
+	CreateComingTable(*models.CreateComingTableFromOrder) (string, error)
+	GetProgress(*models.PurchaseOrderProgressRequest) (*models.PurchaseOrderProgress, error)
+}
+
+type LabelRepoI interface {
+	GetTemplate(*models.BranchPrimaryKey) (*models.LabelTemplate, error)
+	SaveTemplate(*models.LabelTemplate) (string, error)
+	GetItems(*models.LabelRequest) ([]*models.LabelItem, error)
+}
+
+type ReportRepoI interface {
+	Income(*models.ReportRequest) (*models.IncomeReportResponse, error)
+	Stock(*models.ReportRequest) (*models.StockReportResponse, error)
+	TopProducts(*models.ReportRequest) (*models.TopProductsResponse, error)
+	Movements(*models.ReportRequest) (*models.MovementReportResponse, error)
+	Promotions(*models.ReportRequest) (*models.PromotionReportResponse, error)
+}
+
+type PeriodClosingRepoI interface {
+	Close(*models.PeriodClosingRequest) error
+	Reopen(*models.PeriodClosingRequest) error
+	GetList(*models.PeriodClosingGetListRequest) (*models.PeriodClosingGetListResponse, error)
+	GetLog(*models.PeriodClosingGetListRequest) (*models.PeriodClosingLogResponse, error)
+}
+
+type ProductPriceRepoI interface {
+	Create(*models.CreateProductPrice) (string, error)
+	GetList(*models.ProductPriceGetListRequest) (*models.ProductPriceGetListResponse, error)
+	Delete(*models.ProductPricePrimaryKey) error
+	Effective(*models.EffectivePriceRequest) (*models.EffectivePrice, error)
+	ApplyDue() (int64, error)
+}
+
+type PromotionRepoI interface {
+	Create(*models.CreatePromotion) (string, error)
+	GetByID(*models.PromotionPrimaryKey) (*models.Promotion, error)
+	GetList(*models.PromotionGetListRequest) (*models.PromotionGetListResponse, error)
+	Update(*models.UpdatePromotion) (string, error)
+	Delete(*models.PromotionPrimaryKey) error
+
+	Active(*models.ActivePromotionRequest) ([]*models.Promotion, error)
+	Basket(*models.BasketRequest) ([]*models.BasketLine, error)
+	SaveUsage(*models.BasketRequest, *models.BasketResponse) error
+}
+
+type RevaluationRepoI interface {
+	GetByID(*models.RevaluationPrimaryKey) (*models.Revaluation, error)
+	GetList(*models.RevaluationGetListRequest) (*models.RevaluationGetListResponse, error)
+	GetFull(*models.RevaluationPrimaryKey) (*models.RevaluationFull, error)
+}
+
+type ConsistencyRepoI interface {
+	Check(*models.ConsistencyRequest) (*models.ConsistencyReport, error)
+}
 
-	CheckRemaing(*models.CheckingRemaining) (string, error)
-	UpdateExists(req *models.UpdateRemaining) (string, error)
+type IdempotencyRepoI interface {
+	Begin(*models.IdempotencyKey) (*models.IdempotencyKey, bool, error)
+	Finish(*models.IdempotencyKey) error
+	Release(*models.IdempotencyKey) error
+	DeleteExpired() (int64, error)
 }